@@ -11,10 +11,10 @@ type Counters struct {
 	LastRtt   time.Duration `json:"lastRtt"`
 	SRtt      time.Duration `json:"sRtt"`
 	Rto       time.Duration `json:"rto"`
-	Cwnd      int           `json:"cwnd"`
-	NInFlight uint32        `json:"nInFlight"` // number of in-flight Interests
-	NTxRetx   uint64        `json:"nTxRetx"`   // number of retransmitted Interests
-	NRxData   uint64        `json:"nRxData"`   // number of Data satisfying pending Interests
+	Cwnd      int           `json:"cwnd" openmetrics:"gauge"`
+	NInFlight uint32        `json:"nInFlight" openmetrics:"gauge"` // number of in-flight Interests
+	NTxRetx   uint64        `json:"nTxRetx"`                       // number of retransmitted Interests
+	NRxData   uint64        `json:"nRxData"`                       // number of Data satisfying pending Interests
 }
 
 // Counters retrieves counters.
@@ -8,6 +8,7 @@ import "C"
 import (
 	"errors"
 	"math"
+	"sync"
 	"unsafe"
 
 	mathpkg "github.com/pkg/math"
@@ -70,6 +71,7 @@ func (w worker) face() iface.Face {
 type Fetcher struct {
 	workers      []*worker
 	fp           []*C.FetchProc
+	mutex        sync.Mutex // protects nActiveProcs
 	nActiveProcs int
 }
 
@@ -110,6 +112,7 @@ func New(face iface.Face, cfg FetcherConfig) (*Fetcher, error) {
 			return nil, e
 		}
 		fp.pitToken = C.uint8_t(i)
+		fp.fd = -1
 		fetcher.fp[i] = fp
 		fetcher.Logic(i).Init(cfg.WindowCapacity, socket)
 	}
@@ -129,15 +132,22 @@ func (fetcher *Fetcher) Reset() {
 	for _, fth := range fetcher.workers {
 		fth.c.head.next = nil
 	}
-	for i := range fetcher.fp {
+	for i, fp := range fetcher.fp {
+		fp.fd, fp.segmentLen, fp.nWriteErrs = -1, 0, 0
 		fetcher.Logic(i).Reset()
 	}
+
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
 	fetcher.nActiveProcs = 0
 }
 
 // AddTemplate sets name prefix and other InterestTemplate arguments.
 // Return index of fetch procedure.
 func (fetcher *Fetcher) AddTemplate(tplCfg ndni.InterestTemplateConfig) (i int, e error) {
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
+
 	i = fetcher.nActiveProcs
 	if i >= len(fetcher.fp) {
 		return -1, errors.New("too many templates")
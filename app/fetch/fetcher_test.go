@@ -1,15 +1,23 @@
 package fetch_test
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	mathpkg "github.com/pkg/math"
 	"github.com/usnistgov/ndn-dpdk/app/fetch"
 	"github.com/usnistgov/ndn-dpdk/app/tg/tgtestenv"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr/ndn6file"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
@@ -70,3 +78,75 @@ func TestFetcher(t *testing.T) {
 	assert.GreaterOrEqual(nInterests, 5000)
 	assert.Less(nInterests, 6000)
 }
+
+func TestFetchFile(t *testing.T) {
+	assert, require := makeAR(t)
+
+	intFace := intface.MustNew()
+	defer intFace.D.Close()
+
+	fetcher, e := fetch.New(intFace.D, fetch.FetcherConfig{
+		NThreads:       1,
+		NProcs:         1,
+		WindowCapacity: 1024,
+	})
+	require.NoError(e)
+	tgtestenv.Open(t, fetcher)
+	defer fetcher.Close()
+
+	const segmentLen = 3000
+	content := make([]byte, 100*segmentLen+1000)
+	rand.Read(content)
+	finalSeg := tlv.NNI(len(content) / segmentLen)
+
+	versioned := ndn.ParseName("/F").Append(ndn.NameComponentFrom(an.TtVersionNameComponent, tlv.NNI(1)))
+	m := ndn6file.Metadata{
+		Metadata:    rdr.Metadata{Name: versioned},
+		FinalBlock:  ndn.NameComponentFrom(an.TtSegmentNameComponent, finalSeg),
+		SegmentSize: segmentLen,
+		Size:        int64(len(content)),
+		Mode:        0x8000 | 0644,
+	}
+
+	go func() {
+		for packet := range intFace.Rx {
+			interest := packet.Interest
+			require.NotNil(interest)
+			if rdr.IsDiscoveryInterest(*interest) {
+				payload, e := m.MarshalBinary()
+				require.NoError(e)
+				intFace.Tx <- ndn.MakeData(interest,
+					interest.Name.Append(ndn.NameComponentFrom(an.TtVersionNameComponent, tlv.NNI(2)),
+						ndn.NameComponentFrom(an.TtSegmentNameComponent, tlv.NNI(0))), payload)
+				continue
+			}
+
+			require.True(versioned.IsPrefixOf(interest.Name))
+			var seg tlv.NNI
+			require.NoError(seg.UnmarshalBinary(interest.Name.Get(-1).Value))
+			require.LessOrEqual(seg, finalSeg)
+			if rand.Float64() > 0.01 {
+				offset := int(seg) * segmentLen
+				intFace.Tx <- ndn.MakeData(interest, content[offset:mathpkg.MinInt(offset+segmentLen, len(content))])
+			}
+		}
+		close(intFace.Tx)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	filename := filepath.Join(t.TempDir(), "F.bin")
+	res, e := fetcher.FetchFile(ctx, fetch.FileTask{
+		Name:     ndn.ParseName("/F"),
+		Filename: filename,
+	})
+	require.NoError(e)
+	assert.True(res.Name.Equal(versioned))
+	assert.EqualValues(len(content), res.Size)
+	assert.EqualValues(finalSeg, res.FinalSegNum)
+	assert.Greater(res.Throughput, 0.0)
+
+	written, e := os.ReadFile(filename)
+	require.NoError(e)
+	assert.Equal(content, written)
+}
@@ -0,0 +1,217 @@
+package fetch
+
+/*
+#include "../../csrc/fetch/fetcher.h"
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr/ndn6file"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+const (
+	// DefaultMetadataLifetime is the default InterestLifetime of RDR discovery Interest.
+	DefaultMetadataLifetime = time.Second
+
+	metadataRetxLimit = 3
+	pollInterval      = time.Millisecond
+)
+
+var (
+	errMetadataTimeout = errors.New("RDR metadata timeout")
+	errMetadataNack    = errors.New("RDR metadata Nack")
+	errNotFile         = errors.New("RDR metadata does not describe a file")
+	errSegmentLen      = fmt.Errorf("SegmentSize must be between 1 and %d", C.FetchMaxSegmentLen)
+	errWriteFile       = errors.New("error writing file")
+)
+
+// FileTask describes a file retrieval via ndn6-file-server protocol.
+type FileTask struct {
+	// Name is the file name prefix, without version and segment components.
+	Name ndn.Name `json:"name"`
+
+	// Filename is the local path to write the file.
+	Filename string `json:"filename"`
+
+	// InterestLifetime is the InterestLifetime of segment Interests and RDR discovery Interest.
+	InterestLifetime nnduration.Milliseconds `json:"interestLifetime,omitempty"`
+
+	// HopLimit is the HopLimit of segment Interests.
+	HopLimit ndn.HopLimit `json:"hopLimit,omitempty"`
+}
+
+// FileResult contains outcome of a file retrieval.
+type FileResult struct {
+	Name        ndn.Name      `json:"name"` // versioned name
+	Filename    string        `json:"filename"`
+	Size        int64         `json:"size"`
+	SegmentLen  int           `json:"segmentLen"`
+	FinalSegNum uint64        `json:"finalSegNum"`
+	Duration    time.Duration `json:"duration"`
+	Throughput  float64       `json:"throughput"` // payload bits per second
+	Counters    Counters      `json:"counters"`
+}
+
+// RetrieveMetadata retrieves ndn6-file-server metadata via RDR discovery.
+// The fetcher must be stopped. The discovery Interest is sent with the PIT token of the first
+// fetch procedure, and the reply is taken from its RxQueue.
+func (fetcher *Fetcher) RetrieveMetadata(ctx context.Context, name ndn.Name, lifetime time.Duration) (m ndn6file.Metadata, e error) {
+	if lifetime <= 0 {
+		lifetime = DefaultMetadataLifetime
+	}
+	interest := rdr.MakeDiscoveryInterest(name)
+
+	var tpl ndni.InterestTemplate
+	tpl.Init(interest.Name, ndn.CanBePrefixFlag, ndn.MustBeFreshFlag, lifetime)
+
+	face := fetcher.Face()
+	fp := fetcher.fp[0]
+	interestMp := ndni.InterestMempool.Get(face.NumaSocket())
+	q := fetcher.rxQueue(0)
+	vec := make(pktmbuf.Vector, iface.MaxBurstSize)
+
+	for i := 0; i < metadataRetxLimit; i++ {
+		mbufs, e := interestMp.Alloc(1)
+		if e != nil {
+			return ndn6file.Metadata{}, e
+		}
+		pkt := tpl.Encode(mbufs[0], nil, rand.Uint32())
+		pkt.SetPitToken([]byte{byte(fp.pitToken)})
+		iface.TxBurst(face.ID(), []*ndni.Packet{pkt})
+
+		deadline := time.Now().Add(lifetime)
+		for time.Now().Before(deadline) {
+			select {
+			case <-ctx.Done():
+				return ndn6file.Metadata{}, ctx.Err()
+			default:
+			}
+
+			count, _ := q.Pop(vec, eal.TscNow())
+			var data *ndn.Data
+			isNack := false
+			for _, mbuf := range vec[:count] {
+				switch npkt := ndni.PacketFromPtr(mbuf.Ptr()).ToNPacket(); {
+				case npkt.Data != nil && interest.Name.IsPrefixOf(npkt.Data.Name):
+					data = npkt.Data
+				case npkt.Nack != nil && interest.Name.Equal(npkt.Nack.Interest.Name):
+					isNack = true
+				}
+			}
+			vec[:count].Close()
+
+			switch {
+			case data != nil:
+				return decodeFileMetadata(*data)
+			case isNack:
+				return ndn6file.Metadata{}, errMetadataNack
+			}
+			time.Sleep(pollInterval)
+		}
+	}
+	return ndn6file.Metadata{}, errMetadataTimeout
+}
+
+func decodeFileMetadata(data ndn.Data) (m ndn6file.Metadata, e error) {
+	if data.ContentType != an.ContentBlob {
+		return m, ndn.ErrContentType
+	}
+	e = m.UnmarshalBinary(data.Content)
+	return m, e
+}
+
+// FetchFile retrieves a file via ndn6-file-server protocol and writes it to a local file.
+// If the fetcher is running, it is stopped and reset.
+//
+// This discovers the versioned name and file size via RDR metadata, fetches all segments with
+// the first fetch procedure, writes segment payloads at their offsets in the local file, and
+// verifies the final file size.
+func (fetcher *Fetcher) FetchFile(ctx context.Context, task FileTask) (res FileResult, e error) {
+	fetcher.Reset()
+	res.Filename = task.Filename
+
+	m, e := fetcher.RetrieveMetadata(ctx, task.Name, task.InterestLifetime.Duration())
+	if e != nil {
+		return res, e
+	}
+	if !m.IsFile() || !m.FinalBlock.Valid() || m.FinalBlock.Type != an.TtSegmentNameComponent {
+		return res, errNotFile
+	}
+	var finalSeg tlv.NNI
+	if e := finalSeg.UnmarshalBinary(m.FinalBlock.Value); e != nil {
+		return res, fmt.Errorf("FinalBlock: %w", e)
+	}
+	if m.SegmentSize <= 0 || m.SegmentSize > C.FetchMaxSegmentLen {
+		return res, errSegmentLen
+	}
+	res.Name, res.SegmentLen, res.FinalSegNum = m.Name, m.SegmentSize, uint64(finalSeg)
+
+	f, e := os.Create(task.Filename)
+	if e != nil {
+		return res, e
+	}
+	defer f.Close()
+
+	i, e := fetcher.AddTemplate(ndni.InterestTemplateConfig{
+		Prefix:           m.Name,
+		InterestLifetime: task.InterestLifetime,
+		HopLimit:         task.HopLimit,
+	})
+	if e != nil {
+		return res, e
+	}
+	fp, logic := fetcher.fp[i], fetcher.Logic(i)
+	fp.fd = C.int(f.Fd())
+	fp.segmentLen = C.uint32_t(m.SegmentSize)
+	logic.SetFinalSegNum(res.FinalSegNum)
+
+	t0 := time.Now()
+	fetcher.Launch()
+	ticker := time.NewTicker(pollInterval)
+WAIT:
+	for !logic.Finished() {
+		select {
+		case <-ctx.Done():
+			break WAIT
+		case <-ticker.C:
+		}
+	}
+	ticker.Stop()
+	fetcher.Stop()
+	res.Duration = time.Since(t0)
+	fp.fd = -1
+	res.Counters = logic.Counters()
+
+	if e := ctx.Err(); e != nil {
+		return res, e
+	}
+	if fp.nWriteErrs > 0 {
+		return res, fmt.Errorf("%w: %d segments failed", errWriteFile, fp.nWriteErrs)
+	}
+
+	st, e := f.Stat()
+	if e != nil {
+		return res, e
+	}
+	res.Size = st.Size()
+	if res.Size != m.Size {
+		return res, fmt.Errorf("file size mismatch: metadata %d, written %d", m.Size, res.Size)
+	}
+	res.Throughput = float64(res.Size*8) / res.Duration.Seconds()
+	return res, nil
+}
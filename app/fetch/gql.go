@@ -11,6 +11,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
 	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 )
 
@@ -21,6 +22,7 @@ var GqlRetrieveByFaceID func(id iface.ID) interface{}
 // GraphQL types.
 var (
 	GqlConfigInput     *graphql.InputObject
+	GqlFileTaskInput   *graphql.InputObject
 	GqlFetcherNodeType *gqlserver.NodeType
 	GqlFetcherType     *graphql.Object
 )
@@ -34,6 +36,15 @@ func init() {
 		}),
 	})
 
+	GqlFileTaskInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "FetchFileTaskInput",
+		Description: "File retrieval task.",
+		Fields: gqlserver.BindInputFields(FileTask{}, gqlserver.FieldTypes{
+			reflect.TypeOf(ndn.Name{}):                 gqlserver.NonNullString,
+			reflect.TypeOf(nnduration.Milliseconds(0)): nnduration.GqlMilliseconds,
+		}),
+	})
+
 	GqlFetcherNodeType = tggql.NewNodeType("Fetcher", (*Fetcher)(nil), &GqlRetrieveByFaceID)
 	GqlFetcherType = graphql.NewObject(GqlFetcherNodeType.Annotate(graphql.ObjectConfig{
 		Name:   "Fetcher",
@@ -114,4 +125,32 @@ func init() {
 			return result, nil
 		},
 	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "fetchFile",
+		Description: "Retrieve a file via ndn6-file-server protocol and write it to a local file.",
+		Args: graphql.FieldConfigArgument{
+			"fetcher": &graphql.ArgumentConfig{
+				Description: "Fetcher ID.",
+				Type:        gqlserver.NonNullID,
+			},
+			"task": &graphql.ArgumentConfig{
+				Description: "File retrieval task.",
+				Type:        graphql.NewNonNull(GqlFileTaskInput),
+			},
+		},
+		Type: gqlserver.NonNullJSON,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var fetcher *Fetcher
+			if e := gqlserver.RetrieveNodeOfType(GqlFetcherNodeType, p.Args["fetcher"], &fetcher); e != nil {
+				return nil, e
+			}
+
+			var task FileTask
+			if e := jsonhelper.Roundtrip(p.Args["task"], &task, jsonhelper.DisallowUnknownFields); e != nil {
+				return nil, e
+			}
+			return fetcher.FetchFile(p.Context, task)
+		},
+	})
 }
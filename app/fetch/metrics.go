@@ -0,0 +1,16 @@
+package fetch
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+)
+
+// CollectMetrics appends counters of active fetch procedures to s.
+func (fetcher *Fetcher) CollectMetrics(s *openmetrics.Set, labels openmetrics.Labels) {
+	fetcher.mutex.Lock()
+	defer fetcher.mutex.Unlock()
+	for i := 0; i < fetcher.nActiveProcs; i++ {
+		s.AddStruct("ndndpdk_fetch_", labels.With("proc", strconv.Itoa(i)), fetcher.Logic(i).Counters())
+	}
+}
@@ -55,6 +55,16 @@ const (
 
 	MetadataFreshness = 1
 
+	MaxSigInfoLen  = 256
+	MaxSigValueLen = 72 // ECDSA P-256 signature in DER format
+	MaxHmacKeyLen  = 64
+
+	MinSignedCacheCapacity     = 64
+	MaxSignedCacheCapacity     = 1 << 20
+	DefaultSignedCacheCapacity = 4096
+
+	DefaultCryptoOpPoolCapacity = 4095
+
 	_ = "enumgen::FileServer"
 )
 
@@ -100,6 +110,10 @@ type Config struct {
 	// StatValidity is the validity period of statx result.
 	StatValidity nnduration.Nanoseconds `json:"statValidity,omitempty" gqldesc:"statx result validity period."`
 
+	// SignedCacheCapacity is the number of precomputed signed Data per thread.
+	// This is used only if some mount enables signing.precompute.
+	SignedCacheCapacity int `json:"signedCacheCapacity,omitempty" gqldesc:"Precomputed signed Data per thread."`
+
 	payloadHeadroom       int
 	uringCongestionLbound int
 	uringWaitLbound       int
@@ -126,6 +140,11 @@ func (cfg *Config) Validate() error {
 				return fmt.Errorf("mounts[%d].prefix must consist of GenericNameComponents", i)
 			}
 		}
+		if m.Signing != nil {
+			if e := m.Signing.validate(); e != nil {
+				return fmt.Errorf("mounts[%d].signing: %w", i, e)
+			}
+		}
 	}
 
 	if cfg.SegmentLen == 0 {
@@ -157,9 +176,31 @@ func (cfg *Config) Validate() error {
 		return errors.New("openFds must be greater than keepFds")
 	}
 
+	cfg.SignedCacheCapacity = ringbuffer.AlignCapacity(cfg.SignedCacheCapacity,
+		MinSignedCacheCapacity, DefaultSignedCacheCapacity, MaxSignedCacheCapacity)
+
 	return nil
 }
 
+// sigLen returns maximum length of DSigInfo and DSigValue TLVs among mounts.
+func (cfg Config) sigLen() (sigLen int) {
+	sigLen = ndni.DataEncNullSigLen
+	for _, m := range cfg.Mounts {
+		sigLen = mathpkg.MaxInt(sigLen, m.Signing.sigLen())
+	}
+	return sigLen
+}
+
+// hasSigning determines whether any mount satisfies a predicate on its signing configuration.
+func (cfg Config) hasSigning(pred func(sc *SigningConfig) bool) bool {
+	for _, m := range cfg.Mounts {
+		if !m.Signing.isNull() && pred(m.Signing) {
+			return true
+		}
+	}
+	return false
+}
+
 func (cfg Config) adjustUringThres(thres *float64, dflt float64) (lbound int) {
 	if math.IsNaN(*thres) || *thres <= 0.0 || *thres >= 1.0 {
 		*thres = dflt
@@ -186,7 +227,7 @@ func (cfg *Config) checkPayloadMempool(segmentLen int) error {
 	}
 
 	suggest := pktmbuf.DefaultHeadroom + ndni.NameMaxLength +
-		mathpkg.MaxInt(segmentLen, ndni.NameMaxLength+EstimatedMetadataSize) + ndni.DataEncNullSigLen + 64
+		mathpkg.MaxInt(segmentLen, ndni.NameMaxLength+EstimatedMetadataSize) + cfg.sigLen() + 64
 	if tpl.Dataroom < suggest {
 		logger.Warn("PAYLOAD dataroom too small for configured segmentLen, Interests with long names may be dropped",
 			zap.Int("configured-dataroom", tpl.Dataroom),
@@ -195,7 +236,7 @@ func (cfg *Config) checkPayloadMempool(segmentLen int) error {
 		)
 	}
 
-	cfg.payloadHeadroom = tpl.Dataroom - ndni.DataEncNullSigLen - mathpkg.MaxInt(segmentLen, EstimatedMetadataSize)
+	cfg.payloadHeadroom = tpl.Dataroom - cfg.sigLen() - mathpkg.MaxInt(segmentLen, EstimatedMetadataSize)
 	if cfg.payloadHeadroom < pktmbuf.DefaultHeadroom {
 		return fmt.Errorf("PAYLOAD dataroom %d too small for segmentLen %d; increase PAYLOAD dataroom to %d",
 			tpl.Dataroom, segmentLen, suggest)
@@ -211,7 +252,12 @@ func (cfg Config) tscStatValidity() int64 {
 type Mount struct {
 	Prefix ndn.Name `json:"prefix" gqldesc:"NDN name prefix."`
 	Path   string   `json:"path" gqldesc:"Filesystem path."`
-	dfd    *int
+
+	// Signing contains Data signing configuration.
+	// Default is Null signature.
+	Signing *SigningConfig `json:"signing,omitempty" gqldesc:"Data signing configuration."`
+
+	dfd *int
 }
 
 func (m *Mount) openDirectory() error {
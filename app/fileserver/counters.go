@@ -14,6 +14,11 @@ type Counters struct {
 	UringSubmitWait     uint64 `json:"uringSubmitWait" gqldesc:"uring submissions waiting for completions."`
 	SqeSubmit           uint64 `json:"sqeSubmit" gqldesc:"I/O submissions total."`
 	CqeFail             uint64 `json:"cqeFail" gqldesc:"I/O completions with errors."`
+	SignInline          uint64 `json:"signInline" gqldesc:"Data signed in server threads."`
+	SignOffload         uint64 `json:"signOffload" gqldesc:"Data passed to cryptodev for signing."`
+	SignFail            uint64 `json:"signFail" gqldesc:"Signing failures."`
+	SignedCacheHit      uint64 `json:"signedCacheHit" gqldesc:"Data served from precomputed signed Data."`
+	SignedCacheInsert   uint64 `json:"signedCacheInsert" gqldesc:"Data inserted into precomputed signed Data."`
 }
 
 func (cnt *Counters) add(c countersC) {
@@ -28,6 +33,11 @@ func (cnt *Counters) add(c countersC) {
 	cnt.UringSubmitWait += c.UringSubmitWait
 	cnt.SqeSubmit += c.SqeSubmit
 	cnt.CqeFail += c.CqeFail
+	cnt.SignInline += c.SignInline
+	cnt.SignOffload += c.SignOffload
+	cnt.SignFail += c.SignFail
+	cnt.SignedCacheHit += c.SignedCacheHit
+	cnt.SignedCacheInsert += c.SignedCacheInsert
 
 	cnt.UringSubmit = cnt.UringSubmitNonBlock + cnt.UringSubmitWait
 }
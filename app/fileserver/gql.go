@@ -16,6 +16,7 @@ var GqlRetrieveByFaceID func(id iface.ID) interface{}
 
 // GraphQL types.
 var (
+	GqlSigningInput   *graphql.InputObject
 	GqlMountInput     *graphql.InputObject
 	GqlConfigInput    *graphql.InputObject
 	GqlCountersType   *graphql.Object
@@ -24,11 +25,19 @@ var (
 )
 
 func init() {
+	GqlSigningInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "FileServerSigningInput",
+		Description: "File server Data signing configuration.",
+		Fields: gqlserver.BindInputFields(SigningConfig{}, gqlserver.FieldTypes{
+			reflect.TypeOf(ndn.Name{}): gqlserver.NonNullString,
+		}),
+	})
 	GqlMountInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "FileServerMountInput",
 		Description: "File server mount definition.",
 		Fields: gqlserver.BindInputFields(Mount{}, gqlserver.FieldTypes{
-			reflect.TypeOf(ndn.Name{}): gqlserver.NonNullString,
+			reflect.TypeOf(ndn.Name{}):      gqlserver.NonNullString,
+			reflect.TypeOf(SigningConfig{}): GqlSigningInput,
 		}),
 	})
 	GqlConfigInput = graphql.NewInputObject(graphql.InputObjectConfig{
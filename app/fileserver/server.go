@@ -1,8 +1,12 @@
 package fileserver
 
 import (
+	"fmt"
+
 	"github.com/usnistgov/ndn-dpdk/app/tg/tgdef"
 	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/dpdk/cryptodev"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"go4.org/must"
@@ -14,6 +18,39 @@ var logger = logging.New("fileserver")
 type Server struct {
 	workers []*worker
 	mounts  []Mount
+	signers []*signer
+	crypto  *serverCrypto
+}
+
+// serverCrypto contains cryptodev resources for signing offload.
+type serverCrypto struct {
+	dev    *cryptodev.CryptoDev
+	opPool *cryptodev.OpPool
+}
+
+func (sc *serverCrypto) close() {
+	if sc.dev != nil {
+		must.Close(sc.dev)
+	}
+	if sc.opPool != nil {
+		must.Close(sc.opPool)
+	}
+}
+
+func newServerCrypto(socket eal.NumaSocket, nThreads int) (sc *serverCrypto, e error) {
+	sc = &serverCrypto{}
+	if sc.opPool, e = cryptodev.NewOpPool(cryptodev.OpPoolConfig{Capacity: DefaultCryptoOpPoolCapacity}, socket); e != nil {
+		return nil, fmt.Errorf("cryptodev.NewOpPool: %w", e)
+	}
+
+	var vcfg cryptodev.VDevConfig
+	vcfg.Socket = socket
+	vcfg.NQueuePairs = nThreads
+	if sc.dev, e = cryptodev.CreateVDev(vcfg); e != nil {
+		sc.close()
+		return nil, fmt.Errorf("cryptodev.CreateVDev: %w", e)
+	}
+	return sc, nil
 }
 
 var _ tgdef.Producer = &Server{}
@@ -58,6 +95,14 @@ func (p *Server) Close() error {
 		errs = append(errs, w.close())
 	}
 	p.workers = nil
+	if p.crypto != nil {
+		p.crypto.close()
+		p.crypto = nil
+	}
+	for _, s := range p.signers {
+		s.close()
+	}
+	p.signers = nil
 	for _, m := range p.mounts {
 		m.closeDirectory()
 	}
@@ -87,12 +132,31 @@ func New(face iface.Face, cfg Config) (p *Server, e error) {
 	}
 	copy(cfg.Mounts, p.mounts)
 
+	for i, m := range cfg.Mounts {
+		s, e := newSigner(m.Signing, socket)
+		if e != nil {
+			must.Close(p)
+			return nil, fmt.Errorf("mounts[%d].signing: %w", i, e)
+		}
+		p.signers = append(p.signers, s)
+	}
+
+	if cfg.hasSigning(func(sc *SigningConfig) bool { return sc.Offload }) {
+		if p.crypto, e = newServerCrypto(socket, cfg.NThreads); e != nil {
+			must.Close(p)
+			return nil, e
+		}
+	}
+
 	for i := 0; i < cfg.NThreads; i++ {
-		w, e := newWorker(faceID, socket, cfg)
+		w, e := newWorker(faceID, socket, cfg, p.signers)
 		if e != nil {
 			must.Close(p)
 			return nil, e
 		}
+		if p.crypto != nil {
+			w.setCrypto(p.crypto, i)
+		}
 		p.workers = append(p.workers, w)
 	}
 	return p, nil
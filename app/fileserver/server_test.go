@@ -2,16 +2,21 @@ package fileserver_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"math"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
-	mathpkg "github.com/pkg/math"
 	"github.com/usnistgov/ndn-dpdk/app/fileserver"
 	"github.com/usnistgov/ndn-dpdk/app/tg/tgtestenv"
 	"github.com/usnistgov/ndn-dpdk/core/nnduration"
@@ -19,12 +24,12 @@ import (
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
 	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
 	"github.com/usnistgov/ndn-dpdk/ndn/l3"
 	"github.com/usnistgov/ndn-dpdk/ndn/rdr"
 	"github.com/usnistgov/ndn-dpdk/ndn/rdr/ndn6file"
 	"github.com/usnistgov/ndn-dpdk/ndn/segmented"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
-	"golang.org/x/sys/unix"
 )
 
 func TestServer(t *testing.T) {
@@ -144,7 +149,27 @@ func TestServer(t *testing.T) {
 			}
 			nFound++
 		}
-		assert.GreaterOrEqual(nFound, mathpkg.MinInt(cfg.SegmentLen/(unix.NAME_MAX+2), len(dirEntryNames)))
+		assert.Equal(len(dirEntryNames), nFound)
+	}
+	testRetrieveDir := func(dirname, name string) {
+		defer wg.Done()
+		dirEntries, e := os.ReadDir(dirname)
+		require.NoError(e)
+		nExpected := 0
+		for _, dirEntry := range dirEntries {
+			if mode := dirEntry.Type(); mode.IsRegular() || mode.IsDir() {
+				nExpected++
+			}
+		}
+
+		ls, e := ndn6file.RetrieveDirectoryListing(timeout, ndn.ParseName(name), segmented.FetchOptions{
+			Fw:        fw,
+			RetxLimit: 3,
+			MaxCwnd:   256,
+		})
+		if assert.NoError(e) {
+			assert.Len(ls, nExpected)
+		}
 	}
 	testNotFound := func(name string, expectNack bool) {
 		defer wg.Done()
@@ -171,11 +196,12 @@ func TestServer(t *testing.T) {
 		return
 	}()
 
-	wg.Add(12 + len(localLibs))
+	wg.Add(13 + len(localLibs))
 	go testFetchFile("/usr/local/bin/dpdk-testpmd", "/usr/local-bin/dpdk-testpmd", true)
 	go testFetchFile("/usr/bin/jq", "/usr/bin/jq", false)
 	go testFetchDir("/usr/bin", "/usr/bin")
 	go testFetchDir("/usr/local/bin", "/usr/local-bin/"+ndn6file.KeywordLs.String())
+	go testRetrieveDir("/usr/bin", "/usr/bin")
 	go testNotFound("/usr/local-bin/ndndpdk/no-such-program", true)
 	go testNotFound("/no-such-mount/autoexec.bat", false)
 	go testNotFound("/usr/local-bin/bad/zero%00/filename", false)
@@ -204,3 +230,104 @@ func TestServer(t *testing.T) {
 	cntJ, _ := json.Marshal(cnt)
 	fmt.Println(string(cntJ))
 }
+
+func TestSigning(t *testing.T) {
+	assert, require := makeAR(t)
+	dir := t.TempDir()
+
+	hmacName := keychain.ToKeyName(ndn.ParseName("/fileserver-test/hmac"))
+	hmacSecret := make([]byte, 32)
+	rand.Read(hmacSecret)
+	hmacFile := filepath.Join(dir, "hmac.key")
+	require.NoError(os.WriteFile(hmacFile, hmacSecret, 0600))
+	hmacVerifier, e := keychain.NewHMACPublicKey(hmacName, hmacSecret)
+	require.NoError(e)
+
+	ecdsaName := keychain.ToKeyName(ndn.ParseName("/fileserver-test/ecdsa"))
+	ecdsaKey, e := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(e)
+	ecdsaDer, e := x509.MarshalPKCS8PrivateKey(ecdsaKey)
+	require.NoError(e)
+	ecdsaFile := filepath.Join(dir, "ecdsa.pem")
+	require.NoError(os.WriteFile(ecdsaFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecdsaDer}), 0600))
+	ecdsaVerifier, e := keychain.NewECDSAPublicKey(ecdsaName, &ecdsaKey.PublicKey)
+	require.NoError(e)
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	cfg := fileserver.Config{
+		NThreads: 1,
+		Mounts: []fileserver.Mount{
+			{
+				Prefix:  ndn.ParseName("/digest"),
+				Path:    "/usr/bin",
+				Signing: &fileserver.SigningConfig{Type: "digest", Offload: true},
+			},
+			{
+				Prefix:  ndn.ParseName("/hmac"),
+				Path:    "/usr/bin",
+				Signing: &fileserver.SigningConfig{Type: "hmac", KeyFile: hmacFile, KeyName: hmacName},
+			},
+			{
+				Prefix:  ndn.ParseName("/ecdsa"),
+				Path:    "/usr/bin",
+				Signing: &fileserver.SigningConfig{Type: "ecdsa", KeyFile: ecdsaFile, KeyName: ecdsaName, Precompute: true},
+			},
+		},
+	}
+
+	p, e := fileserver.New(face.D, cfg)
+	require.NoError(e)
+	defer p.Close()
+	tgtestenv.Open(t, p)
+	p.Launch()
+	time.Sleep(time.Second)
+
+	fw := l3.NewForwarder()
+	fwFace, e := fw.AddFace(face.A)
+	require.NoError(e)
+	fwFace.AddRoute(ndn.ParseName("/"))
+
+	timeout, cancel := context.WithTimeout(context.TODO(), 20*time.Second)
+	defer cancel()
+
+	content, e := os.ReadFile("/usr/bin/jq")
+	require.NoError(e)
+	digest := sha256.Sum256(content)
+
+	testFetch := func(prefix string, verifier ndn.Verifier) {
+		var m ndn6file.Metadata
+		e := rdr.RetrieveMetadata(timeout, &m, ndn.ParseName(prefix+"/jq"), endpoint.ConsumerOptions{
+			Fw:       fw,
+			Retx:     endpoint.RetxOptions{Limit: 3},
+			Verifier: verifier,
+		})
+		if !assert.NoError(e, prefix) {
+			return
+		}
+
+		fetcher := segmented.Fetch(m.Name, segmented.FetchOptions{
+			Fw:        fw,
+			RetxLimit: 3,
+			MaxCwnd:   256,
+			Verifier:  verifier,
+		})
+		payload, e := fetcher.Payload(timeout)
+		if assert.NoError(e, prefix) {
+			assert.Equal(digest, sha256.Sum256(payload), prefix)
+		}
+	}
+
+	testFetch("/digest", ndn.DigestSigning)
+	testFetch("/hmac", hmacVerifier)
+	testFetch("/ecdsa", ecdsaVerifier)
+	testFetch("/ecdsa", ecdsaVerifier)
+
+	cnt := p.Counters()
+	assert.Greater(cnt.SignOffload, uint64(0))
+	assert.Greater(cnt.SignInline, uint64(0))
+	assert.Zero(cnt.SignFail)
+	assert.Greater(cnt.SignedCacheInsert, uint64(0))
+	assert.Greater(cnt.SignedCacheHit, uint64(0))
+}
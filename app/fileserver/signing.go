@@ -0,0 +1,223 @@
+package fileserver
+
+/*
+#include "../../csrc/fileserver/signer.h"
+*/
+import "C"
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+// SigningConfig contains Data signing configuration of a mount.
+type SigningConfig struct {
+	// Type is the signature type.
+	// "null" (default): Null signature, which provides no integrity protection.
+	// "digest": DigestSha256.
+	// "hmac": HmacWithSha256.
+	// "ecdsa": SignatureSha256WithEcdsa, P-256 curve only.
+	Type string `json:"type,omitempty" gqldesc:"Signature type: null, digest, hmac, ecdsa."`
+
+	// KeyFile is the filename of the signing key.
+	// For "hmac", this file contains the raw secret, up to MaxHmacKeyLen octets.
+	// For "ecdsa", this file contains a private key in PKCS#8 or SEC1 format, DER or PEM encoded.
+	KeyFile string `json:"keyFile,omitempty" gqldesc:"Signing key filename."`
+
+	// KeyName is the KeyLocator name.
+	// This is required for "hmac" and "ecdsa", and not allowed for other types.
+	KeyName ndn.Name `json:"keyName,omitempty" gqldesc:"KeyLocator name."`
+
+	// Offload enables signing in a cryptodev instead of the server threads.
+	// This is supported for "digest" and "hmac".
+	Offload bool `json:"offload,omitempty" gqldesc:"Offload signing to cryptodev."`
+
+	// Precompute enables caching of signed file segments.
+	// Signed Data are reused as long as the versioned name stays the same, so that this is only
+	// suitable for immutable files. This cannot be combined with Offload.
+	Precompute bool `json:"precompute,omitempty" gqldesc:"Cache signed file segments."`
+
+	sigType uint32
+	sigInfo []byte
+}
+
+var (
+	errSigningKeyFile    = errors.New("keyFile required for hmac and ecdsa")
+	errSigningKeyName    = errors.New("keyName required for hmac and ecdsa, not allowed for others")
+	errSigningOffload    = errors.New("offload is not supported for ecdsa")
+	errSigningPrecompute = errors.New("precompute cannot be combined with offload")
+	errSigningHmacKey    = fmt.Errorf("HMAC key must have between 1 and %d octets", MaxHmacKeyLen)
+	errSigningEcdsaKey   = errors.New("ECDSA key must be a P-256 private key")
+	errSignerInit        = errors.New("FileServerSigner_Init error")
+)
+
+// validate applies defaults, validates the configuration, and prepares SigInfo.
+func (cfg *SigningConfig) validate() error {
+	needKey := false
+	switch cfg.Type {
+	case "", "null":
+		cfg.Type, cfg.sigType = "null", an.SigNull
+	case "digest":
+		cfg.sigType = an.SigSha256
+	case "hmac":
+		cfg.sigType, needKey = an.SigHmacWithSha256, true
+	case "ecdsa":
+		cfg.sigType, needKey = an.SigSha256WithEcdsa, true
+		if cfg.Offload {
+			return errSigningOffload
+		}
+	default:
+		return fmt.Errorf("unknown signature type %s", cfg.Type)
+	}
+
+	if needKey != (cfg.KeyFile != "") {
+		return errSigningKeyFile
+	}
+	if needKey != (len(cfg.KeyName) > 0) {
+		return errSigningKeyName
+	}
+	if cfg.Offload && cfg.Precompute {
+		return errSigningPrecompute
+	}
+
+	if cfg.sigType == an.SigNull {
+		cfg.Offload, cfg.Precompute, cfg.sigInfo = false, false, nil
+		return nil
+	}
+
+	si := ndn.SigInfo{Type: cfg.sigType}
+	if needKey {
+		si.KeyLocator.Name = cfg.KeyName
+	}
+	wire, e := tlv.EncodeFrom(si.EncodeAs(an.TtDSigInfo))
+	if e != nil {
+		return e
+	}
+	if len(wire) > MaxSigInfoLen {
+		return fmt.Errorf("SigInfo cannot exceed %d octets", MaxSigInfoLen)
+	}
+	cfg.sigInfo = wire
+	return nil
+}
+
+// isNull determines whether the configuration results in Null signature.
+func (cfg *SigningConfig) isNull() bool {
+	return cfg == nil || cfg.sigType == an.SigNull
+}
+
+// sigLen returns maximum length of DSigInfo and DSigValue TLVs.
+func (cfg *SigningConfig) sigLen() int {
+	if cfg.isNull() {
+		return 0
+	}
+	sigValueL := 32
+	if cfg.sigType == an.SigSha256WithEcdsa {
+		sigValueL = MaxSigValueLen
+	}
+	return len(cfg.sigInfo) + 2 + sigValueL
+}
+
+// loadKey reads the key file, and returns key bits expected by FileServerSigner_Init.
+func (cfg *SigningConfig) loadKey() (key []byte, e error) {
+	if cfg.KeyFile == "" {
+		return nil, nil
+	}
+	file, e := os.ReadFile(cfg.KeyFile)
+	if e != nil {
+		return nil, e
+	}
+
+	switch cfg.sigType {
+	case an.SigHmacWithSha256:
+		if len(file) == 0 || len(file) > MaxHmacKeyLen {
+			return nil, errSigningHmacKey
+		}
+		return file, nil
+	case an.SigSha256WithEcdsa:
+		return parseEcdsaKey(file)
+	}
+	return nil, nil
+}
+
+func parseEcdsaKey(file []byte) ([]byte, error) {
+	der := file
+	if block, _ := pem.Decode(file); block != nil {
+		der = block.Bytes
+	}
+
+	var pvt *ecdsa.PrivateKey
+	if k, e := x509.ParsePKCS8PrivateKey(der); e == nil {
+		pvt, _ = k.(*ecdsa.PrivateKey)
+	} else if k, e := x509.ParseECPrivateKey(der); e == nil {
+		pvt = k
+	}
+	if pvt == nil || pvt.Curve != elliptic.P256() {
+		return nil, errSigningEcdsaKey
+	}
+	return x509.MarshalPKCS8PrivateKey(pvt)
+}
+
+// signer wraps C.FileServerSigner.
+type signer struct {
+	c *C.FileServerSigner
+}
+
+func (s *signer) ptr() *C.FileServerSigner {
+	if s == nil {
+		return nil
+	}
+	return s.c
+}
+
+func (s *signer) close() {
+	if s == nil || s.c == nil {
+		return
+	}
+	C.FileServerSigner_Close(s.c)
+	eal.Free(s.c)
+	s.c = nil
+}
+
+func newSigner(cfg *SigningConfig, socket eal.NumaSocket) (s *signer, e error) {
+	if cfg.isNull() {
+		return nil, nil
+	}
+
+	key, e := cfg.loadKey()
+	if e != nil {
+		return nil, fmt.Errorf("load key %s: %w", cfg.KeyFile, e)
+	}
+
+	s = &signer{
+		c: (*C.FileServerSigner)(eal.Zmalloc("FileServerSigner", C.sizeof_FileServerSigner, socket)),
+	}
+	s.c.sigType = C.uint8_t(cfg.sigType)
+	s.c.offload = C.bool(cfg.Offload)
+	s.c.precompute = C.bool(cfg.Precompute)
+	sigInfoV := unsafe.Slice((*byte)(unsafe.Pointer(&s.c.sigInfoV[0])), len(s.c.sigInfoV))
+	s.c.sigInfoL = C.uint16_t(copy(sigInfoV, cfg.sigInfo))
+
+	var keyPtr *C.uint8_t
+	if len(key) > 0 {
+		keyPtr = (*C.uint8_t)(C.CBytes(key))
+		defer func() {
+			C.memset(unsafe.Pointer(keyPtr), 0, C.size_t(len(key)))
+			C.free(unsafe.Pointer(keyPtr))
+		}()
+	}
+	if !C.FileServerSigner_Init(s.c, keyPtr, C.size_t(len(key))) {
+		eal.Free(s.c)
+		return nil, errSignerInit
+	}
+	return s, nil
+}
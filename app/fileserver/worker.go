@@ -49,15 +49,23 @@ func (w worker) rxQueue() *iface.PktQueue {
 
 func (w *worker) close() error {
 	e := w.rxQueue().Close()
+	if w.c.signedCache != nil {
+		eal.Free(w.c.signedCache)
+	}
 	eal.Free(w.c)
 	return e
 }
 
+func (w *worker) setCrypto(sc *serverCrypto, i int) {
+	w.c.cryptoOpPool = (*C.struct_rte_mempool)(sc.opPool.Ptr())
+	sc.dev.QueuePairs()[i].CopyToC(unsafe.Pointer(&w.c.cqp))
+}
+
 func (w worker) counters() countersC {
 	return *(*countersC)(unsafe.Pointer(&w.c.cnt))
 }
 
-func newWorker(faceID iface.ID, socket eal.NumaSocket, cfg Config) (w *worker, e error) {
+func newWorker(faceID iface.ID, socket eal.NumaSocket, cfg Config, signers []*signer) (w *worker, e error) {
 	w = &worker{
 		c: (*C.FileServer)(eal.Zmalloc("FileServer", C.sizeof_FileServer, socket)),
 	}
@@ -84,6 +92,14 @@ func newWorker(faceID iface.ID, socket eal.NumaSocket, cfg Config) (w *worker, e
 		w.c.dfd[i] = C.int(*m.dfd)
 		w.c.mountPrefixComps[i] = C.int16_t(len(m.Prefix))
 		prefixes.Append(m.Prefix)
+		w.c.signer[i] = signers[i].ptr()
+	}
+
+	if cfg.hasSigning(func(sc *SigningConfig) bool { return sc.Precompute }) {
+		(*ndni.Mempools)(unsafe.Pointer(&w.c.mp)).Assign(socket, ndni.DataMempool)
+		w.c.signedCache = (**C.Packet)(eal.Zmalloc("FileServerSignedCache",
+			unsafe.Sizeof(uintptr(0))*uintptr(cfg.SignedCacheCapacity), socket))
+		w.c.signedCacheMask = C.uint32_t(cfg.SignedCacheCapacity - 1)
 	}
 
 	w.ThreadWithCtrl = ealthread.NewThreadWithCtrl(
@@ -23,6 +23,7 @@ const (
 	RoleInput  = iface.RoleRx
 	RoleOutput = iface.RoleTx
 	RoleCrypto = "CRYPTO"
+	RoleDisk   = "DISK"
 	RoleFwd    = "FWD"
 )
 
@@ -30,12 +31,14 @@ const (
 type Config struct {
 	LCoreAlloc ealthread.Config `json:"-"`
 
-	Ndt      ndt.Config         `json:"ndt,omitempty"`
-	Fib      fibdef.Config      `json:"fib,omitempty"`
-	Pcct     pcct.Config        `json:"pcct,omitempty"`
-	Suppress pit.SuppressConfig `json:"suppress,omitempty"`
+	Ndt         ndt.Config         `json:"ndt,omitempty"`
+	NdtBalancer ndt.BalancerConfig `json:"ndtBalancer,omitempty"`
+	Fib         fibdef.Config      `json:"fib,omitempty"`
+	Pcct        pcct.Config        `json:"pcct,omitempty"`
+	Suppress    pit.SuppressConfig `json:"suppress,omitempty"`
 
 	Crypto            CryptoConfig         `json:"crypto,omitempty"`
+	Disk              DiskConfig           `json:"disk,omitempty"`
 	FwdInterestQueue  iface.PktQueueConfig `json:"fwdInterestQueue,omitempty"`
 	FwdDataQueue      iface.PktQueueConfig `json:"fwdDataQueue,omitempty"`
 	FwdNackQueue      iface.PktQueueConfig `json:"fwdNackQueue,omitempty"`
@@ -44,7 +47,11 @@ type Config struct {
 
 func (cfg *Config) validate() error {
 	if len(cfg.LCoreAlloc) > 0 {
-		if e := cfg.LCoreAlloc.ValidateRoles(map[string]int{RoleInput: 1, RoleOutput: 1, RoleCrypto: 0, RoleFwd: 1}); e != nil {
+		nDisk := 0
+		if cfg.Pcct.CsDiskCapacity > 0 {
+			nDisk = 1
+		}
+		if e := cfg.LCoreAlloc.ValidateRoles(map[string]int{RoleInput: 1, RoleOutput: 1, RoleCrypto: 0, RoleDisk: nDisk, RoleFwd: 1}); e != nil {
 			return e
 		}
 	}
@@ -118,11 +125,16 @@ func DefaultAlloc() (m map[string]eal.LCores, e error) {
 // DataPlane represents the forwarder data plane.
 type DataPlane struct {
 	ndt   *ndt.Ndt
+	ndtb  *ndt.Balancer
 	fib   *fib.Fib
 	fwis  []*Input
 	fwcs  []*Crypto
 	fwcsh map[eal.NumaSocket]*CryptoShared
 	fwds  []*Fwd
+	disk  *Disk
+
+	learnStop chan struct{}
+	learnDone chan struct{}
 }
 
 // New creates and launches forwarder data plane.
@@ -136,7 +148,15 @@ func New(cfg Config) (dp *DataPlane, e error) {
 	if len(cfg.LCoreAlloc) > 0 {
 		alloc, e = ealthread.AllocConfig(cfg.LCoreAlloc)
 	} else {
-		alloc, e = DefaultAlloc()
+		var lcDisk eal.LCores
+		if cfg.Pcct.CsDiskCapacity > 0 {
+			if lcDisk, e = ealthread.AllocRequest(ealthread.AllocReq{Role: RoleDisk}); e != nil {
+				return nil, e
+			}
+		}
+		if alloc, e = DefaultAlloc(); e == nil && len(lcDisk) > 0 {
+			alloc[RoleDisk] = lcDisk
+		}
 	}
 	if e != nil {
 		return nil, e
@@ -173,6 +193,17 @@ func New(cfg Config) (dp *DataPlane, e error) {
 		fibFwds = append(fibFwds, fwd)
 	}
 
+	if cfg.Pcct.CsDiskCapacity > 0 {
+		if dp.disk, e = newDisk(cfg.Disk, alloc[RoleDisk][0], len(dp.fwds)*cfg.Pcct.CsDiskCapacity); e != nil {
+			must.Close(dp)
+			return nil, fmt.Errorf("newDisk: %w", e)
+		}
+		if e = dp.disk.AssignTo(dp.fwds, cfg.Pcct.CsDiskCapacity); e != nil {
+			must.Close(dp)
+			return nil, e
+		}
+	}
+
 	if dp.fib, e = fib.New(cfg.Fib, fibFwds); e != nil {
 		must.Close(dp)
 		return nil, fmt.Errorf("fib.New: %w", e)
@@ -228,6 +259,17 @@ func New(cfg Config) (dp *DataPlane, e error) {
 		ealthread.Launch(fwi.rxl)
 	}
 
+	dp.startLearner()
+
+	if cfg.NdtBalancer.Interval > 0 {
+		loads := make([]ndt.LoadReader, len(dp.fwds))
+		for i, fwd := range dp.fwds {
+			loads[i] = fwd
+		}
+		dp.ndtb = ndt.NewBalancer(dp.ndt, loads, cfg.NdtBalancer)
+		dp.ndtb.Start()
+	}
+
 	return dp, nil
 }
 
@@ -236,6 +278,11 @@ func (dp *DataPlane) Ndt() *ndt.Ndt {
 	return dp.ndt
 }
 
+// NdtBalancer returns the NDT load balancer, or nil if disabled.
+func (dp *DataPlane) NdtBalancer() *ndt.Balancer {
+	return dp.ndtb
+}
+
 // Fib returns the FIB.
 func (dp *DataPlane) Fib() *fib.Fib {
 	return dp.fib
@@ -246,11 +293,21 @@ func (dp *DataPlane) Fwds() []*Fwd {
 	return dp.fwds
 }
 
+// Disk returns shared resources of the disk-backed Content Store, or nil if disabled.
+func (dp *DataPlane) Disk() *Disk {
+	return dp.disk
+}
+
 // Close stops the data plane and releases resources.
 func (dp *DataPlane) Close() error {
 	var lcores eal.LCores
 	errs := []error{}
 
+	dp.stopLearner()
+	if dp.ndtb != nil {
+		errs = append(errs, dp.ndtb.Close())
+	}
+
 	for _, rxl := range iface.ListRxLoops() {
 		lcores = append(lcores, rxl.LCore())
 	}
@@ -266,6 +323,14 @@ func (dp *DataPlane) Close() error {
 	for _, fwcsh := range dp.fwcsh {
 		errs = append(errs, fwcsh.Close())
 	}
+	if dp.disk != nil {
+		// stop forwarding threads, so that DiskStore would not receive more requests
+		for _, fwd := range dp.fwds {
+			fwd.Stop()
+		}
+		lcores = append(lcores, dp.disk.th.LCore())
+		errs = append(errs, dp.disk.Close())
+	}
 	for _, fwd := range dp.fwds {
 		lcores = append(lcores, fwd.LCore())
 		errs = append(errs, fwd.Close())
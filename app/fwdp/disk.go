@@ -0,0 +1,134 @@
+package fwdp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/usnistgov/ndn-dpdk/container/diskstore"
+	"github.com/usnistgov/ndn-dpdk/dpdk/bdev"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/dpdk/spdkenv"
+	"go.uber.org/multierr"
+)
+
+// DiskConfig contains configuration of the disk-backed second-tier Content Store.
+//
+// Disk caching is enabled when pcct.Config.CsDiskCapacity is positive.
+// Each forwarding thread receives a disjoint range of CsDiskCapacity slots in a shared DiskStore.
+type DiskConfig struct {
+	// Filename is the path to a file used as the block device.
+	// The file is created or extended as necessary.
+	// If empty, a memory-backed block device is created, which is mainly useful for testing.
+	Filename string `json:"filename,omitempty"`
+
+	// NBlocksPerSlot is the number of 512-octet blocks in each slot.
+	// Default is 16, which can store Data packets up to 8192 octets.
+	// Longer Data packets are not written to disk.
+	NBlocksPerSlot int `json:"nBlocksPerSlot,omitempty"`
+}
+
+func (cfg *DiskConfig) applyDefaults() {
+	if cfg.NBlocksPerSlot <= 0 {
+		cfg.NBlocksPerSlot = 16
+	}
+}
+
+type diskCloser interface {
+	bdev.Device
+	Close() error
+}
+
+// Disk contains shared resources of the disk-backed second-tier Content Store.
+type Disk struct {
+	device diskCloser
+	th     *spdkenv.Thread
+	store  *diskstore.DiskStore
+}
+
+// Store returns the DiskStore.
+func (disk *Disk) Store() *diskstore.DiskStore {
+	return disk.store
+}
+
+// AssignTo gives each forwarding thread a slot allocator.
+func (disk *Disk) AssignTo(fwds []*Fwd, capacity int) error {
+	min, max := disk.store.SlotRange()
+	if need := uint64(len(fwds) * capacity); max-min+1 < need {
+		return fmt.Errorf("DiskStore has %d slots but %d are needed", max-min+1, need)
+	}
+
+	for i, fwd := range fwds {
+		first := min + uint64(i*capacity)
+		alloc := diskstore.NewAlloc(first, first+uint64(capacity)-1, fwd.NumaSocket())
+		fwd.Cs().SetDisk(disk.store, alloc)
+	}
+	return nil
+}
+
+// Close releases resources.
+// Forwarding threads using the DiskStore must be closed first.
+func (disk *Disk) Close() error {
+	errs := []error{}
+	if disk.store != nil {
+		errs = append(errs, disk.store.Close())
+	}
+	if disk.th != nil {
+		errs = append(errs, disk.th.Close())
+	}
+	if disk.device != nil {
+		errs = append(errs, disk.device.Close())
+	}
+	return multierr.Combine(errs...)
+}
+
+func newDisk(cfg DiskConfig, lc eal.LCore, nSlots int) (disk *Disk, e error) {
+	cfg.applyDefaults()
+	disk = &Disk{}
+	nBlocks := (nSlots + 1) * cfg.NBlocksPerSlot // slot 0 is unusable
+
+	if cfg.Filename == "" {
+		disk.device, e = bdev.NewMalloc(diskstore.BlockSize, nBlocks)
+	} else {
+		disk.device, e = openDiskFile(cfg.Filename, int64(nBlocks)*int64(diskstore.BlockSize))
+	}
+	if e != nil {
+		return nil, fmt.Errorf("bdev: %w", e)
+	}
+
+	if disk.th, e = spdkenv.NewThread(); e != nil {
+		disk.Close()
+		return nil, fmt.Errorf("spdkenv.NewThread: %w", e)
+	}
+	disk.th.SetLCore(lc)
+	ealthread.Launch(disk.th)
+
+	if disk.store, e = diskstore.New(disk.device, disk.th, cfg.NBlocksPerSlot); e != nil {
+		disk.Close()
+		return nil, fmt.Errorf("diskstore.New: %w", e)
+	}
+	return disk, nil
+}
+
+func openDiskFile(filename string, size int64) (*bdev.Aio, error) {
+	f, e := os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0o644)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+
+	st, e := f.Stat()
+	if e != nil {
+		return nil, e
+	}
+	if st.Mode()&os.ModeType != 0 {
+		return nil, errors.New("disk file is not a regular file")
+	}
+	if st.Size() < size {
+		if e = f.Truncate(size); e != nil {
+			return nil, e
+		}
+	}
+	return bdev.NewAio(filename, diskstore.BlockSize)
+}
@@ -65,6 +65,10 @@ func (fwd *Fwd) Init(lc eal.LCore, pcctCfg pcct.Config, qcfgI, qcfgD, qcfgN ifac
 		return e
 	}
 
+	if e = fwd.initLearnQueue(socket); e != nil {
+		return fmt.Errorf("initLearnQueue: %w", e)
+	}
+
 	if fwd.pcct, e = pcct.New(pcctCfg, socket); e != nil {
 		return fmt.Errorf("pcct.New: %w", e)
 	}
@@ -89,7 +93,12 @@ func (fwd *Fwd) Close() error {
 	must.Close(fwd.queueI)
 	must.Close(fwd.queueD)
 	must.Close(fwd.queueN)
+	fwd.closeLearnQueue()
+	diskAlloc := fwd.Cs().DiskAlloc()
 	must.Close(fwd.pcct)
+	if diskAlloc != nil {
+		must.Close(diskAlloc)
+	}
 	eal.Free(fwd.c)
 	return nil
 }
@@ -122,6 +131,7 @@ func (fwd *Fwd) Counters() (cnt FwdCounters) {
 	cnt.NDupNonce = uint64(fwd.c.nDupNonce)
 	cnt.NSgNoFwd = uint64(fwd.c.nSgNoFwd)
 	cnt.NNackMismatch = uint64(fwd.c.nNackMismatch)
+	cnt.NLearnDrops = uint64(fwd.c.nLearnDrops)
 	return cnt
 }
 
@@ -145,6 +155,7 @@ type FwdCounters struct {
 	NDupNonce     uint64 `json:"nDupNonce" gqldesc:"Interests dropped due to duplicate nonce."`
 	NSgNoFwd      uint64 `json:"nSgNoFwd" gqldesc:"Interests not forwarded by strategy."`
 	NNackMismatch uint64 `json:"nNackMismatch" gqldesc:"Nacks dropped due to outdated nonce."`
+	NLearnDrops   uint64 `json:"nLearnDrops" gqldesc:"Learned nexthop requests dropped due to full queue."`
 }
 
 func init() {
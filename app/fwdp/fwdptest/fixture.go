@@ -93,6 +93,13 @@ func (fixture *Fixture) SetFibEntry(name string, strategy string, nexthops ...if
 	fixture.require.NoError(e)
 }
 
+// SetFibEntryParams inserts or replaces a FIB entry with strategy parameters.
+func (fixture *Fixture) SetFibEntryParams(name string, strategy string, params map[string]interface{}, nexthops ...iface.ID) error {
+	entry := fibtestenv.MakeEntry(name, fixture.makeStrategy(strategy), nexthops...)
+	entry.Params = params
+	return fixture.Fib.Insert(entry)
+}
+
 // ReadFibCounters returns counters of specified FIB entry.
 func (fixture *Fixture) ReadFibCounters(name string) (cnt fibdef.EntryCounters) {
 	entry := fixture.Fib.Find(ndn.ParseName(name))
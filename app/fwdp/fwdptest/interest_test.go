@@ -2,10 +2,13 @@ package fwdptest
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/usnistgov/ndn-dpdk/app/fwdp"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
@@ -221,6 +224,56 @@ func TestCsHit(t *testing.T) {
 	assert.Equal(uint64(2), fibCnt.NTxInterests)
 }
 
+func TestCsDisk(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t, func(cfg *fwdp.Config) {
+		cfg.LCoreAlloc[fwdp.RoleFwd] = ealthread.RoleConfig{LCores: []int{eal.Workers[3].ID()}}
+		cfg.LCoreAlloc[fwdp.RoleDisk] = ealthread.RoleConfig{LCores: []int{eal.Workers[4].ID()}}
+		cfg.Pcct.CsDirectCapacity = 100
+		cfg.Pcct.CsDiskCapacity = 200
+	})
+	defer fixture.Close()
+
+	face1, face2 := intface.MustNew(), intface.MustNew()
+	collect1, collect2 := intface.Collect(face1), intface.Collect(face2)
+	fixture.SetFibEntry("/B", "multicast", face2.ID)
+
+	// retrieve /B/[first..last] and return the number of Interests forwarded to face2
+	retrieve := func(first, last int) (nForwarded int) {
+		nRx1, nTx2 := collect1.Count(), collect2.Count()
+		for i := first; i <= last; i++ {
+			face1.Tx <- ndn.MakeInterest(fmt.Sprintf("/B/%d", i), makeToken().LpL3())
+		}
+		fixture.StepDelay()
+		nForwarded = collect2.Count() - nTx2
+		for i := nTx2; i < collect2.Count(); i++ {
+			face2.Tx <- ndn.MakeData(collect2.Get(i).Interest)
+		}
+		fixture.StepDelay()
+		assert.Equal(last-first+1, collect1.Count()-nRx1)
+		return nForwarded
+	}
+
+	// T1=[1..100] -> T1=[61..100], T2=[1..60]
+	assert.Equal(100, retrieve(1, 100))
+	assert.Equal(0, retrieve(1, 60))
+
+	// new entries push T1 entries into B1, which are written to disk
+	assert.Equal(100, retrieve(101, 200))
+	diskCnt := fixture.DataPlane.Fwds()[0].Cs().DiskCounters()
+	assert.Greater(diskCnt.NInsert, uint64(0))
+	assert.Zero(diskCnt.NHits)
+
+	// Interests for evicted entries are satisfied from disk without forwarding
+	nForwarded := retrieve(61, 100)
+	diskCnt = fixture.DataPlane.Fwds()[0].Cs().DiskCounters()
+	assert.Greater(diskCnt.NHits, uint64(0))
+	assert.EqualValues(40-nForwarded, diskCnt.NHits)
+	for i := collect1.Count() - 40; i < collect1.Count(); i++ {
+		assert.NotNil(collect1.Get(i).Data, i)
+	}
+}
+
 func TestFwHint(t *testing.T) {
 	assert, _ := makeAR(t)
 	fixture := NewFixture(t)
@@ -418,4 +471,57 @@ func TestCongMark(t *testing.T) {
 			}
 		}
 	})
+	fibCnt := fixture.ReadFibCounters("/A")
+	assert.Equal(uint64(1), fibCnt.NRxCongMarks)
+	assert.EqualValues(1, face1.D.Counters().RxCongMarks)
+	assert.EqualValues(2, face2.D.Counters().TxCongMarks)
+	assert.EqualValues(1, face2.D.Counters().RxCongMarks)
+}
+
+func TestNexthopStats(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3, face4 := intface.MustNew(), intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect2, collect3, collect4 := intface.Collect(face2), intface.Collect(face3), intface.Collect(face4)
+	fixture.SetFibEntry("/A", "multicast", face2.ID, face3.ID, face4.ID)
+
+	face1.Tx <- ndn.MakeInterest("/A/1", 200*time.Millisecond)
+	fixture.StepDelay()
+	require.Equal(1, collect2.Count())
+	require.Equal(1, collect3.Count())
+	require.Equal(1, collect4.Count())
+
+	// face2 replies Data, face3 replies Nack, face4 does not reply
+	time.Sleep(20 * time.Millisecond)
+	face2.Tx <- ndn.MakeData(collect2.Get(-1).Interest)
+	face3.Tx <- ndn.MakeNack(collect3.Get(-1).Interest, an.NackNoRoute)
+	fixture.StepDelay()
+
+	// another Interest expires with all nexthops pending
+	face1.Tx <- ndn.MakeInterest("/A/2", 100*time.Millisecond)
+	time.Sleep(300 * time.Millisecond)
+
+	fibCnt := fixture.ReadFibCounters("/A")
+	require.Len(fibCnt.Nexthops, 3)
+	nh2, nh3, nh4 := fibCnt.Nexthops[0], fibCnt.Nexthops[1], fibCnt.Nexthops[2]
+	assert.Equal(face2.ID, nh2.Nexthop)
+	assert.Equal(face3.ID, nh3.Nexthop)
+	assert.Equal(face4.ID, nh4.Nexthop)
+
+	assert.Equal(uint64(1), nh2.NSatisfied)
+	assert.Equal(uint64(1), nh2.NTimeouts)
+	assert.Equal(uint64(0), nh2.NNacks)
+	assert.GreaterOrEqual(nh2.SRtt, 20*time.Millisecond)
+	assert.Greater(nh2.Rto, nh2.SRtt)
+
+	assert.Equal(uint64(0), nh3.NSatisfied)
+	assert.Equal(uint64(1), nh3.NTimeouts)
+	assert.Equal(uint64(1), nh3.NNacks)
+	assert.Zero(nh3.SRtt)
+
+	assert.Equal(uint64(0), nh4.NSatisfied)
+	assert.Equal(uint64(1), nh4.NTimeouts)
+	assert.Equal(uint64(0), nh4.NNacks)
 }
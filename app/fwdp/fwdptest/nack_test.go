@@ -3,6 +3,7 @@ package fwdptest
 import (
 	"testing"
 
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
@@ -51,6 +52,53 @@ func TestNackMerge(t *testing.T) {
 	assert.Equal(1, collect1.Count())
 }
 
+func TestNackCongMark(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3, face4 := intface.MustNew(), intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3, collect4 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3), intface.Collect(face4)
+	fixture.SetFibEntry("/A", "multicast", face4.ID)
+
+	// two downstream nodes, only face2 sends a marked Interest
+	face1.Tx <- ndn.MakeInterest("/A/1", ndn.NonceFromUint(0x1ac2bc55))
+	face2.Tx <- ndn.MakeInterest("/A/1", ndn.NonceFromUint(0x3e1c8716), ndn.LpL3{CongMark: 1})
+	fixture.StepDelay()
+	assert.GreaterOrEqual(collect4.Count(), 1)
+
+	// unmarked Nack from upstream: only face2 receives a marked Nack
+	face4.Tx <- ndn.MakeNack(collect4.Get(-1).Interest, an.NackCongestion)
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+	assert.Equal(1, collect2.Count())
+	if packet := collect1.Get(-1); assert.NotNil(packet.Nack) {
+		assert.EqualValues(0, packet.Lp.CongMark)
+	}
+	if packet := collect2.Get(-1); assert.NotNil(packet.Nack) {
+		assert.EqualValues(1, packet.Lp.CongMark)
+	}
+
+	// marked Nack from upstream: downstream receives a marked Nack
+	collect4.Clear()
+	face3.Tx <- ndn.MakeInterest("/A/2", ndn.NonceFromUint(0x7b4a9f31))
+	fixture.StepDelay()
+	assert.Equal(1, collect4.Count())
+
+	nack := ndn.MakeNack(collect4.Get(-1).Interest, an.NackNoRoute).ToPacket()
+	nack.Lp.CongMark = 1
+	face4.Tx <- nack
+	fixture.StepDelay()
+	assert.Equal(1, collect3.Count())
+	if packet := collect3.Get(-1); assert.NotNil(packet.Nack) {
+		assert.EqualValues(1, packet.Lp.CongMark)
+	}
+
+	fibCnt := fixture.ReadFibCounters("/A")
+	assert.Equal(uint64(2), fibCnt.NRxNacks)
+	assert.Equal(uint64(1), fibCnt.NRxCongMarks)
+}
+
 func TestNackDuplicate(t *testing.T) {
 	assert, _ := makeAR(t)
 	fixture := NewFixture(t)
@@ -104,4 +152,7 @@ func TestReturnNacks(t *testing.T) {
 	fixture.StepDelay()
 	assert.Equal(1, collect1.Count())
 	assert.NotNil(collect1.Get(-1).Nack)
+	assert.Equal(uint64(0), fixture.SumCounter(func(fwd *fwdp.Fwd) uint64 {
+		return fwd.Pit().Counters().NEntries
+	}))
 }
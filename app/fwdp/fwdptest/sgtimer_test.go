@@ -44,3 +44,29 @@ func TestSgTimer(t *testing.T) {
 	time.Sleep(150 * time.Millisecond)
 	assert.Equal(1, collect2.Count())
 }
+
+func TestSgParams(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1 := intface.MustNew()
+	face2 := intface.MustNew()
+	collect2 := intface.Collect(face2)
+
+	assert.Error(fixture.SetFibEntryParams("/A", "delay", map[string]interface{}{"delay": -1}, face2.ID))
+	assert.Error(fixture.SetFibEntryParams("/A", "delay", map[string]interface{}{"unknown": 1}, face2.ID))
+	assert.Error(fixture.SetFibEntryParams("/A", "multicast", map[string]interface{}{"delay": 50}, face2.ID))
+	require.NoError(fixture.SetFibEntryParams("/A", "delay", map[string]interface{}{"delay": 50}, face2.ID))
+
+	// The strategy sets a 50ms timer, instead of the default 200ms.
+	face1.A.Tx() <- ndn.MakeInterest("/A/1", 400*time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(0, collect2.Count())
+	time.Sleep(80 * time.Millisecond)
+	assert.Equal(1, collect2.Count())
+
+	entry := fixture.Fib.Find(ndn.ParseName("/A"))
+	require.NotNil(entry)
+	assert.EqualValues(50, entry.Params["delay"])
+}
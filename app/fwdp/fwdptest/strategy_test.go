@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/usnistgov/ndn-dpdk/app/fwdp"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
@@ -225,6 +226,57 @@ func TestSequential(t *testing.T) {
 	assert.Equal(2, collect3.Count())
 }
 
+func TestBestroute(t *testing.T) {
+	assert, _ := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	face1, face2, face3, face4 := intface.MustNew(), intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect1, collect2, collect3, collect4 := intface.Collect(face1), intface.Collect(face2), intface.Collect(face3), intface.Collect(face4)
+	fixture.SetFibEntry("/A", "bestroute", face1.ID, face2.ID, face3.ID)
+
+	// forward to first nexthop
+	face4.Tx <- ndn.MakeInterest("/A/1", ndn.NonceFromUint(0x6f1e3d2a))
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+	assert.Equal(0, collect2.Count())
+	assert.Equal(0, collect3.Count())
+
+	// face1 replies Nack~NoRoute, retry on face2
+	face1.Tx <- ndn.MakeNack(collect1.Get(-1).Interest, an.NackNoRoute)
+	fixture.StepDelay()
+	assert.Equal(1, collect2.Count())
+	assert.Equal(0, collect3.Count())
+	assert.Equal(0, collect4.Count())
+
+	// face2 replies Nack~Congestion, retry on face3
+	face2.Tx <- ndn.MakeNack(collect2.Get(-1).Interest, an.NackCongestion)
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+	assert.Equal(1, collect3.Count())
+	assert.Equal(0, collect4.Count())
+
+	// face3 replies Nack~NoRoute, return least severe Nack to downstream
+	face3.Tx <- ndn.MakeNack(collect3.Get(-1).Interest, an.NackNoRoute)
+	fixture.StepDelay()
+	assert.Equal(1, collect4.Count())
+	if packet := collect4.Get(-1); assert.NotNil(packet.Nack) {
+		assert.EqualValues(an.NackCongestion, packet.Nack.Reason)
+		assert.Equal(ndn.NonceFromUint(0x6f1e3d2a), packet.Nack.Interest.Nonce)
+	}
+	assert.Equal(uint64(0), fixture.SumCounter(func(fwd *fwdp.Fwd) uint64 {
+		return fwd.Pit().Counters().NEntries
+	}))
+
+	// face1 is down, forward to face2
+	face1.SetDown(true)
+	face4.Tx <- ndn.MakeInterest("/A/2")
+	fixture.StepDelay()
+	assert.Equal(1, collect1.Count())
+	assert.Equal(2, collect2.Count())
+	assert.Equal(1, collect3.Count())
+}
+
 func TestRoundrobin(t *testing.T) {
 	assert, _ := makeAR(t)
 	fixture := NewFixture(t)
@@ -272,3 +324,70 @@ func TestRoundrobin(t *testing.T) {
 	assert.Equal(1, collect2.Count())
 	assert.Equal(2, collect3.Count())
 }
+
+func TestSelfLearning(t *testing.T) {
+	assert, require := makeAR(t)
+	fixture := NewFixture(t)
+	defer fixture.Close()
+
+	// face2 is a configured nexthop, such as a multicast face; face3 is the producer, whose Data
+	// arrives on a different face than the flooded Interest
+	face1, face2, face3 := intface.MustNew(), intface.MustNew(), intface.MustNew()
+	collect2, collect3 := intface.Collect(face2), intface.Collect(face3)
+	require.NoError(fixture.SetFibEntryParams("/A", "selflearning", map[string]interface{}{"timeout": 100}, face2.ID))
+
+	// dispatch each prefix to a different forwarding thread
+	nFwds := len(fixture.DataPlane.Fwds())
+	require.GreaterOrEqual(nFwds, 2)
+	prefixes := make([]string, nFwds)
+	for i, j, indexSet := 0, 0, map[uint64]bool{}; i < nFwds; j++ {
+		prefix := fmt.Sprintf("/A/%d", j)
+		index := fixture.Ndt.IndexOfName(ndn.ParseName(prefix))
+		if indexSet[index] {
+			continue
+		}
+		indexSet[index] = true
+		fixture.Ndt.Update(index, uint8(i))
+		prefixes[i] = prefix
+		i++
+	}
+
+	for i, prefix := range prefixes {
+		// flood to configured nexthops, including face3 if it has been learned by another thread
+		n2, n3 := collect2.Count(), collect3.Count()
+		face1.Tx <- ndn.MakeInterest(prefix + "/1")
+		fixture.StepDelay()
+		assert.Equal(n2+1, collect2.Count(), "fwd %d", i)
+		if i == 0 {
+			assert.Equal(n3, collect3.Count(), "fwd %d", i)
+		} else {
+			assert.Equal(n3+1, collect3.Count(), "fwd %d", i)
+		}
+
+		// face3 replies Data, which is learned and installed in the FIB entry
+		face3.Tx <- ndn.MakeData(collect2.Get(-1).Interest)
+		fixture.StepDelay()
+		time.Sleep(2 * fwdp.LearnInterval)
+		if entry := fixture.Fib.Find(ndn.ParseName("/A")); assert.NotNil(entry) {
+			assert.Contains(entry.Nexthops, face3.ID, "fwd %d", i)
+		}
+
+		// unicast to face3
+		n2, n3 = collect2.Count(), collect3.Count()
+		face1.Tx <- ndn.MakeInterest(prefix + "/2")
+		fixture.StepDelay()
+		assert.Equal(n2, collect2.Count(), "fwd %d", i)
+		assert.Equal(n3+1, collect3.Count(), "fwd %d", i)
+		face3.Tx <- ndn.MakeData(collect3.Get(-1).Interest)
+		fixture.StepDelay()
+	}
+
+	// unicast Interest from face2 times out, and is not flooded back to face2
+	n2, n3 := collect2.Count(), collect3.Count()
+	face2.Tx <- ndn.MakeInterest(prefixes[0]+"/3", 1000*time.Millisecond)
+	fixture.StepDelay()
+	assert.Equal(n3+1, collect3.Count())
+	time.Sleep(200 * time.Millisecond)
+	assert.Equal(n2, collect2.Count())
+	assert.Equal(n3+1, collect3.Count())
+}
@@ -1,16 +1,22 @@
 package fwdp
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 	"unsafe"
 
+	"github.com/functionalfoundry/graphqlws"
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/container/cs/cscnt"
+	"github.com/usnistgov/ndn-dpdk/container/diskstore"
 	"github.com/usnistgov/ndn-dpdk/container/pit"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver/gqlsub"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/core/runningstat"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
@@ -26,14 +32,47 @@ var (
 
 // GraphQL types.
 var (
-	GqlInputNodeType   *gqlserver.NodeType
-	GqlInputType       *graphql.Object
-	GqlFwdCountersType *graphql.Object
-	GqlFwdNodeType     *gqlserver.NodeType
-	GqlFwdType         *graphql.Object
-	GqlDataPlaneType   *graphql.Object
+	GqlInputNodeType       *gqlserver.NodeType
+	GqlInputType           *graphql.Object
+	GqlFwdCountersType     *graphql.Object
+	GqlFwdNodeType         *gqlserver.NodeType
+	GqlFwdType             *graphql.Object
+	GqlFwdCountersDiffType *graphql.Object
+	GqlDataPlaneType       *graphql.Object
 )
 
+// fwdCountersDiff contains forwarding thread table and queue counters, as differences since the previous update.
+type fwdCountersDiff struct {
+	Pit          pit.Counters   `json:"pitCounters" gqldesc:"PIT counters."`
+	Cs           cscnt.Counters `json:"csCounters" gqldesc:"CS counters."`
+	NQueueDropsI uint64         `json:"nInterestsQueueDrops" gqldesc:"Interests dropped or marked by input queue."`
+	NQueueDropsD uint64         `json:"nDataQueueDrops" gqldesc:"Data dropped or marked by input queue."`
+	NQueueDropsN uint64         `json:"nNacksQueueDrops" gqldesc:"Nacks dropped or marked by input queue."`
+}
+
+type fwdCountersSnapshot struct {
+	pit                    pit.Counters
+	cs                     cscnt.Counters
+	queueI, queueD, queueN iface.PktQueueCounters
+}
+
+func readFwdCountersSnapshot(fwd *Fwd) (s fwdCountersSnapshot) {
+	s.pit = fwd.Pit().Counters()
+	s.cs = cscnt.ReadCounters(fwd.Pit(), fwd.Cs())
+	s.queueI, s.queueD, s.queueN = fwd.queueI.Counters(), fwd.queueD.Counters(), fwd.queueN.Counters()
+	return s
+}
+
+func (s fwdCountersSnapshot) Since(prev fwdCountersSnapshot) fwdCountersDiff {
+	return fwdCountersDiff{
+		Pit:          s.pit.Since(prev.pit),
+		Cs:           s.cs.Since(prev.cs),
+		NQueueDropsI: s.queueI.Since(prev.queueI).NDrops,
+		NQueueDropsD: s.queueD.Since(prev.queueD).NDrops,
+		NQueueDropsN: s.queueN.Since(prev.queueN).NDrops,
+	}
+}
+
 func init() {
 	GqlInputNodeType = gqlserver.NewNodeType((*Input)(nil))
 	GqlInputNodeType.Retrieve = func(id string) (interface{}, error) {
@@ -177,6 +216,66 @@ func init() {
 	}))
 	GqlFwdNodeType.Register(GqlFwdType)
 
+	GqlFwdCountersDiffType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "FwFwdCountersDiff",
+		Fields: gqlserver.BindFields(fwdCountersDiff{}, gqlserver.FieldTypes{
+			reflect.TypeOf(pit.Counters{}):   pit.GqlCountersType,
+			reflect.TypeOf(cscnt.Counters{}): cscnt.GqlCountersType,
+		}),
+	})
+
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "fwdCounters",
+		Description: "Obtain PIT, CS, and input queue counters of a forwarding thread, as differences since the previous update.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Description: "Forwarding thread ID.",
+				Type:        gqlserver.NonNullID,
+			},
+			"interval": &graphql.ArgumentConfig{
+				Description: "Interval between updates.",
+				Type:        nnduration.GqlNanoseconds,
+			},
+		},
+		Type: GqlFwdCountersDiffType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Info.RootValue.(fwdCountersDiff), nil
+		},
+	}, func(ctx context.Context, sub *graphqlws.Subscription, updates chan<- interface{}) {
+		defer close(updates)
+
+		id, ok := gqlsub.GetArg(sub, "id", graphql.ID).(string)
+		if !ok {
+			return
+		}
+		var fwd *Fwd
+		if e := gqlserver.RetrieveNodeOfType(GqlFwdNodeType, id, &fwd); e != nil {
+			return
+		}
+
+		interval, ok := gqlsub.GetArg(sub, "interval", nnduration.GqlNanoseconds).(nnduration.Nanoseconds)
+		if !ok {
+			return
+		}
+
+		prev := readFwdCountersSnapshot(fwd)
+		ticker := time.NewTicker(interval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !fwd.IsRunning() {
+					return
+				}
+				cnt := readFwdCountersSnapshot(fwd)
+				updates <- cnt.Since(prev)
+				prev = cnt
+			}
+		}
+	})
+
 	GqlDataPlaneType = graphql.NewObject(graphql.ObjectConfig{
 		Name: "FwDataPlane",
 		Fields: graphql.Fields{
@@ -196,6 +295,17 @@ func init() {
 					return dp.fwds, nil
 				},
 			},
+			"diskCounters": &graphql.Field{
+				Description: "DiskStore counters, null if disk caching is disabled.",
+				Type:        diskstore.GqlCountersType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					dp := p.Source.(*DataPlane)
+					if dp.disk == nil {
+						return nil, nil
+					}
+					return dp.disk.store.Counters(), nil
+				},
+			},
 		},
 	})
 
@@ -0,0 +1,140 @@
+package fwdp
+
+/*
+#include "../../csrc/fwdp/fwd.h"
+*/
+import "C"
+import (
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/ethport"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"go.uber.org/zap"
+)
+
+var logger = logging.New("fwdp")
+
+const (
+	learnQueueCapacity = 256
+	learnBurstSize     = 16
+
+	// LearnInterval is the interval of applying learned nexthop requests to the FIB,
+	// and expiring learned nexthops.
+	LearnInterval = 100 * time.Millisecond
+)
+
+// learnRequest is a request from a strategy to add, refresh, or remove a learned nexthop.
+type learnRequest struct {
+	Name     ndn.Name
+	Nexthop  iface.ID
+	Lifetime time.Duration // zero means removal
+}
+
+func (fwd *Fwd) initLearnQueue(socket eal.NumaSocket) error {
+	nameC := C.CString(eal.AllocObjectID("fwdp.LearnQueue"))
+	defer C.free(unsafe.Pointer(nameC))
+	fwd.c.learnQueue = C.rte_ring_create_elem(nameC, C.sizeof_FwLearnRecord, learnQueueCapacity,
+		C.int(socket.ID()), C.RING_F_SP_ENQ|C.RING_F_SC_DEQ)
+	if fwd.c.learnQueue == nil {
+		return eal.GetErrno()
+	}
+	return nil
+}
+
+func (fwd *Fwd) closeLearnQueue() {
+	C.rte_ring_free(fwd.c.learnQueue)
+	fwd.c.learnQueue = nil
+}
+
+// dequeueLearn retrieves pending learned nexthop requests.
+func (fwd *Fwd) dequeueLearn() (reqs []learnRequest) {
+	var recs [learnBurstSize]C.FwLearnRecord
+	for {
+		n := int(C.rte_ring_dequeue_burst_elem(fwd.c.learnQueue, unsafe.Pointer(&recs[0]),
+			C.sizeof_FwLearnRecord, C.uint(len(recs)), nil))
+		for _, rec := range recs[:n] {
+			req := learnRequest{
+				Nexthop:  iface.ID(rec.nh),
+				Lifetime: time.Duration(rec.lifetime) * time.Millisecond,
+			}
+			req.Name.UnmarshalBinary(C.GoBytes(unsafe.Pointer(&rec.nameV[0]), C.int(rec.nameL)))
+			reqs = append(reqs, req)
+		}
+		if n < len(recs) {
+			return reqs
+		}
+	}
+}
+
+func (dp *DataPlane) startLearner() {
+	dp.learnStop, dp.learnDone = make(chan struct{}), make(chan struct{})
+	go dp.runLearner(dp.learnStop, dp.learnDone)
+}
+
+func (dp *DataPlane) runLearner(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(LearnInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			dp.learnStep()
+		}
+	}
+}
+
+func (dp *DataPlane) stopLearner() {
+	if dp.learnStop != nil {
+		close(dp.learnStop)
+		<-dp.learnDone
+		dp.learnStop, dp.learnDone = nil, nil
+	}
+}
+
+// learnStep applies learned nexthop requests from strategies to the FIB, and expires learned
+// nexthops.
+func (dp *DataPlane) learnStep() {
+	for _, fwd := range dp.fwds {
+		for _, req := range fwd.dequeueLearn() {
+			var e error
+			switch {
+			case req.Lifetime > 0 && isMulticastFace(req.Nexthop):
+				continue
+			case req.Lifetime > 0:
+				e = dp.fib.LearnNexthop(req.Name, req.Nexthop, req.Lifetime)
+			default:
+				e = dp.fib.ForgetNexthop(req.Name, req.Nexthop)
+			}
+			if e != nil {
+				logger.Debug("learned nexthop error",
+					zap.Stringer("fwd", fwd),
+					zap.Stringer("name", req.Name),
+					req.Nexthop.ZapField("nexthop"),
+					zap.Duration("lifetime", req.Lifetime),
+					zap.Error(e),
+				)
+			}
+		}
+	}
+
+	if e := dp.fib.ExpireLearnedNexthops(time.Now()); e != nil {
+		logger.Debug("learned nexthop expiry error", zap.Error(e))
+	}
+}
+
+// isMulticastFace determines whether a face communicates with a multicast group.
+// Such a face cannot become a learned nexthop, because it does not lead to a single producer.
+func isMulticastFace(id iface.ID) bool {
+	face := iface.Get(id)
+	if face == nil {
+		return false
+	}
+	loc, ok := face.Locator().(ethport.Locator)
+	return ok && loc.EthCLocator().IsMulticast()
+}
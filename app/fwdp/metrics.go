@@ -0,0 +1,31 @@
+package fwdp
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/container/cs/cscnt"
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+)
+
+var queueDropsFamily = openmetrics.Family{
+	Name: "ndndpdk_fwd_queue_drops",
+	Help: "Packets dropped or congestion marked by forwarding thread input queue.",
+	Type: openmetrics.Counter,
+}
+
+func init() {
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		if GqlDataPlane == nil {
+			return
+		}
+		for _, fwd := range GqlDataPlane.fwds {
+			labels := openmetrics.Labels{"fwd": strconv.Itoa(fwd.id)}
+			s.AddStruct("ndndpdk_fwd_", labels, fwd.Counters())
+			s.AddStruct("ndndpdk_pit_", labels, fwd.Pit().Counters())
+			s.AddStruct("ndndpdk_cs_", labels, cscnt.ReadCounters(fwd.Pit(), fwd.Cs()))
+			s.Add(queueDropsFamily, labels.With("queue", "interests"), fwd.queueI.Counters().NDrops)
+			s.Add(queueDropsFamily, labels.With("queue", "data"), fwd.queueD.Counters().NDrops)
+			s.Add(queueDropsFamily, labels.With("queue", "nacks"), fwd.queueN.Counters().NDrops)
+		}
+	})
+}
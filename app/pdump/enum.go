@@ -6,6 +6,25 @@ const (
 	// MaxNames is the maximum number of name filters.
 	MaxNames = 4
 
+	// MaxFaces is the maximum number of faces in EthPortSource face filter.
+	MaxFaces = 8
+
+	// MaxFragBases is the number of LpSeqNum bases of captured first fragments remembered by
+	// FaceSource, so that subsequent fragments of the same packets are captured too.
+	MaxFragBases = 64
+
+	// PktBitInterest indicates Interest in packet type filter.
+	PktBitInterest = 1 << 0
+
+	// PktBitData indicates Data in packet type filter.
+	PktBitData = 1 << 1
+
+	// PktBitNack indicates Nack in packet type filter.
+	PktBitNack = 1 << 2
+
+	// PktBitFragment indicates NDNLPv2 fragment in packet type filter.
+	PktBitFragment = 1 << 3
+
 	// WriterBurstSize is the burst size in the writer.
 	WriterBurstSize = 64
 
@@ -31,4 +50,6 @@ const (
 const (
 	MinFileSize     = 1 << 16
 	DefaultFileSize = 1 << 24
+
+	MaxFiles = 1024
 )
@@ -8,6 +8,7 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"unsafe"
 
 	"github.com/google/gopacket/layers"
@@ -27,10 +28,63 @@ type EthGrab string
 
 // EthGrab values.
 const (
+	// EthGrabRxUnmatched captures incoming frames that do not match any face.
+	// This requires the port to use RxTable.
 	EthGrabRxUnmatched EthGrab = "RxUnmatched"
+
+	// EthGrabRxFlow captures incoming frames dispatched to faces by hardware flows, including
+	// frames that are subsequently rejected by software matching.
+	// This requires the port to use RxFlow.
+	EthGrabRxFlow EthGrab = "RxFlow"
 )
 
-var ethPortSources = map[*ethport.Port]*EthPortSource{}
+var ethGrabImpls = map[EthGrab]struct {
+	getRef   func(port *ethport.Port) *C.PdumpSourceRef
+	errNoRef error
+	hasFaces bool
+}{
+	EthGrabRxUnmatched: {
+		func(port *ethport.Port) *C.PdumpSourceRef {
+			rxt := (*C.EthRxTable)(ethport.RxTablePtrFromPort(port))
+			if rxt == nil {
+				return nil
+			}
+			return &rxt.pdumpUnmatched
+		},
+		errors.New("port is not using RxTable"),
+		false,
+	},
+	EthGrabRxFlow: {
+		func(port *ethport.Port) *C.PdumpSourceRef {
+			return (*C.PdumpSourceRef)(ethport.RxFlowPdumpPtrFromPort(port))
+		},
+		errors.New("port is not using RxFlow"),
+		true,
+	},
+}
+
+type ethPortGrab struct {
+	port *ethport.Port
+	grab EthGrab
+}
+
+var (
+	ethPortSources     = map[ethPortGrab]*EthPortSource{}
+	ethPortClosingOnce sync.Once
+)
+
+func handlePortClosing(port *ethport.Port) {
+	sourcesMutex.Lock()
+	defer sourcesMutex.Unlock()
+
+	for grab := range ethGrabImpls {
+		s, ok := ethPortSources[ethPortGrab{port, grab}]
+		if !ok {
+			continue
+		}
+		s.closeImpl()
+	}
+}
 
 // EthPortConfig contains EthPortSource configuration.
 type EthPortConfig struct {
@@ -38,7 +92,11 @@ type EthPortConfig struct {
 	Port   *ethport.Port
 	Grab   EthGrab
 
-	rxt *C.EthRxTable
+	// Faces restricts captured frames to those dispatched to these faces.
+	// This is only supported with EthGrabRxFlow. Empty list captures frames of all faces.
+	Faces []iface.Face
+
+	ref *C.PdumpSourceRef
 }
 
 func (cfg *EthPortConfig) validate() error {
@@ -48,14 +106,28 @@ func (cfg *EthPortConfig) validate() error {
 		errs = append(errs, errors.New("writer not found"))
 	}
 
-	if cfg.Port == nil {
+	grabImpl, ok := ethGrabImpls[cfg.Grab]
+	switch {
+	case !ok:
+		errs = append(errs, errors.New("grab not supported"))
+	case cfg.Port == nil:
 		errs = append(errs, errors.New("port not found"))
-	} else if cfg.rxt = (*C.EthRxTable)(ethport.RxTablePtrFromPort(cfg.Port)); cfg.rxt == nil {
-		errs = append(errs, errors.New("port is not using RxTable"))
+	default:
+		if cfg.ref = grabImpl.getRef(cfg.Port); cfg.ref == nil {
+			errs = append(errs, grabImpl.errNoRef)
+		}
 	}
 
-	if cfg.Grab != EthGrabRxUnmatched {
-		errs = append(errs, errors.New("grab not supported"))
+	if len(cfg.Faces) > 0 && !grabImpl.hasFaces {
+		errs = append(errs, errors.New("face filter not supported on this grab"))
+	}
+	if len(cfg.Faces) > MaxFaces {
+		errs = append(errs, fmt.Errorf("cannot have more than %d faces", MaxFaces))
+	}
+	for _, face := range cfg.Faces {
+		if face == nil {
+			errs = append(errs, errors.New("face not found"))
+		}
 	}
 
 	return multierr.Combine(errs...)
@@ -64,12 +136,13 @@ func (cfg *EthPortConfig) validate() error {
 // EthPortSource is a packet dump source attached to an Ethernet port on a grab opportunity.
 type EthPortSource struct {
 	EthPortConfig
+	key    ethPortGrab
 	logger *zap.Logger
-	c      *C.PdumpSource
+	c      *C.PdumpEthPortSource
 }
 
 func (s *EthPortSource) setRef(expected, newPtr *C.PdumpSource) {
-	setSourceRef(&s.rxt.pdumpUnmatched, expected, newPtr)
+	setSourceRef(s.ref, expected, newPtr)
 }
 
 // Close detaches the dump source.
@@ -81,8 +154,8 @@ func (s *EthPortSource) Close() error {
 
 func (s *EthPortSource) closeImpl() error {
 	s.logger.Info("EthPortSource close")
-	s.setRef(s.c, nil)
-	delete(ethPortSources, s.Port)
+	s.setRef(&s.c.base, nil)
+	delete(ethPortSources, s.key)
 
 	go func() {
 		urcu.Synchronize()
@@ -104,22 +177,30 @@ func NewEthPortSource(cfg EthPortConfig) (s *EthPortSource, e error) {
 
 	s = &EthPortSource{
 		EthPortConfig: cfg,
+		key:           ethPortGrab{cfg.Port, cfg.Grab},
 	}
-	if _, ok := ethPortSources[s.Port]; ok {
-		return nil, errors.New("another EthPortSource is attached to this port")
+	if _, ok := ethPortSources[s.key]; ok {
+		return nil, errors.New("another EthPortSource is attached to this port and grab")
 	}
 	dev := s.Port.EthDev()
 	id, socket := dev.ID(), dev.NumaSocket()
 
-	s.logger = logger.With(dev.ZapField("port"))
-	s.c = (*C.PdumpSource)(eal.Zmalloc("PdumpSource", C.sizeof_PdumpSource, socket))
-	*s.c = C.PdumpSource{
+	s.logger = logger.With(dev.ZapField("port"), zap.String("grab", string(s.Grab)))
+	s.c = (*C.PdumpEthPortSource)(eal.Zmalloc("PdumpEthPortSource", C.sizeof_PdumpEthPortSource, socket))
+	s.c.base = C.PdumpSource{
 		directMp: (*C.struct_rte_mempool)(pktmbuf.Direct.Get(socket).Ptr()),
 		queue:    s.Writer.c.queue,
 		filter:   nil,
 		mbufType: MbufTypeRaw,
 		mbufPort: C.uint16_t(id),
-		mbufCopy: false,
+		mbufCopy: s.Grab != EthGrabRxUnmatched, // RxFlow frames continue to be processed after capture
+	}
+	if len(s.Faces) > 0 {
+		s.c.base.filter = C.PdumpSource_Filter(C.PdumpEthPortSource_Filter)
+		for i, face := range s.Faces {
+			s.c.faces[i] = C.FaceID(face.ID())
+		}
+		s.c.nFaces = C.uint8_t(len(s.Faces))
 	}
 
 	s.Writer.defineIntf(id, pcapgo.NgInterface{
@@ -128,9 +209,10 @@ func NewEthPortSource(cfg EthPortConfig) (s *EthPortSource, e error) {
 		LinkType:    layers.LinkTypeEthernet,
 	})
 	s.Writer.startSource()
-	s.setRef(nil, s.c)
+	s.setRef(nil, &s.c.base)
 
-	ethPortSources[s.Port] = s
+	ethPortClosingOnce.Do(func() { ethport.OnPortClosing(handlePortClosing) })
+	ethPortSources[s.key] = s
 	s.logger.Info("EthPortSource open",
 		zap.Uintptr("dumper", uintptr(unsafe.Pointer(s.c))),
 		zap.Uintptr("queue", uintptr(unsafe.Pointer(s.Writer.c.queue))),
@@ -17,11 +17,13 @@ import (
 
 	"github.com/google/gopacket/layers"
 	"github.com/google/gopacket/pcapgo"
+	"github.com/usnistgov/ndn-dpdk/core/cptr"
 	"github.com/usnistgov/ndn-dpdk/core/urcu"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -34,6 +36,10 @@ type Direction string
 const (
 	DirIncoming Direction = "RX"
 	DirOutgoing Direction = "TX"
+
+	// DirIncomingDrop captures incoming frames dropped by the face, due to NDNLPv2 or network
+	// layer decoding errors or partial messages evicted from the reassembler.
+	DirIncomingDrop Direction = "RXDROP"
 )
 
 var dirImpls = map[Direction]struct {
@@ -48,6 +54,10 @@ var dirImpls = map[Direction]struct {
 		C.SLLOutgoing,
 		func(faceC *C.Face) *C.PdumpSourceRef { return &faceC.impl.tx.pdump },
 	},
+	DirIncomingDrop: {
+		C.SLLIncoming,
+		func(faceC *C.Face) *C.PdumpSourceRef { return &faceC.impl.rx.pdumpDrop },
+	},
 }
 
 type faceDir struct {
@@ -88,6 +98,7 @@ type FaceConfig struct {
 	Face   iface.Face
 	Dir    Direction
 	Names  []NameFilterEntry
+	Filter PacketFilter
 }
 
 func (cfg *FaceConfig) validate() error {
@@ -115,6 +126,7 @@ func (cfg *FaceConfig) validate() error {
 		}
 	}
 
+	errs = append(errs, cfg.Filter.validate())
 	return multierr.Combine(errs...)
 }
 
@@ -125,6 +137,77 @@ type NameFilterEntry struct {
 	SampleProbability float64  `json:"sampleProbability" gqldesc:"Sample probability between 0.0 and 1.0." gqldflt:"1.0"`
 }
 
+// PktType indicates a packet type in PacketFilter.
+type PktType string
+
+// PktType values.
+const (
+	PktInterest PktType = "Interest"
+	PktData     PktType = "Data"
+	PktNack     PktType = "Nack"
+	PktFragment PktType = "Fragment"
+)
+
+var pktTypeBits = map[PktType]C.uint8_t{
+	PktInterest: C.PdumpPktBitInterest,
+	PktData:     C.PdumpPktBitData,
+	PktNack:     C.PdumpPktBitNack,
+	PktFragment: C.PdumpPktBitFragment,
+}
+
+// PacketFilter matches packets by packet type and NDNLPv2 header fields.
+// A packet is captured only if it satisfies every non-empty field, in addition to the name filter.
+// The zero value matches every packet.
+type PacketFilter struct {
+	// Types lists acceptable packet types.
+	// A packet that is fragmented by NDNLPv2 has type "Fragment", regardless of its network layer type.
+	Types []PktType `json:"types,omitempty" gqldesc:"Acceptable packet types, empty means any."`
+
+	// PitToken is a PIT token prefix.
+	PitToken []byte `json:"pitToken,omitempty" gqldesc:"PIT token prefix."`
+
+	// NackReasons lists acceptable NackReason values.
+	// If non-empty, only Nacks are captured.
+	NackReasons []int `json:"nackReasons,omitempty" gqldesc:"Acceptable Nack reasons, empty means any."`
+
+	// CongMarked indicates that only packets carrying CongestionMark are captured.
+	CongMarked bool `json:"congMarked,omitempty" gqldesc:"Capture only congestion marked packets."`
+}
+
+func (f PacketFilter) validate() error {
+	errs := []error{}
+	for _, t := range f.Types {
+		if _, ok := pktTypeBits[t]; !ok {
+			errs = append(errs, fmt.Errorf("unknown packet type %s", t))
+		}
+	}
+	if len(f.PitToken) > 32 {
+		errs = append(errs, errors.New("PIT token prefix cannot exceed 32 octets"))
+	}
+	for _, reason := range f.NackReasons {
+		if reason <= an.NackNone || reason > math.MaxUint8 {
+			errs = append(errs, fmt.Errorf("invalid Nack reason %d", reason))
+		}
+	}
+	return multierr.Combine(errs...)
+}
+
+func (f PacketFilter) isEmpty() bool {
+	return len(f.Types) == 0 && len(f.PitToken) == 0 && len(f.NackReasons) == 0 && !f.CongMarked
+}
+
+func (f PacketFilter) assign(c *C.PdumpFaceSource) {
+	c.filterAttrs = C.bool(!f.isEmpty())
+	for _, t := range f.Types {
+		c.pktTypes |= pktTypeBits[t]
+	}
+	c.congMarked = C.bool(f.CongMarked)
+	c.pitToken.length = C.uint8_t(copy(cptr.AsByteSlice(&c.pitToken.value), f.PitToken))
+	for _, reason := range f.NackReasons {
+		c.nackReasons[reason>>6] |= 1 << (reason & 0x3F)
+	}
+}
+
 // FaceSource is a packet dump source attached to a face on a single direction.
 type FaceSource struct {
 	FaceConfig
@@ -200,6 +283,7 @@ func NewFaceSource(cfg FaceConfig) (s *FaceSource, e error) {
 		}
 		s.c.sample[i] = C.uint32_t(math.Ceil(nf.SampleProbability * math.MaxUint32))
 	}
+	s.Filter.assign(s.c)
 
 	s.Writer.defineIntf(int(s.Face.ID()), pcapgo.NgInterface{
 		Name:        fmt.Sprintf("face%d", s.Face.ID()),
@@ -32,6 +32,9 @@ var (
 	GqlEthGrabEnum           *graphql.Enum
 	GqlNameFilterEntryInput  *graphql.InputObject
 	GqlNameFilterEntryType   *graphql.Object
+	GqlPktTypeEnum           *graphql.Enum
+	GqlPacketFilterInput     *graphql.InputObject
+	GqlPacketFilterType      *graphql.Object
 	GqlWriterNodeType        *gqlserver.NodeType
 	GqlWriterType            *graphql.Object
 	GqlFaceSourceNodeType    *gqlserver.NodeType
@@ -42,8 +45,9 @@ var (
 )
 
 func init() {
-	GqlDirectionEnum = gqlserver.NewStringEnum("PdumpDirection", "Packet dump traffic direction.", DirIncoming, DirOutgoing)
-	GqlEthGrabEnum = gqlserver.NewStringEnum("PdumpEthGrab", "Packet dump Ethernet port grab position.", EthGrabRxUnmatched)
+	GqlDirectionEnum = gqlserver.NewStringEnum("PdumpDirection", "Packet dump traffic direction.", DirIncoming, DirOutgoing, DirIncomingDrop)
+	GqlEthGrabEnum = gqlserver.NewStringEnum("PdumpEthGrab", "Packet dump Ethernet port grab position.", EthGrabRxUnmatched, EthGrabRxFlow)
+	GqlPktTypeEnum = gqlserver.NewStringEnum("PdumpPktType", "Packet dump packet type.", PktInterest, PktData, PktNack, PktFragment)
 	GqlNameFilterEntryInput = graphql.NewInputObject(graphql.InputObjectConfig{
 		Name:        "PdumpNameFilterEntryInput",
 		Description: "Packet dump name filter entry.",
@@ -58,6 +62,20 @@ func init() {
 			reflect.TypeOf(ndn.Name{}): ndni.GqlNameType,
 		}),
 	})
+	packetFilterTypes := gqlserver.FieldTypes{
+		reflect.TypeOf(PktInterest): GqlPktTypeEnum,
+		reflect.TypeOf([]byte{}):    gqlserver.Bytes,
+	}
+	GqlPacketFilterInput = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "PdumpPacketFilterInput",
+		Description: "Packet dump packet attribute filter.",
+		Fields:      gqlserver.BindInputFields(PacketFilter{}, packetFilterTypes),
+	})
+	GqlPacketFilterType = graphql.NewObject(graphql.ObjectConfig{
+		Name:        "PdumpPacketFilter",
+		Description: "Packet dump packet attribute filter.",
+		Fields:      gqlserver.BindFields(PacketFilter{}, packetFilterTypes),
+	})
 
 	GqlWriterNodeType = gqlserver.NewNodeType((*Writer)(nil))
 	GqlWriterNodeType.GetID = func(source interface{}) string {
@@ -99,6 +117,22 @@ func init() {
 					return w.filename, nil
 				},
 			},
+			"maxFiles": &graphql.Field{
+				Description: "Number of rotated files to keep, 0 if rotation is disabled.",
+				Type:        gqlserver.NonNullInt,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					w := p.Source.(*Writer)
+					return w.maxFiles, nil
+				},
+			},
+			"stream": &graphql.Field{
+				Description: "Whether output is streamed to a Unix socket or FIFO.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					w := p.Source.(*Writer)
+					return w.stream, nil
+				},
+			},
 			"worker": ealthread.GqlWithWorker(nil),
 		},
 	}))
@@ -109,13 +143,17 @@ func init() {
 		Description: "Start packet dump writer.",
 		Args: graphql.FieldConfigArgument{
 			"filename": &graphql.ArgumentConfig{
-				Description: "Output file name.",
+				Description: "Output file name. If it refers to an existing Unix socket or FIFO, output is streamed into it.",
 				Type:        gqlserver.NonNullString,
 			},
 			"maxSize": &graphql.ArgumentConfig{
 				Description: "Maximum output file size in bytes. Storage will be pre-allocated.",
 				Type:        graphql.Int,
 			},
+			"maxFiles": &graphql.ArgumentConfig{
+				Description: "Enable file rotation and keep this many files. Default is stopping when the file is full.",
+				Type:        graphql.Int,
+			},
 		},
 		Type: graphql.NewNonNull(GqlWriterType),
 		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -131,6 +169,9 @@ func init() {
 			if maxSize, ok := p.Args["maxSize"]; ok {
 				cfg.MaxSize = maxSize.(int)
 			}
+			if maxFiles, ok := p.Args["maxFiles"]; ok {
+				cfg.MaxFiles = maxFiles.(int)
+			}
 			w, e := NewWriter(cfg)
 			if e != nil {
 				return nil, e
@@ -213,6 +254,14 @@ func init() {
 					return s.Names, nil
 				},
 			},
+			"filter": &graphql.Field{
+				Description: "Packet attribute filter.",
+				Type:        graphql.NewNonNull(GqlPacketFilterType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					s := p.Source.(*FaceSource)
+					return s.Filter, nil
+				},
+			},
 		},
 	}))
 	GqlFaceSourceNodeType.Register(GqlFaceSourceType)
@@ -237,6 +286,10 @@ func init() {
 				Description: "Name filter.",
 				Type:        gqlserver.NewNonNullList(GqlNameFilterEntryInput),
 			},
+			"filter": &graphql.ArgumentConfig{
+				Description: "Packet attribute filter.",
+				Type:        GqlPacketFilterInput,
+			},
 		},
 		Type: graphql.NewNonNull(GqlFaceSourceType),
 		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -245,6 +298,9 @@ func init() {
 			gqlserver.RetrieveNodeOfType(iface.GqlFaceNodeType, p.Args["face"], &cfg.Face)
 			cfg.Dir = p.Args["dir"].(Direction)
 			jsonhelper.Roundtrip(p.Args["names"], &cfg.Names)
+			if filter, ok := p.Args["filter"]; ok {
+				jsonhelper.Roundtrip(filter, &cfg.Filter)
+			}
 			return NewFaceSource(cfg)
 		},
 	})
@@ -252,10 +308,15 @@ func init() {
 	GqlEthPortSourceNodeType = gqlserver.NewNodeType((*EthPortSource)(nil))
 	GqlEthPortSourceNodeType.GetID = func(source interface{}) string {
 		s := source.(*EthPortSource)
-		return strconv.Itoa(s.Port.EthDev().ID())
+		return fmt.Sprintf("%d-%s", s.Port.EthDev().ID(), s.Grab)
 	}
 	GqlEthPortSourceNodeType.Retrieve = func(id string) (interface{}, error) {
-		ethDevObj, _ := ethdev.GqlEthDevNodeType.Retrieve(id)
+		var devID int
+		var grab EthGrab
+		if _, e := fmt.Sscanf(id, "%d-%s", &devID, &grab); e != nil {
+			return nil, e
+		}
+		ethDevObj, _ := ethdev.GqlEthDevNodeType.Retrieve(strconv.Itoa(devID))
 		ethDev, _ := ethDevObj.(ethdev.EthDev)
 		port := ethport.Find(ethDev)
 		if port == nil {
@@ -264,7 +325,7 @@ func init() {
 
 		sourcesMutex.Lock()
 		defer sourcesMutex.Unlock()
-		return ethPortSources[port], nil
+		return ethPortSources[ethPortGrab{port, grab}], nil
 	}
 	GqlEthPortSourceNodeType.Delete = func(source interface{}) error {
 		s := source.(*EthPortSource)
@@ -299,6 +360,14 @@ func init() {
 					return s.Grab, nil
 				},
 			},
+			"faces": &graphql.Field{
+				Description: "Face filter.",
+				Type:        gqlserver.NewNonNullList(iface.GqlFaceType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					s := p.Source.(*EthPortSource)
+					return s.Faces, nil
+				},
+			},
 		},
 	}))
 	GqlEthPortSourceNodeType.Register(GqlEthPortSourceType)
@@ -319,6 +388,10 @@ func init() {
 				Description: "Grab opportunity.",
 				Type:        graphql.NewNonNull(GqlEthGrabEnum),
 			},
+			"faces": &graphql.ArgumentConfig{
+				Description: "Face filter, only supported with RxFlow grab.",
+				Type:        graphql.NewList(gqlserver.NonNullID),
+			},
 		},
 		Type: graphql.NewNonNull(GqlEthPortSourceType),
 		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -329,6 +402,13 @@ func init() {
 				cfg.Port = ethport.Find(ethDev)
 			}
 			cfg.Grab = p.Args["grab"].(EthGrab)
+			if faces, ok := p.Args["faces"].([]interface{}); ok {
+				for _, id := range faces {
+					var face iface.Face
+					gqlserver.RetrieveNodeOfType(iface.GqlFaceNodeType, id, &face)
+					cfg.Faces = append(cfg.Faces, face)
+				}
+			}
 			return NewEthPortSource(cfg)
 		},
 	})
@@ -20,6 +20,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/iface/ethface"
 	"github.com/usnistgov/ndn-dpdk/iface/ethport"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 	"inet.af/netaddr"
 )
@@ -151,3 +152,74 @@ func TestEthPortUnmatched(t *testing.T) {
 		os.Rename(filename, save)
 	}
 }
+
+func TestEthPortRxFlow(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName()
+	defer del()
+
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		MaxSize:      1 << 22,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+
+	pair, e := ethringdev.NewPair(ethringdev.PairConfig{
+		NQueues: 2,
+		RxPool:  mbuftestenv.DirectMempool(),
+	})
+	require.NoError(e)
+	portT, e := ethport.New(ethport.Config{EthDev: pair.PortA})
+	require.NoError(e)
+	defer portT.Close()
+	portF, e := ethport.New(ethport.Config{EthDev: pair.PortB, RxFlowQueues: 2})
+	require.NoError(e)
+
+	// RxFlow grab requires RxFlow receive path, and RxUnmatched grab requires RxTable
+	_, e = pdump.NewEthPortSource(pdump.EthPortConfig{
+		Writer: w,
+		Port:   portT,
+		Grab:   pdump.EthGrabRxFlow,
+	})
+	assert.Error(e)
+	_, e = pdump.NewEthPortSource(pdump.EthPortConfig{
+		Writer: w,
+		Port:   portF,
+		Grab:   pdump.EthGrabRxUnmatched,
+	})
+	assert.Error(e)
+
+	// face filter is only supported on RxFlow grab
+	faceI := intface.MustNew()
+	defer faceI.D.Close()
+	_, e = pdump.NewEthPortSource(pdump.EthPortConfig{
+		Writer: w,
+		Port:   portT,
+		Grab:   pdump.EthGrabRxUnmatched,
+		Faces:  []iface.Face{faceI.D},
+	})
+	assert.Error(e)
+
+	dump, e := pdump.NewEthPortSource(pdump.EthPortConfig{
+		Writer: w,
+		Port:   portF,
+		Grab:   pdump.EthGrabRxFlow,
+		Faces:  []iface.Face{faceI.D},
+	})
+	require.NoError(e)
+	_, e = pdump.NewEthPortSource(pdump.EthPortConfig{
+		Writer: w,
+		Port:   portF,
+		Grab:   pdump.EthGrabRxFlow,
+	})
+	assert.Error(e, "only one source per port and grab")
+	_ = dump // closing the port should automatically close the source
+
+	assert.NoError(portF.Close())
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(w.Close())
+}
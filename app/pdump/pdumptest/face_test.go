@@ -1,6 +1,7 @@
 package pdumptest
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -16,7 +17,10 @@ import (
 	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/sockettransport"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 )
@@ -129,3 +133,370 @@ func TestFaceRxTx(t *testing.T) {
 		os.Rename(filename, save)
 	}
 }
+
+func TestFaceFragments(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName()
+	defer del()
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		MaxSize:      1 << 22,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+
+	face := intface.Must(intface.New(socketface.Config{
+		Config: iface.Config{MTU: 1200},
+	}))
+	go func() {
+		for range face.Rx {
+		}
+	}()
+
+	_, e = pdump.NewFaceSource(pdump.FaceConfig{
+		Writer: w,
+		Face:   face.D,
+		Dir:    pdump.DirOutgoing,
+		Names: []pdump.NameFilterEntry{
+			{Name: ndn.ParseName("/A"), SampleProbability: 1.0},
+		},
+	})
+	require.NoError(e)
+
+	const nBursts, nBurstSize = 32, 4
+	payload := make([]byte, 3000)
+	for i := 0; i < nBursts; i++ {
+		pkts := make([]*ndni.Packet, nBurstSize)
+		for j := range pkts {
+			pkts[j] = makeData(fmt.Sprintf("/%c/%d/%d", 'A'+j%2, i, j), payload)
+		}
+		iface.TxBurst(face.ID, pkts)
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	face.D.Close()
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(w.Close())
+
+	f, e := os.Open(filename)
+	require.NoError(e)
+	defer f.Close()
+	r, e := pcapgo.NewNgReader(f, pcapgo.DefaultNgReaderOptions)
+	require.NoError(e)
+
+	prefixA := ndn.ParseName("/A")
+	reass := ndn.NewLpReassembler(nBursts * nBurstSize)
+	nFragments, nDataA := 0, 0
+	var sll layers.LinuxSLL
+	parser := gopacket.NewDecodingLayerParser(layers.LayerTypeLinuxSLL, &sll)
+	parser.IgnoreUnsupported = true
+	decoded := []gopacket.LayerType{}
+	for {
+		pkt, _, e := r.ReadPacketData()
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		if !assert.NoError(parser.DecodeLayers(pkt, &decoded)) {
+			continue
+		}
+		var npkt ndn.Packet
+		if !assert.NoError(tlv.Decode(sll.Payload, &npkt)) || !assert.NotNil(npkt.Fragment) {
+			continue
+		}
+		nFragments++
+
+		full, e := reass.Accept(&npkt)
+		if assert.NoError(e) && full != nil && assert.NotNil(full.Data) {
+			assert.True(prefixA.IsPrefixOf(full.Data.Name))
+			nDataA++
+		}
+	}
+	assert.Greater(nFragments, nBursts*nBurstSize/2)
+	assert.Equal(nBursts*nBurstSize/2, nDataA)
+}
+
+func TestFaceConfigValidate(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName()
+	defer del()
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		MaxSize:      1 << 22,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+	defer w.Close()
+
+	face := intface.MustNew()
+	defer face.D.Close()
+
+	names := []pdump.NameFilterEntry{{Name: ndn.ParseName("/"), SampleProbability: 1.0}}
+	for i, filter := range []pdump.PacketFilter{
+		{Types: []pdump.PktType{"Unknown"}},
+		{PitToken: make([]byte, 33)},
+		{NackReasons: []int{an.NackNone}},
+		{NackReasons: []int{256}},
+		{NackReasons: []int{an.NackCongestion, -1}},
+	} {
+		_, e := pdump.NewFaceSource(pdump.FaceConfig{
+			Writer: w,
+			Face:   face.D,
+			Dir:    pdump.DirIncoming,
+			Names:  names,
+			Filter: filter,
+		})
+		assert.Error(e, "%d", i)
+	}
+
+	dump, e := pdump.NewFaceSource(pdump.FaceConfig{
+		Writer: w,
+		Face:   face.D,
+		Dir:    pdump.DirIncoming,
+		Names:  names,
+		Filter: pdump.PacketFilter{
+			Types:       []pdump.PktType{pdump.PktInterest, pdump.PktData, pdump.PktNack, pdump.PktFragment},
+			PitToken:    make([]byte, 32),
+			NackReasons: []int{1, 255},
+			CongMarked:  true,
+		},
+	})
+	require.NoError(e)
+	assert.NoError(dump.Close())
+	time.Sleep(100 * time.Millisecond)
+}
+
+func TestFacePacketFilter(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName()
+	defer del()
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		MaxSize:      1 << 22,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+
+	face := intface.MustNew()
+	go func() {
+		for range face.Rx {
+		}
+	}()
+
+	_, e = pdump.NewFaceSource(pdump.FaceConfig{
+		Writer: w,
+		Face:   face.D,
+		Dir:    pdump.DirIncoming,
+		Names: []pdump.NameFilterEntry{
+			{Name: ndn.ParseName("/"), SampleProbability: 1.0},
+		},
+		Filter: pdump.PacketFilter{
+			Types:      []pdump.PktType{pdump.PktData},
+			PitToken:   []byte{0xA0, 0xA1},
+			CongMarked: true,
+		},
+	})
+	require.NoError(e)
+	_, e = pdump.NewFaceSource(pdump.FaceConfig{
+		Writer: w,
+		Face:   face.D,
+		Dir:    pdump.DirOutgoing,
+		Names: []pdump.NameFilterEntry{
+			{Name: ndn.ParseName("/"), SampleProbability: 1.0},
+		},
+		Filter: pdump.PacketFilter{
+			// reasons in different words of the bitmap
+			NackReasons: []int{an.NackCongestion, an.NackNoRoute},
+		},
+	})
+	require.NoError(e)
+
+	// RX: Data with matching PIT token prefix and congestion mark are captured
+	rxTokens := [][]byte{{0xA0, 0xA1, 0x01}, {0xA0, 0xA1}, {0xA0, 0xB1}, {0xA0}, nil}
+	const nRxRounds = 100
+	nRxExpected := 0
+	for i := 0; i < nRxRounds; i++ {
+		for j, token := range rxTokens {
+			lp := ndn.LpL3{PitToken: token, CongMark: uint8(i % 2)}
+			if j < 2 && lp.CongMark != 0 {
+				nRxExpected++
+			}
+			face.Tx <- ndn.MakeData(fmt.Sprintf("/D/%d/%d", i, j), lp)
+			if j == 0 {
+				face.Tx <- ndn.MakeInterest(fmt.Sprintf("/I/%d", i), lp)
+			}
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// TX: only Nacks with selected reasons are captured
+	txReasons := []uint8{an.NackCongestion, an.NackDuplicate, an.NackNoRoute}
+	const nTxBursts = 100
+	for i := 0; i < nTxBursts; i++ {
+		pkts := []*ndni.Packet{
+			makeInterest(fmt.Sprintf("/I/%d", i)),
+			makeData(fmt.Sprintf("/D/%d", i)),
+		}
+		for _, reason := range txReasons {
+			pkts = append(pkts, makeNack(ndn.MakeInterest(fmt.Sprintf("/N/%d/%d", i, reason)), reason))
+		}
+		iface.TxBurst(face.ID, pkts)
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	face.D.Close()
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(w.Close())
+
+	f, e := os.Open(filename)
+	require.NoError(e)
+	defer f.Close()
+	r, e := pcapgo.NewNgReader(f, pcapgo.DefaultNgReaderOptions)
+	require.NoError(e)
+
+	nRx := 0
+	nTxReasons := map[uint8]int{}
+	var sll layers.LinuxSLL
+	parser := gopacket.NewDecodingLayerParser(layers.LayerTypeLinuxSLL, &sll)
+	parser.IgnoreUnsupported = true
+	decoded := []gopacket.LayerType{}
+	for {
+		pkt, _, e := r.ReadPacketData()
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		if !assert.NoError(parser.DecodeLayers(pkt, &decoded)) {
+			continue
+		}
+		var npkt ndn.Packet
+		if !assert.NoError(tlv.Decode(sll.Payload, &npkt)) {
+			continue
+		}
+		switch sll.PacketType {
+		case layers.LinuxSLLPacketTypeHost:
+			if assert.NotNil(npkt.Data) {
+				assert.True(bytes.HasPrefix(npkt.Lp.PitToken, []byte{0xA0, 0xA1}))
+				assert.NotZero(npkt.Lp.CongMark)
+				nRx++
+			}
+		case layers.LinuxSLLPacketTypeOutgoing:
+			if assert.NotNil(npkt.Nack) {
+				nTxReasons[npkt.Nack.Reason]++
+			}
+		default:
+			assert.Fail("unexpected sll.PacketType")
+		}
+	}
+	assert.Equal(nRxExpected, nRx)
+	assert.Equal(map[uint8]int{
+		an.NackCongestion: nTxBursts,
+		an.NackNoRoute:    nTxBursts,
+	}, nTxReasons)
+}
+
+func TestFaceRxDrop(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName()
+	defer del()
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		MaxSize:      1 << 22,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+
+	trFace, trPeer, e := sockettransport.Pipe(sockettransport.Config{})
+	require.NoError(e)
+	var cfg socketface.Config
+	cfg.ReassemblerCapacity = iface.MinReassemblerCapacity
+	face, e := socketface.Wrap(trFace, cfg)
+	require.NoError(e)
+	go func() {
+		for range trPeer.Rx() {
+		}
+	}()
+
+	_, e = pdump.NewFaceSource(pdump.FaceConfig{
+		Writer: w,
+		Face:   face,
+		Dir:    pdump.DirIncomingDrop,
+		Names: []pdump.NameFilterEntry{
+			{Name: ndn.ParseName("/"), SampleProbability: 1.0},
+		},
+	})
+	require.NoError(e)
+
+	send := func(pkt tlv.Fielder) {
+		wire, e := tlv.EncodeFrom(pkt)
+		require.NoError(e)
+		trPeer.Tx() <- wire
+		time.Sleep(time.Millisecond)
+	}
+
+	// malformed frame: Interest without Name
+	malformed := []byte{0x05, 0x02, 0x0C, 0x00}
+	trPeer.Tx() <- malformed
+	time.Sleep(time.Millisecond)
+
+	// well-formed packet: not captured
+	send(ndn.MakeInterest("/I"))
+
+	// first fragments of incomplete packets: oldest partial messages are evicted from reassembler
+	const nPartial = 3 * iface.MinReassemblerCapacity
+	fragmenter := ndn.NewLpFragmenter(1000)
+	for i := 0; i < nPartial; i++ {
+		frags, e := fragmenter.Fragment(ndn.MakeData(fmt.Sprintf("/F/%d", i), make([]byte, 1500)).ToPacket())
+		require.NoError(e)
+		require.Greater(len(frags), 1)
+		send(frags[0])
+	}
+	const nEvicted = nPartial - iface.MinReassemblerCapacity
+	time.Sleep(100 * time.Millisecond)
+
+	cnt := face.Counters()
+	assert.EqualValues(1, cnt.RxDecodeErrs)
+	assert.EqualValues(nEvicted, cnt.RxReassDrops)
+
+	face.Close()
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(w.Close())
+
+	f, e := os.Open(filename)
+	require.NoError(e)
+	defer f.Close()
+	r, e := pcapgo.NewNgReader(f, pcapgo.DefaultNgReaderOptions)
+	require.NoError(e)
+
+	var sll layers.LinuxSLL
+	parser := gopacket.NewDecodingLayerParser(layers.LayerTypeLinuxSLL, &sll)
+	parser.IgnoreUnsupported = true
+	decoded := []gopacket.LayerType{}
+	nCaptured := 0
+	for {
+		pkt, _, e := r.ReadPacketData()
+		if errors.Is(e, io.EOF) {
+			break
+		}
+		if assert.NoError(parser.DecodeLayers(pkt, &decoded)) {
+			assert.Equal(layers.LinuxSLLPacketTypeHost, sll.PacketType)
+			if nCaptured == 0 {
+				assert.Equal(malformed, sll.Payload)
+			}
+			nCaptured++
+		}
+	}
+	assert.Equal(1+nEvicted, nCaptured)
+}
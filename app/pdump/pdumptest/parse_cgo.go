@@ -18,3 +18,31 @@ func extractName(npkt tlv.Fielder) []byte {
 	lnameC := C.Pdump_ExtractName((*C.struct_rte_mbuf)(m.Ptr()))
 	return C.GoBytes(unsafe.Pointer(lnameC.value), C.int(lnameC.length))
 }
+
+type parsedPacket struct {
+	PktType    int
+	Name       []byte
+	PitToken   []byte
+	NackReason int
+	CongMark   int
+	SeqNum     uint64
+	FragIndex  int
+	FragCount  int
+}
+
+func parsePacket(npkt tlv.Fielder) (p parsedPacket) {
+	wire, _ := tlv.EncodeFrom(npkt)
+	m := mbuftestenv.MakePacket(wire)
+	defer m.Close()
+	var parsedC C.PdumpParsed
+	C.Pdump_Parse((*C.struct_rte_mbuf)(m.Ptr()), &parsedC)
+	p.PktType = int(parsedC.pktType)
+	p.Name = C.GoBytes(unsafe.Pointer(parsedC.name.value), C.int(parsedC.name.length))
+	p.PitToken = C.GoBytes(unsafe.Pointer(&parsedC.pitToken.value[0]), C.int(parsedC.pitToken.length))
+	p.NackReason = int(parsedC.nackReason)
+	p.CongMark = int(parsedC.congMark)
+	p.SeqNum = uint64(parsedC.seqNum)
+	p.FragIndex = int(parsedC.fragIndex)
+	p.FragCount = int(parsedC.fragCount)
+	return
+}
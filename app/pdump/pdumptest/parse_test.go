@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/usnistgov/ndn-dpdk/app/pdump"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
 )
@@ -38,3 +39,46 @@ func TestExtractNameFragment(t *testing.T) {
 	assert.Len(extractName(frags[2]), 0)
 	assert.Len(extractName(frags[3]), 0)
 }
+
+func TestParseAttrs(t *testing.T) {
+	assert, require := makeAR(t)
+
+	p := parsePacket(ndn.MakeInterest("/I/1"))
+	assert.Equal(pdump.PktBitInterest, p.PktType)
+	assert.Equal(nameWire("/I/1"), p.Name)
+	assert.Len(p.PitToken, 0)
+	assert.Equal(an.NackNone, p.NackReason)
+	assert.Equal(0, p.CongMark)
+
+	p = parsePacket(ndn.MakeData("/D/1", ndn.LpL3{PitToken: []byte{0xB0, 0xB1, 0xB2}, CongMark: 1}).ToPacket())
+	assert.Equal(pdump.PktBitData, p.PktType)
+	assert.Equal(nameWire("/D/1"), p.Name)
+	assert.Equal([]byte{0xB0, 0xB1, 0xB2}, p.PitToken)
+	assert.Equal(1, p.CongMark)
+
+	p = parsePacket(ndn.MakeNack(an.NackDuplicate, ndn.MakeInterest("/N/1", ndn.LpL3{PitToken: []byte{0xC0}})).ToPacket())
+	assert.Equal(pdump.PktBitNack, p.PktType)
+	assert.Equal(nameWire("/N/1"), p.Name)
+	assert.Equal([]byte{0xC0}, p.PitToken)
+	assert.Equal(an.NackDuplicate, p.NackReason)
+
+	fragmenter := ndn.NewLpFragmenter(1000)
+	data := ndn.MakeData("/D/2", ndn.LpL3{PitToken: []byte{0xD0, 0xD1}}, make([]byte, 1500))
+	frags, _ := fragmenter.Fragment(data.ToPacket())
+	require.GreaterOrEqual(len(frags), 2)
+
+	p = parsePacket(frags[0])
+	assert.Equal(pdump.PktBitFragment, p.PktType)
+	assert.Equal(nameWire("/D/2"), p.Name)
+	assert.Equal([]byte{0xD0, 0xD1}, p.PitToken)
+	assert.Equal(frags[0].Fragment.SeqNum, p.SeqNum)
+	assert.Equal(0, p.FragIndex)
+	assert.Equal(len(frags), p.FragCount)
+
+	p = parsePacket(frags[1])
+	assert.Equal(pdump.PktBitFragment, p.PktType)
+	assert.Len(p.Name, 0)
+	assert.Equal(frags[0].Fragment.SeqNum+1, p.SeqNum)
+	assert.Equal(1, p.FragIndex)
+	assert.Equal(len(frags), p.FragCount)
+}
@@ -18,4 +18,6 @@ func TestMain(m *testing.M) {
 var (
 	makeAR       = testenv.MakeAR
 	makeInterest = ndnitestenv.MakeInterest
+	makeData     = ndnitestenv.MakeData
+	makeNack     = ndnitestenv.MakeNack
 )
@@ -0,0 +1,146 @@
+package pdumptest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/gopacket/pcapgo"
+	"github.com/usnistgov/ndn-dpdk/app/pdump"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+)
+
+// dumpOutgoing captures outgoing Interests on an intface into w.
+func dumpOutgoing(t testing.TB, w *pdump.Writer, nBursts int) {
+	_, require := makeAR(t)
+
+	face := intface.MustNew()
+	go func() {
+		for range face.Rx {
+		}
+	}()
+
+	_, e := pdump.NewFaceSource(pdump.FaceConfig{
+		Writer: w,
+		Face:   face.D,
+		Dir:    pdump.DirOutgoing,
+		Names: []pdump.NameFilterEntry{
+			{Name: ndn.ParseName("/"), SampleProbability: 1.0},
+		},
+	})
+	require.NoError(e)
+
+	suffix := strings.Repeat("/Z", 64)
+	const nBurstSize = 16
+	for i := 0; i < nBursts; i++ {
+		pkts := make([]*ndni.Packet, nBurstSize)
+		for j := range pkts {
+			pkts[j] = makeInterest(fmt.Sprintf("/%d/%d%s", i, j, suffix))
+		}
+		iface.TxBurst(face.ID, pkts)
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	face.D.Close() // closing the face should automatically close dumpers
+	time.Sleep(100 * time.Millisecond)
+}
+
+func countPcapngPackets(t testing.TB, r io.Reader) (n int) {
+	assert, require := makeAR(t)
+	ngr, e := pcapgo.NewNgReader(r, pcapgo.DefaultNgReaderOptions)
+	require.NoError(e)
+	for {
+		_, _, e := ngr.ReadPacketData()
+		if errors.Is(e, io.EOF) {
+			return
+		}
+		if !assert.NoError(e) {
+			return
+		}
+		n++
+	}
+}
+
+func TestWriterRotate(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName("dump.pcapng")
+	defer del()
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		MaxSize:      pdump.MinFileSize,
+		MaxFiles:     3,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+
+	dumpOutgoing(t, w, 512)
+	assert.NoError(w.Close())
+
+	files, e := filepath.Glob(filepath.Join(filepath.Dir(filename), "dump_*_*.pcapng"))
+	require.NoError(e)
+	require.Len(files, 3)
+	for _, file := range files {
+		st, e := os.Stat(file)
+		require.NoError(e)
+		assert.LessOrEqual(st.Size(), int64(pdump.MinFileSize))
+
+		f, e := os.Open(file)
+		require.NoError(e)
+		assert.Greater(countPcapngPackets(t, f), 0, file)
+		f.Close()
+	}
+}
+
+func TestWriterStream(t *testing.T) {
+	assert, require := makeAR(t)
+
+	filename, del := testenv.TempName("dump.sock")
+	defer del()
+	listener, e := net.Listen("unix", filename)
+	require.NoError(e)
+	defer listener.Close()
+
+	nPackets := make(chan int, 1)
+	go func() {
+		conn, e := listener.Accept()
+		if !assert.NoError(e) {
+			nPackets <- 0
+			return
+		}
+		defer conn.Close()
+		nPackets <- countPcapngPackets(t, conn)
+	}()
+
+	w, e := pdump.NewWriter(pdump.WriterConfig{
+		Filename:     filename,
+		RingCapacity: 4096,
+	})
+	require.NoError(e)
+	require.NoError(ealthread.AllocLaunch(w))
+	defer ealthread.AllocFree(w.LCore())
+
+	dumpOutgoing(t, w, 64)
+	assert.NoError(w.Close())
+
+	select {
+	case n := <-nPackets:
+		assert.InDelta(64*16, n, 64)
+	case <-time.After(5 * time.Second):
+		assert.Fail("stream reader timeout")
+	}
+}
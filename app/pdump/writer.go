@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"math"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -25,6 +26,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
 )
 
 // Role is writer thread role name.
@@ -34,10 +36,26 @@ var logger = logging.New("pdump")
 
 // WriterConfig contains writer configuration.
 type WriterConfig struct {
-	Filename     string
-	MaxSize      int
+	// Filename is the output filename.
+	// If it refers to an existing Unix socket or FIFO, pcapng blocks are streamed into it,
+	// and MaxSize and MaxFiles are ignored.
+	Filename string
+
+	// MaxSize is the maximum size of each output file.
+	MaxSize int
+
+	// MaxFiles enables file rotation if greater than 1.
+	// When the current file reaches MaxSize, a new file is started, and the oldest file is deleted
+	// to keep at most MaxFiles files.
+	// Rotated filenames are derived from Filename by inserting a sequence number and a timestamp
+	// before the extension, such as "dump_00001_20060102150405.pcapng".
+	// Otherwise, capture stops when the output file reaches MaxSize.
+	MaxFiles int
+
 	RingCapacity int
 	Socket       eal.NumaSocket
+
+	streamFd int
 }
 
 func (cfg *WriterConfig) applyDefaults() {
@@ -59,13 +77,51 @@ func (cfg WriterConfig) validate() error {
 	if cfg.MaxSize < MinFileSize {
 		errs = append(errs, fmt.Errorf("file size is less than %d", MinFileSize))
 	}
+	if cfg.MaxFiles < 0 || cfg.MaxFiles > MaxFiles {
+		errs = append(errs, fmt.Errorf("number of rotated files must be between 0 and %d", MaxFiles))
+	}
 	return multierr.Combine(errs...)
 }
 
+// openStream opens a Unix socket or FIFO for streaming.
+// It returns -1 if the filename does not refer to either.
+func openStream(filename string) (fd int, e error) {
+	var st unix.Stat_t
+	if unix.Stat(filename, &st) != nil {
+		return -1, nil
+	}
+
+	switch st.Mode & unix.S_IFMT {
+	case unix.S_IFIFO:
+		// O_NONBLOCK makes open fail instead of block when the FIFO has no reader
+		if fd, e = unix.Open(filename, unix.O_WRONLY|unix.O_NONBLOCK|unix.O_CLOEXEC, 0); e != nil {
+			return -1, fmt.Errorf("open FIFO %s: %w", filename, e)
+		}
+	case unix.S_IFSOCK:
+		if fd, e = unix.Socket(unix.AF_UNIX, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0); e != nil {
+			return -1, fmt.Errorf("socket: %w", e)
+		}
+		if e = unix.Connect(fd, &unix.SockaddrUnix{Name: filename}); e != nil {
+			unix.Close(fd)
+			return -1, fmt.Errorf("connect %s: %w", filename, e)
+		}
+	default:
+		return -1, nil
+	}
+
+	if e = unix.SetNonblock(fd, false); e != nil {
+		unix.Close(fd)
+		return -1, e
+	}
+	return fd, nil
+}
+
 // Writer is a packet dump writer thread.
 type Writer struct {
 	ealthread.ThreadWithCtrl
 	filename string
+	maxFiles int
+	stream   bool
 	c        *C.PdumpWriter
 	queue    *ringbuffer.Ring
 	mp       *pktmbuf.Pool
@@ -154,7 +210,11 @@ func (w *Writer) Close() error {
 	)
 
 	if w.c != nil {
+		if w.c.streamFd >= 0 {
+			unix.Close(int(w.c.streamFd))
+		}
 		C.free(unsafe.Pointer(w.c.filename))
+		C.free(unsafe.Pointer(w.c.suffix))
 		eal.Free(w.c)
 		w.c = nil
 	}
@@ -181,15 +241,31 @@ func NewWriter(cfg WriterConfig) (w *Writer, e error) {
 	if e := cfg.validate(); e != nil {
 		return nil, e
 	}
+	if cfg.streamFd, e = openStream(cfg.Filename); e != nil {
+		return nil, e
+	}
+	if cfg.streamFd >= 0 || cfg.MaxFiles == 1 {
+		cfg.MaxFiles = 0
+	}
 
 	w = &Writer{
 		filename: cfg.Filename,
+		maxFiles: cfg.MaxFiles,
+		stream:   cfg.streamFd >= 0,
 		c:        (*C.PdumpWriter)(eal.Zmalloc("PdumpWriter", C.sizeof_PdumpWriter, cfg.Socket)),
 		mp:       pktmbuf.Direct.Get(cfg.Socket),
 		intfs:    map[int]pcapgo.NgInterface{},
 	}
-	w.c.filename = C.CString(cfg.Filename)
+	if cfg.MaxFiles > 0 {
+		ext := filepath.Ext(cfg.Filename)
+		w.c.filename = C.CString(strings.TrimSuffix(cfg.Filename, ext))
+		w.c.suffix = C.CString(ext)
+	} else {
+		w.c.filename = C.CString(cfg.Filename)
+	}
 	w.c.maxSize = C.size_t(cfg.MaxSize)
+	w.c.maxFiles = C.uint32_t(cfg.MaxFiles)
+	w.c.streamFd = C.int(cfg.streamFd)
 	for i := range w.c.intf {
 		w.c.intf[i] = math.MaxUint32
 	}
@@ -212,6 +288,8 @@ func NewWriter(cfg WriterConfig) (w *Writer, e error) {
 
 	logger.Info("Writer open",
 		zap.String("filename", cfg.Filename),
+		zap.Int("max-files", cfg.MaxFiles),
+		zap.Bool("stream", w.stream),
 		zap.Uintptr("queue", uintptr(unsafe.Pointer(w.c.queue))),
 	)
 	return w, nil
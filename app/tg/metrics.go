@@ -0,0 +1,46 @@
+package tg
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+	"github.com/usnistgov/ndn-dpdk/iface"
+)
+
+var consumerRttFamily = openmetrics.Family{
+	Name: "ndndpdk_tg_consumer_rtt_mean_seconds",
+	Help: "Mean round trip time of consumer.",
+	Type: openmetrics.Gauge,
+}
+
+func init() {
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		mapFaceGenMutex.RLock()
+		defer mapFaceGenMutex.RUnlock()
+		ids := make([]int, 0, len(mapFaceGen))
+		for id := range mapFaceGen {
+			ids = append(ids, int(id))
+		}
+		sort.Ints(ids)
+
+		for _, id := range ids {
+			gen := mapFaceGen[iface.ID(id)]
+			labels := openmetrics.Labels{"face": strconv.Itoa(id)}
+			if gen.producer != nil {
+				s.AddStruct("ndndpdk_tg_producer_", labels, gen.producer.Counters())
+			}
+			if gen.consumer != nil {
+				cnt := gen.consumer.Counters()
+				s.AddStruct("ndndpdk_tg_consumer_", labels, cnt)
+				if cnt.Rtt.Len() > 0 {
+					s.Add(consumerRttFamily, labels, time.Duration(cnt.Rtt.Mean()))
+				}
+			}
+			if gen.fetcher != nil {
+				gen.fetcher.CollectMetrics(s, labels)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+/**
+ * @file
+ * The best route strategy forwards each Interest to the first usable FIB nexthop.
+ * When a nexthop returns a Nack, it retries the Interest on the next nexthop that has not
+ * returned a Nack. When all nexthops have failed, it returns the least severe Nack downstream.
+ */
+#include "api.h"
+
+enum StatusCode
+{
+  S_OK = 0,
+  S_UNKNOWN = 2,
+  S_NO_NEXTHOP = 3,
+  S_RETRY = 11,
+  S_PENDING = 12,
+  S_NACK = 13,
+};
+
+SUBROUTINE uint64_t
+RxInterest(SgCtx* ctx)
+{
+  SgFibNexthopIt it;
+  for (SgFibNexthopIt_Init2(&it, ctx); SgFibNexthopIt_Valid(&it); SgFibNexthopIt_Next(&it)) {
+    SgForwardInterestResult res = SgForwardInterest(ctx, it.nh);
+    if (res == SGFWDI_OK) {
+      return S_OK;
+    }
+  }
+  return S_NO_NEXTHOP;
+}
+
+SUBROUTINE uint64_t
+RxNack(SgCtx* ctx)
+{
+  SgNackReason leastSevere = (SgNackReason)ctx->pkt->nackReason;
+
+  // ctx->nhFlt excludes the nexthop that returned this Nack
+  SgFibNexthopIt it;
+  for (SgFibNexthopIt_Init2(&it, ctx); SgFibNexthopIt_Valid(&it); SgFibNexthopIt_Next(&it)) {
+    SgNackReason reason = SgGetNackReason(ctx, it.nh);
+    if (reason != SgNackNone) {
+      if (reason < leastSevere) {
+        leastSevere = reason;
+      }
+      continue;
+    }
+
+    switch (SgForwardInterest(ctx, it.nh)) {
+      case SGFWDI_OK:
+        return S_RETRY;
+      case SGFWDI_SUPPRESSED:
+        // Interest is pending on this nexthop
+        return S_PENDING;
+      default:
+        break;
+    }
+  }
+
+  SgReturnNacks(ctx, leastSevere);
+  return S_NACK;
+}
+
+uint64_t
+SgMain(SgCtx* ctx)
+{
+  switch (ctx->eventKind) {
+    case SGEVT_INTEREST:
+      return RxInterest(ctx);
+    case SGEVT_NACK:
+      return RxNack(ctx);
+    default:
+      return S_UNKNOWN;
+  }
+}
@@ -1,10 +1,26 @@
 /**
  * @file
- * The delay strategy delays every incoming Interest by 200 milliseconds,
+ * The delay strategy delays every incoming Interest by a fixed duration,
  * and then forwards it to the first available nexthop.
+ *
+ * Parameters:
+ * @li delay: delay duration in milliseconds.
  */
 #include "api.h"
 
+SGPARAMS_SCHEMA("{"
+                "\"type\":\"object\","
+                "\"properties\":{"
+                "\"delay\":{\"type\":\"integer\",\"minimum\":0,\"maximum\":60000,\"default\":200}"
+                "},"
+                "\"additionalProperties\":false"
+                "}");
+
+enum
+{
+  P_DELAY = 0,
+};
+
 SUBROUTINE uint64_t
 Timer(SgCtx* ctx)
 {
@@ -21,7 +37,7 @@ Timer(SgCtx* ctx)
 SUBROUTINE uint64_t
 RxInterest(SgCtx* ctx)
 {
-  bool ok = SgSetTimer(ctx, SgTscFromMillis(ctx, 200));
+  bool ok = SgSetTimer(ctx, SgTscFromMillis(ctx, SgCtx_Param(ctx, P_DELAY)));
   return ok ? 0 : 3;
 }
 
@@ -3,11 +3,25 @@
  * The fast route strategy multicasts the first Interest, observes which
  * nexthop replies first, and keeps using it. It then periodically probes
  * an unselected nexthop, and switches to it if it is faster.
+ *
+ * Parameters:
+ * @li probeInterval: how often to send probe Interest, in number of packets.
  */
 #include "api.h"
 
-// how often to send probe Interest, in number of packets
-#define PROBE_INTERVAL 1024
+SGPARAMS_SCHEMA("{"
+                "\"type\":\"object\","
+                "\"properties\":{"
+                "\"probeInterval\":{\"type\":\"integer\",\"minimum\":1,\"maximum\":65535,"
+                "\"default\":1024}"
+                "},"
+                "\"additionalProperties\":false"
+                "}");
+
+enum
+{
+  P_PROBE_INTERVAL = 0,
+};
 
 enum StatusCode
 {
@@ -78,7 +92,7 @@ RxInterest(SgCtx* ctx)
 
   // unicast to selected nexthop
   if (fei->hasSelectedNexthop && Unicast(ctx)) {
-    if (++fei->nUnicast >= PROBE_INTERVAL) {
+    if (++fei->nUnicast >= SgCtx_Param(ctx, P_PROBE_INTERVAL)) {
       fei->nUnicast = 0;
       return Probe(ctx);
     }
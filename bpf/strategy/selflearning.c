@@ -0,0 +1,199 @@
+/**
+ * @file
+ * The self-learning strategy discovers unicast paths by flooding.
+ *
+ * When the FIB entry has no learned nexthop, it floods the Interest to all nexthops, such as an
+ * Ethernet multicast face. When Data comes back, it asks the control plane to install the face
+ * that the Data arrived on as a learned nexthop, and then unicasts subsequent Interests to it.
+ * The control plane ignores requests to learn an Ethernet multicast face, and does not change
+ * nexthops configured by the operator.
+ * If a learned nexthop times out or returns a Nack, the strategy asks the control plane to remove
+ * it, and floods the Interest again.
+ *
+ * Parameters:
+ * @li lifetime: learned nexthop lifetime in milliseconds.
+ *     The strategy refreshes a learned nexthop when Data arrives after half of its lifetime.
+ * @li timeout: how long to wait for Data after unicasting an Interest, in milliseconds.
+ */
+#include "api.h"
+
+SGPARAMS_SCHEMA("{"
+                "\"type\":\"object\","
+                "\"properties\":{"
+                "\"lifetime\":{\"type\":\"integer\",\"minimum\":100,\"maximum\":3600000,"
+                "\"default\":30000},"
+                "\"timeout\":{\"type\":\"integer\",\"minimum\":1,\"maximum\":60000,\"default\":500}"
+                "},"
+                "\"additionalProperties\":false"
+                "}");
+
+enum
+{
+  P_LIFETIME = 0,
+  P_TIMEOUT = 1,
+};
+
+enum StatusCode
+{
+  S_OK = 0,
+  S_UNKNOWN = 2,
+  S_NO_NEXTHOP = 3,
+  S_UNICAST = 11,
+  S_FLOOD = 12,
+  S_LEARN = 21,
+  S_LEARN_ERR = 22,
+  S_FORGET = 31,
+};
+
+enum
+{
+  MAX_LEARNED = 8,
+};
+
+typedef struct FibEntryInfo
+{
+  TscTime refreshAt[MAX_LEARNED]; ///< when to refresh learned nexthop
+  FaceID learned[MAX_LEARNED];    ///< learned nexthops, zero means empty slot
+} FibEntryInfo;
+
+typedef struct PitEntryInfo
+{
+  FaceID unicastNh; ///< nexthop of unicast Interest, zero if flooded
+} PitEntryInfo;
+
+SUBROUTINE int
+FindLearned(const FibEntryInfo* fei, FaceID nh)
+{
+  for (int i = 0; i < MAX_LEARNED; ++i) {
+    if (fei->learned[i] == nh) {
+      return i;
+    }
+  }
+  return -1;
+}
+
+SUBROUTINE uint64_t
+Flood(SgCtx* ctx, FaceID except)
+{
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  pei->unicastNh = 0;
+
+  bool ok = false;
+  SgFibNexthopIt it;
+  for (SgFibNexthopIt_Init2(&it, ctx); SgFibNexthopIt_Valid(&it); SgFibNexthopIt_Next(&it)) {
+    if (it.nh != except && SgForwardInterest(ctx, it.nh) == SGFWDI_OK) {
+      ok = true;
+    }
+  }
+  return ok ? S_FLOOD : S_NO_NEXTHOP;
+}
+
+SUBROUTINE void
+Forget(SgCtx* ctx, FaceID nh)
+{
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  int i = FindLearned(fei, nh);
+  if (i >= 0) {
+    fei->learned[i] = 0;
+  }
+  SgForgetNexthop(ctx, nh);
+}
+
+SUBROUTINE uint64_t
+RxInterest(SgCtx* ctx)
+{
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+
+  // unicast to the first learned nexthop that has been installed in the FIB entry
+  SgFibNexthopIt it;
+  for (SgFibNexthopIt_Init2(&it, ctx); SgFibNexthopIt_Valid(&it); SgFibNexthopIt_Next(&it)) {
+    if (FindLearned(fei, it.nh) < 0 || SgForwardInterest(ctx, it.nh) != SGFWDI_OK) {
+      continue;
+    }
+    pei->unicastNh = it.nh;
+    SgSetTimer(ctx, SgTscFromMillis(ctx, SgCtx_Param(ctx, P_TIMEOUT)));
+    return S_UNICAST;
+  }
+
+  return Flood(ctx, 0);
+}
+
+SUBROUTINE uint64_t
+RxData(SgCtx* ctx)
+{
+  FibEntryInfo* fei = SgCtx_FibScratchT(ctx, FibEntryInfo);
+  FaceID rxFace = ctx->pkt->rxFace;
+
+  int i = FindLearned(fei, rxFace);
+  if (i >= 0 && ctx->now < fei->refreshAt[i]) {
+    return S_OK;
+  }
+  if (i < 0) {
+    // pick an empty slot, or overwrite the slot due for refresh soonest
+    i = 0;
+    for (int j = 0; j < MAX_LEARNED; ++j) {
+      if (fei->learned[j] == 0) {
+        i = j;
+        break;
+      }
+      if (fei->refreshAt[j] < fei->refreshAt[i]) {
+        i = j;
+      }
+    }
+  }
+
+  uint32_t lifetime = SgCtx_Param(ctx, P_LIFETIME);
+  if (!SgLearnNexthop(ctx, rxFace, lifetime)) {
+    return S_LEARN_ERR;
+  }
+  fei->learned[i] = rxFace;
+  fei->refreshAt[i] = ctx->now + SgTscFromMillis(ctx, lifetime / 2);
+  return S_LEARN;
+}
+
+SUBROUTINE uint64_t
+RxNack(SgCtx* ctx)
+{
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  FaceID nh = pei->unicastNh;
+  if (nh == 0 || nh != ctx->pkt->rxFace) {
+    return S_OK;
+  }
+
+  Forget(ctx, nh);
+  Flood(ctx, nh);
+  return S_FORGET;
+}
+
+SUBROUTINE uint64_t
+Timer(SgCtx* ctx)
+{
+  PitEntryInfo* pei = SgCtx_PitScratchT(ctx, PitEntryInfo);
+  FaceID nh = pei->unicastNh;
+  if (nh == 0) {
+    return S_OK;
+  }
+
+  // unicast Interest has timed out
+  Forget(ctx, nh);
+  Flood(ctx, nh);
+  return S_FORGET;
+}
+
+uint64_t
+SgMain(SgCtx* ctx)
+{
+  switch (ctx->eventKind) {
+    case SGEVT_INTEREST:
+      return RxInterest(ctx);
+    case SGEVT_DATA:
+      return RxData(ctx);
+    case SGEVT_NACK:
+      return RxNack(ctx);
+    case SGEVT_TIMER:
+      return Timer(ctx);
+    default:
+      return S_UNKNOWN;
+  }
+}
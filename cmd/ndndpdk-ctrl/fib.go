@@ -1,6 +1,9 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/urfave/cli/v2"
 )
 
@@ -21,6 +24,7 @@ func init() {
 						strategy {
 							id
 						}
+						params
 					}
 				}
 			`, nil, "fib")
@@ -32,6 +36,8 @@ func init() {
 	var name string
 	var nexthops cli.StringSlice
 	var strategy string
+	var paramsJSON string
+	var params map[string]interface{}
 
 	defineCommand(&cli.Command{
 		Category: "fib",
@@ -56,6 +62,20 @@ func init() {
 				Usage:       "forwarding strategy `ID`",
 				Destination: &strategy,
 			},
+			&cli.StringFlag{
+				Name:        "params",
+				Usage:       "strategy parameters `JSON` object",
+				Destination: &paramsJSON,
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if paramsJSON == "" {
+				return nil
+			}
+			if e := json.Unmarshal([]byte(paramsJSON), &params); e != nil {
+				return fmt.Errorf("--params: %w", e)
+			}
+			return nil
 		},
 		Action: func(c *cli.Context) error {
 			vars := map[string]interface{}{
@@ -65,10 +85,13 @@ func init() {
 			if strategy != "" {
 				vars["strategy"] = strategy
 			}
+			if params != nil {
+				vars["params"] = params
+			}
 
 			return clientDoPrint(c.Context, `
-				mutation insertFibEntry($name: Name!, $nexthops: [ID!]!, $strategy: ID) {
-					insertFibEntry(name: $name, nexthops: $nexthops, strategy: $strategy) {
+				mutation insertFibEntry($name: Name!, $nexthops: [ID!]!, $strategy: ID, $params: JSON) {
+					insertFibEntry(name: $name, nexthops: $nexthops, strategy: $strategy, params: $params) {
 						id
 					}
 				}
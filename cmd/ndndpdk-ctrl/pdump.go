@@ -1,6 +1,8 @@
 package main
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -10,9 +12,12 @@ import (
 
 func init() {
 	var filename, name string
-	var faces, ports cli.StringSlice
-	var wantRX, wantTX, wantRxUnmatched bool
+	var faces, ports, pktTypes, flowFaces cli.StringSlice
+	var nackReasons cli.IntSlice
+	var pitToken string
+	var wantRX, wantTX, wantRxDrop, wantRxUnmatched, wantRxFlow, congMarked bool
 	var sampleProb float64
+	var maxSize, maxFiles int
 	var duration time.Duration
 
 	type withID struct {
@@ -21,11 +26,23 @@ func init() {
 	var writer string
 	var sources []string
 	createWriter := func(c *cli.Context) error {
+		vars := map[string]interface{}{
+			"filename": filename,
+		}
+		if maxSize > 0 {
+			vars["maxSize"] = maxSize
+		}
+		if maxFiles > 0 {
+			vars["maxFiles"] = maxFiles
+		}
+
 		var result withID
 		if e := clientDoPrint(c.Context, `
-				mutation createPdumpWriter($filename: String!) {
-					createPdumpWriter(filename: $filename) {
+				mutation createPdumpWriter($filename: String!, $maxSize: Int, $maxFiles: Int) {
+					createPdumpWriter(filename: $filename, maxSize: $maxSize, maxFiles: $maxFiles) {
 						filename
+						maxFiles
+						stream
 						id
 						worker {
 							id
@@ -34,9 +51,7 @@ func init() {
 						}
 					}
 				}
-			`, map[string]interface{}{
-			"filename": filename,
-		}, "createPdumpWriter", &result); e != nil {
+			`, vars, "createPdumpWriter", &result); e != nil {
 			return e
 		}
 		if cmdout {
@@ -46,14 +61,30 @@ func init() {
 		}
 		return nil
 	}
-	createFaceSource := func(c *cli.Context, face, dir string) error {
+	makePacketFilter := func() (filter map[string]interface{}, e error) {
+		filter = map[string]interface{}{
+			"types":       pktTypes.Value(),
+			"nackReasons": nackReasons.Value(),
+			"congMarked":  congMarked,
+		}
+		if pitToken != "" {
+			token, e := hex.DecodeString(pitToken)
+			if e != nil {
+				return nil, fmt.Errorf("pit-token: %w", e)
+			}
+			filter["pitToken"] = token
+		}
+		return filter, nil
+	}
+	createFaceSource := func(c *cli.Context, face, dir string, filter map[string]interface{}) error {
 		var result withID
 		if e := clientDoPrint(c.Context, `
-			mutation createPdumpFaceSource($writer: ID!, $face: ID!, $dir: PdumpDirection!, $name: Name!, $sampleProb: Float!) {
-				createPdumpFaceSource(writer: $writer, face: $face, dir: $dir, names: [{ name: $name, sampleProbability: $sampleProb }]) {
+			mutation createPdumpFaceSource($writer: ID!, $face: ID!, $dir: PdumpDirection!, $name: Name!, $sampleProb: Float!, $filter: PdumpPacketFilterInput) {
+				createPdumpFaceSource(writer: $writer, face: $face, dir: $dir, names: [{ name: $name, sampleProbability: $sampleProb }], filter: $filter) {
 					id
 					face { id locator }
 					dir
+					filter { types pitToken nackReasons congMarked }
 				}
 			}
 		`, map[string]interface{}{
@@ -62,6 +93,7 @@ func init() {
 			"dir":        dir,
 			"name":       name,
 			"sampleProb": sampleProb,
+			"filter":     filter,
 		}, "createPdumpFaceSource", &result); e != nil {
 			return e
 		}
@@ -72,20 +104,22 @@ func init() {
 		}
 		return nil
 	}
-	createEthPortSource := func(c *cli.Context, port, grab string) error {
+	createEthPortSource := func(c *cli.Context, port, grab string, faces []string) error {
 		var result withID
 		if e := clientDoPrint(c.Context, `
-			mutation createPdumpEthPortSource($writer: ID!, $port: ID!, $grab: PdumpEthGrab!) {
-				createPdumpEthPortSource(writer: $writer, port: $port, grab: $grab) {
+			mutation createPdumpEthPortSource($writer: ID!, $port: ID!, $grab: PdumpEthGrab!, $faces: [ID!]) {
+				createPdumpEthPortSource(writer: $writer, port: $port, grab: $grab, faces: $faces) {
 					id
 					port { id name macAddr }
 					grab
+					faces { id }
 				}
 			}
 		`, map[string]interface{}{
 			"writer": writer,
 			"port":   port,
 			"grab":   grab,
+			"faces":  faces,
 		}, "createPdumpEthPortSource", &result); e != nil {
 			return e
 		}
@@ -125,10 +159,22 @@ func init() {
 	commonFlags := []cli.Flag{
 		&cli.StringFlag{
 			Name:        "filename",
-			Usage:       "destination `filename`",
+			Usage:       "destination `filename`, or existing Unix socket or FIFO for live streaming",
 			Destination: &filename,
 			Required:    true,
 		},
+		&cli.IntFlag{
+			Name:        "max-size",
+			Usage:       "maximum output file size in `bytes`",
+			DefaultText: "16MB",
+			Destination: &maxSize,
+		},
+		&cli.IntFlag{
+			Name:        "max-files",
+			Usage:       "rotate output files, keeping this many `files`",
+			DefaultText: "no rotation",
+			Destination: &maxFiles,
+		},
 		&cli.DurationFlag{
 			Name:        "duration",
 			Usage:       "packet dump duration",
@@ -160,6 +206,11 @@ func init() {
 				Value:       true,
 				Destination: &wantTX,
 			},
+			&cli.BoolFlag{
+				Name:        "rx-drop",
+				Usage:       "capture incoming frames dropped due to decoding errors or reassembler eviction",
+				Destination: &wantRxDrop,
+			},
 			&cli.StringFlag{
 				Name:        "name",
 				Usage:       "name `prefix`",
@@ -172,8 +223,33 @@ func init() {
 				Value:       1.0,
 				Destination: &sampleProb,
 			},
+			&cli.StringSliceFlag{
+				Name:        "type",
+				Usage:       "packet `type`: Interest, Data, Nack, Fragment (repeatable)",
+				Destination: &pktTypes,
+			},
+			&cli.StringFlag{
+				Name:        "pit-token",
+				Usage:       "PIT token `prefix` in hexadecimal",
+				Destination: &pitToken,
+			},
+			&cli.IntSliceFlag{
+				Name:        "nack-reason",
+				Usage:       "Nack `reason` code (repeatable)",
+				Destination: &nackReasons,
+			},
+			&cli.BoolFlag{
+				Name:        "cong-marked",
+				Usage:       "capture only congestion marked packets",
+				Destination: &congMarked,
+			},
 		}, commonFlags...),
 		Action: func(c *cli.Context) error {
+			filter, e := makePacketFilter()
+			if e != nil {
+				return e
+			}
+
 			defer closeAll(c)
 
 			if e := createWriter(c); e != nil {
@@ -181,11 +257,11 @@ func init() {
 			}
 
 			for _, face := range faces.Value() {
-				for dir, enabled := range map[string]bool{"RX": wantRX, "TX": wantTX} {
+				for dir, enabled := range map[string]bool{"RX": wantRX, "TX": wantTX, "RXDROP": wantRxDrop} {
 					if !enabled {
 						continue
 					}
-					if e := createFaceSource(c, face, dir); e != nil {
+					if e := createFaceSource(c, face, dir, filter); e != nil {
 						return e
 					}
 				}
@@ -211,10 +287,23 @@ func init() {
 				Name:        "rx-unmatched",
 				Usage:       "capture incoming packets not matching a face",
 				Destination: &wantRxUnmatched,
-				Required:    true,
+			},
+			&cli.BoolFlag{
+				Name:        "rx-flow",
+				Usage:       "capture incoming packets dispatched by hardware flows",
+				Destination: &wantRxFlow,
+			},
+			&cli.StringSliceFlag{
+				Name:        "flow-face",
+				Usage:       "capture --rx-flow packets of face `ID` only (repeatable)",
+				Destination: &flowFaces,
 			},
 		}, commonFlags...),
 		Action: func(c *cli.Context) error {
+			if !wantRxUnmatched && !wantRxFlow {
+				return errors.New("at least one of --rx-unmatched and --rx-flow is required")
+			}
+
 			defer closeAll(c)
 
 			if e := createWriter(c); e != nil {
@@ -222,11 +311,13 @@ func init() {
 			}
 
 			for _, port := range ports.Value() {
-				for grab, enabled := range map[string]bool{"RxUnmatched": wantRxUnmatched} {
-					if !enabled {
-						continue
+				if wantRxUnmatched {
+					if e := createEthPortSource(c, port, "RxUnmatched", nil); e != nil {
+						return e
 					}
-					if e := createEthPortSource(c, port, grab); e != nil {
+				}
+				if wantRxFlow {
+					if e := createEthPortSource(c, port, "RxFlow", flowFaces.Value()); e != nil {
 						return e
 					}
 				}
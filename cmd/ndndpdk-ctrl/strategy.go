@@ -2,6 +2,8 @@ package main
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path"
 
@@ -29,6 +31,7 @@ func init() {
 					strategies {
 						id
 						name
+						schema
 						fibEntries @include(if: $withFib) {
 							id
 							name
@@ -93,3 +96,149 @@ func init() {
 func init() {
 	defineDeleteCommand("strategy", "unload-strategy", "Unload a strategy ELF program", "strategy program")
 }
+
+func init() {
+	var strategy string
+	var name string
+	var elffile string
+	var elf []byte
+	var keepScratch bool
+
+	defineCommand(&cli.Command{
+		Category: "strategy",
+		Name:     "replace-strategy",
+		Usage:    "Replace the program of a strategy with a new ELF program, keeping its ID",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "strategy",
+				Usage:       "old forwarding strategy `ID`",
+				Destination: &strategy,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "short `name` (default is the name of old strategy)",
+				Destination: &name,
+			},
+			&cli.StringFlag{
+				Name:        "elffile",
+				Usage:       "ELF program `file`",
+				Destination: &elffile,
+				Required:    true,
+			},
+			&cli.BoolFlag{
+				Name:        "keep-scratch",
+				Usage:       "keep strategy scratch areas in FIB entries",
+				Destination: &keepScratch,
+			},
+		},
+		Before: func(c *cli.Context) (e error) {
+			elf, e = os.ReadFile(elffile)
+			return e
+		},
+		Action: func(c *cli.Context) error {
+			vars := map[string]interface{}{
+				"strategy":    strategy,
+				"elf":         base64.StdEncoding.EncodeToString(elf),
+				"keepScratch": keepScratch,
+			}
+			if name != "" {
+				vars["name"] = name
+			}
+
+			return clientDoPrint(c.Context, `
+				mutation replaceStrategy($strategy: ID!, $name: String, $elf: Bytes!, $keepScratch: Boolean) {
+					replaceStrategy(strategy: $strategy, name: $name, elf: $elf, keepScratch: $keepScratch) {
+						id
+						name
+					}
+				}
+			`, vars, "replaceStrategy")
+		},
+	})
+}
+
+func init() {
+	defineCommand(&cli.Command{
+		Category: "strategy",
+		Name:     "list-strategy-choice",
+		Aliases:  []string{"list-strategy-choices"},
+		Usage:    "List strategy choice table entries",
+		Action: func(c *cli.Context) error {
+			return clientDoPrint(c.Context, `
+				{
+					strategyChoices {
+						id
+						name
+						strategy {
+							id
+						}
+						params
+					}
+				}
+			`, nil, "strategyChoices")
+		},
+	})
+}
+
+func init() {
+	var name string
+	var strategy string
+	var paramsJSON string
+	var params map[string]interface{}
+
+	defineCommand(&cli.Command{
+		Category: "strategy",
+		Name:     "set-strategy",
+		Usage:    "Choose a strategy for a name prefix",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "name `prefix`",
+				Destination: &name,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "strategy",
+				Usage:       "forwarding strategy `ID`",
+				Destination: &strategy,
+				Required:    true,
+			},
+			&cli.StringFlag{
+				Name:        "params",
+				Usage:       "strategy parameters `JSON` object",
+				Destination: &paramsJSON,
+			},
+		},
+		Before: func(c *cli.Context) error {
+			if paramsJSON == "" {
+				return nil
+			}
+			if e := json.Unmarshal([]byte(paramsJSON), &params); e != nil {
+				return fmt.Errorf("--params: %w", e)
+			}
+			return nil
+		},
+		Action: func(c *cli.Context) error {
+			vars := map[string]interface{}{
+				"name":     name,
+				"strategy": strategy,
+			}
+			if params != nil {
+				vars["params"] = params
+			}
+
+			return clientDoPrint(c.Context, `
+				mutation setStrategy($name: Name!, $strategy: ID!, $params: JSON) {
+					setStrategy(name: $name, strategy: $strategy, params: $params) {
+						id
+					}
+				}
+			`, vars, "setStrategy")
+		},
+	})
+}
+
+func init() {
+	defineDeleteCommand("strategy", "unset-strategy", "Remove a strategy choice table entry", "strategy choice")
+}
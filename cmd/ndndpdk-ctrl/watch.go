@@ -0,0 +1,139 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+func init() {
+	var interval time.Duration
+	intervalFlag := &cli.DurationFlag{
+		Name:        "interval",
+		Usage:       "update `interval`",
+		Destination: &interval,
+		Value:       time.Second,
+	}
+
+	var id string
+	var names cli.StringSlice
+	cmd := &cli.Command{
+		Category: "watch",
+		Name:     "watch",
+		Usage:    "Watch counters, printing differences since the previous update",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "face",
+				Usage: "Watch face counters",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "id",
+						Usage:       "face `ID`",
+						Destination: &id,
+						Required:    true,
+					},
+					intervalFlag,
+				},
+				Action: func(c *cli.Context) error {
+					return clientDoPrint(c.Context, `
+						subscription watchFace($id: ID!, $interval: NNNanoseconds!) {
+							faceCounters(id: $id, interval: $interval) {`+gqlFaceCounters+`}
+						}
+					`, map[string]interface{}{
+						"id":       id,
+						"interval": interval.Nanoseconds(),
+					}, "faceCounters")
+				},
+			},
+			{
+				Name:  "fib",
+				Usage: "Watch FIB entry counters",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:        "name",
+						Usage:       "FIB entry `name` (repeatable)",
+						Destination: &names,
+						Required:    true,
+					},
+					intervalFlag,
+				},
+				Action: func(c *cli.Context) error {
+					return clientDoPrint(c.Context, `
+						subscription watchFib($names: [Name!]!, $interval: NNNanoseconds!) {
+							fibCounters(names: $names, interval: $interval) {
+								name
+								counters {
+									nRxInterests
+									nRxData
+									nRxNacks
+									nTxInterests
+									nRxCongMarks
+									nexthops {
+										nexthop
+										sRtt
+										nSatisfied
+										nTimeouts
+										nNacks
+									}
+								}
+							}
+						}
+					`, map[string]interface{}{
+						"names":    names.Value(),
+						"interval": interval.Nanoseconds(),
+					}, "fibCounters")
+				},
+			},
+			{
+				Name:  "fwd",
+				Usage: "Watch forwarding thread PIT, CS, and input queue counters",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "id",
+						Usage:       "forwarding thread `ID`",
+						Destination: &id,
+						Required:    true,
+					},
+					intervalFlag,
+				},
+				Action: func(c *cli.Context) error {
+					return clientDoPrint(c.Context, `
+						subscription watchFwd($id: ID!, $interval: NNNanoseconds!) {
+							fwdCounters(id: $id, interval: $interval) {
+								pitCounters {
+									nEntries
+									nInsert
+									nFound
+									nCsMatch
+									nAllocErr
+									nDataHit
+									nDataMiss
+									nNackHit
+									nNackMiss
+									nExpired
+								}
+								csCounters {
+									nHits
+									nMisses
+									directEntries
+									indirectEntries
+									diskEntries
+								}
+								nInterestsQueueDrops
+								nDataQueueDrops
+								nNacksQueueDrops
+							}
+						}
+					`, map[string]interface{}{
+						"id":       id,
+						"interval": interval.Nanoseconds(),
+					}, "fwdCounters")
+				},
+			},
+		},
+	}
+
+	sort.Sort(cli.CommandsByName(cmd.Subcommands))
+	defineCommand(cmd)
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+)
+
+var (
+	keyChainDir string
+	keyChain    *keychain.KeyChain
+)
+
+func openKeyChain(*cli.Context) (e error) {
+	if keyChainDir == "" {
+		return errors.New("--keychain flag is required")
+	}
+	if keyChain == nil {
+		keyChain, e = keychain.OpenKeyChain(keyChainDir)
+	}
+	return e
+}
+
+// findSigner returns a Data signer for a name prefix.
+// If --keychain flag is specified, it returns a key from the KeyChain.
+// Otherwise, it returns SigSha256 signer.
+func findSigner(name ndn.Name) (ndn.Signer, error) {
+	if keyChainDir == "" {
+		return ndn.DigestSigning, nil
+	}
+	if e := openKeyChain(nil); e != nil {
+		return nil, e
+	}
+	return keyChain.Signer(name)
+}
+
+func init() {
+	var name string
+	var rsa bool
+	keygen := &cli.Command{
+		Name:  "keygen",
+		Usage: "Generate key and self-signed certificate.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "identity `name`",
+				Destination: &name,
+				Required:    true,
+			},
+			&cli.BoolFlag{
+				Name:        "rsa",
+				Usage:       "generate RSA key instead of ECDSA key",
+				Destination: &rsa,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			newKeyPair := keychain.NewECDSAKeyPair
+			if rsa {
+				newKeyPair = keychain.NewRSAKeyPair
+			}
+			pvt, pub, e := newKeyPair(ndn.ParseName(name))
+			if e != nil {
+				return e
+			}
+			cert, e := keychain.MakeCert(pub, pvt, keychain.MakeCertOptions{
+				IssuerID: keychain.ComponentSelfIssuer,
+			})
+			if e != nil {
+				return e
+			}
+			if e = keyChain.ImportKey(pvt); e != nil {
+				return e
+			}
+			if e = keyChain.ImportCert(cert); e != nil {
+				return e
+			}
+			fmt.Println(cert.Name())
+			return nil
+		},
+	}
+
+	list := &cli.Command{
+		Name:  "list",
+		Usage: "List identities, keys, and certificates.",
+		Action: func(c *cli.Context) error {
+			dfltID := keyChain.DefaultIdentity()
+			for _, id := range keyChain.Identities() {
+				fmt.Println(defaultMark(id.Equal(dfltID)), id)
+				dfltKey, _ := keyChain.DefaultKey(id)
+				for _, key := range keyChain.Keys(id) {
+					fmt.Println(" ", defaultMark(dfltKey != nil && key.Name().Equal(dfltKey.Name())), key.Name())
+					for _, cert := range keyChain.Certs(key.Name()) {
+						fmt.Println("    ", cert.Name())
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	var delName string
+	del := &cli.Command{
+		Name:  "delete",
+		Usage: "Delete an identity, key, or certificate.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "identity, key, or certificate `name`",
+				Destination: &delName,
+				Required:    true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			name := ndn.ParseName(delName)
+			switch {
+			case keychain.IsCertName(name):
+				return keyChain.DeleteCert(name)
+			case keychain.IsKeyName(name):
+				return keyChain.DeleteKey(name)
+			default:
+				return keyChain.DeleteIdentity(name)
+			}
+		},
+	}
+
+	var dfltName string
+	setDefault := &cli.Command{
+		Name:  "set-default",
+		Usage: "Set default identity or key.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "name",
+				Usage:       "identity or key `name`",
+				Destination: &dfltName,
+				Required:    true,
+			},
+		},
+		Action: func(c *cli.Context) error {
+			name := ndn.ParseName(dfltName)
+			if keychain.IsKeyName(name) {
+				if e := keyChain.SetDefaultKey(name); e != nil {
+					return e
+				}
+				name = keychain.ToSubjectName(name)
+			}
+			return keyChain.SetDefaultIdentity(name)
+		},
+	}
+
+	defineCommand(&cli.Command{
+		Name:        "keychain",
+		Usage:       "Manage KeyChain stored in --keychain directory.",
+		Before:      openKeyChain,
+		Subcommands: []*cli.Command{keygen, list, del, setDefault},
+	})
+}
+
+func defaultMark(isDefault bool) string {
+	if isDefault {
+		return "*"
+	}
+	return " "
+}
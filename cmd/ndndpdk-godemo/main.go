@@ -79,6 +79,11 @@ var app = &cli.App{
 			Usage:       "application face `MTU`",
 			Destination: &mtuFlag,
 		},
+		&cli.StringFlag{
+			Name:        "keychain",
+			Usage:       "KeyChain `directory`",
+			Destination: &keyChainDir,
+		},
 		&cli.BoolFlag{
 			Name:        "nfd",
 			Usage:       "connect to NFD or YaNFD (set FaceUri in NDN_CLIENT_TRANSPORT environment variable)",
@@ -40,7 +40,7 @@ func init() {
 			},
 			&cli.BoolFlag{
 				Name:        "signed",
-				Usage:       "enable packet signing (SigSha256)",
+				Usage:       "enable packet signing (key from --keychain, or SigSha256)",
 				Destination: &wantSign,
 			},
 		},
@@ -50,7 +50,10 @@ func init() {
 			rand.Read(payload)
 			var signer ndn.Signer
 			if wantSign {
-				signer = ndn.DigestSigning
+				var e error
+				if signer, e = findSigner(ndn.ParseName(name)); e != nil {
+					return e
+				}
 			}
 
 			ctx, cancel := context.WithCancel(context.Background())
@@ -3,9 +3,11 @@ package main
 import (
 	"github.com/usnistgov/ndn-dpdk/app/fwdp"
 	"github.com/usnistgov/ndn-dpdk/container/fib"
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/ndt"
 	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
 )
 
 const defaultStrategyName = "multicast"
@@ -29,6 +31,7 @@ func (a fwArgs) Activate() error {
 	fwdp.GqlDataPlane = dp
 	iface.GqlCreateFaceAllowed = true
 	ndt.GqlNdt = dp.Ndt()
+	ndt.GqlBalancer = dp.NdtBalancer()
 	fib.GqlFib = dp.Fib()
 
 	fib.GqlDefaultStrategy, e = strategycode.LoadFile(defaultStrategyName, "")
@@ -36,5 +39,9 @@ func (a fwArgs) Activate() error {
 		return e
 	}
 
-	return nil
+	// FIB entries without a strategy inherit the default strategy, unless overridden by set-strategy
+	return dp.Fib().SetStrategy(fibdef.StrategyChoice{
+		Name:     ndn.Name{},
+		Strategy: fib.GqlDefaultStrategy.ID(),
+	})
 }
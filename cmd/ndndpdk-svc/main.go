@@ -19,6 +19,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
 	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
 	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/dpdk/spdkenv"
 	"github.com/usnistgov/ndn-dpdk/mk/version"
@@ -140,6 +141,10 @@ var app = &cli.App{
 			Usage: "GraphQL HTTP server base URI",
 			Value: "http://127.0.0.1:3030/",
 		},
+		&cli.StringFlag{
+			Name:  "metrics",
+			Usage: "OpenMetrics HTTP server listen address, such as 127.0.0.1:9100 (empty to disable)",
+		},
 	},
 	Action: func(c *cli.Context) (e error) {
 		listen, e := gqlclient.MakeListenAddress(c.String("gqlserver"))
@@ -156,6 +161,10 @@ var app = &cli.App{
 
 		go systemdNotify()
 
+		if metricsListen := c.String("metrics"); metricsListen != "" {
+			go serveMetrics(metricsListen)
+		}
+
 		gqlserver.Prepare()
 		logger.Info("GraphQL HTTP server starting", zap.String("listen", listen))
 		return cli.Exit(http.ListenAndServe(listen, nil), 1)
@@ -178,6 +187,14 @@ func main() {
 	app.Run(os.Args)
 }
 
+func serveMetrics(listen string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", openmetrics.Handler())
+	logger.Info("OpenMetrics HTTP server starting", zap.String("listen", listen))
+	e := http.ListenAndServe(listen, mux)
+	logger.Error("OpenMetrics HTTP server error", zap.Error(e))
+}
+
 func systemdNotify() {
 	daemon.SdNotify(false, daemon.SdNotifyReady)
 
@@ -0,0 +1,22 @@
+package cs_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/cs/cscnt"
+)
+
+func TestCountersSince(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	prev := cscnt.Counters{NHits: 10, NMisses: 5, DirectEntries: 8, DirectCapacity: 100,
+		DiskEntries: 3, DiskCapacity: 20, NDiskHits: 2, NDiskMisses: 1, NDiskFull: 4}
+	cnt := cscnt.Counters{NHits: 17, NMisses: 6, DirectEntries: 9, DirectCapacity: 100,
+		IndirectEntries: 1, IndirectCapacity: 50, DiskEntries: 2, DiskCapacity: 20,
+		NDiskHits: 5, NDiskMisses: 1, NDiskFull: 7}
+
+	// entry counts and capacities are taken from cnt
+	assert.Equal(cscnt.Counters{NHits: 7, NMisses: 1, DirectEntries: 9, DirectCapacity: 100,
+		IndirectEntries: 1, IndirectCapacity: 50, DiskEntries: 2, DiskCapacity: 20,
+		NDiskHits: 3, NDiskMisses: 0, NDiskFull: 3}, cnt.Since(prev))
+}
@@ -8,6 +8,7 @@ import "C"
 import (
 	"unsafe"
 
+	"github.com/usnistgov/ndn-dpdk/container/diskstore"
 	"github.com/usnistgov/ndn-dpdk/container/pcct"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 )
@@ -35,6 +36,36 @@ func (cs *Cs) CountEntries(list ListID) int {
 	return int(C.Cs_CountEntries(cs.ptr(), C.CsListID(list)))
 }
 
+// SetDisk enables disk caching with a DiskStore and a slot allocator.
+// The slot allocator must not be shared with another CS.
+// This must be invoked before inserting any entry.
+func (cs *Cs) SetDisk(store *diskstore.DiskStore, alloc *diskstore.Alloc) {
+	c := cs.ptr()
+	c.diskStore = (*C.DiskStore)(store.Ptr())
+	c.diskAlloc = (*C.DiskAlloc)(alloc.Ptr())
+}
+
+// DiskAlloc returns the disk slot allocator, or nil if disk caching is disabled.
+func (cs *Cs) DiskAlloc() *diskstore.Alloc {
+	return (*diskstore.Alloc)(unsafe.Pointer(cs.ptr().diskAlloc))
+}
+
+// DiskCounters returns counters related to disk caching.
+func (cs *Cs) DiskCounters() (cnt DiskCounters) {
+	c := cs.ptr()
+	cnt.NInsert = uint64(c.nDiskInsert)
+	cnt.NDelete = uint64(c.nDiskDelete)
+	cnt.NFull = uint64(c.nDiskFull)
+	cnt.NHits = uint64(c.nDiskHit)
+	cnt.NMisses = uint64(c.nDiskMiss)
+	if alloc := cs.DiskAlloc(); alloc != nil {
+		min, max := alloc.SlotRange()
+		cnt.Capacity = int(max - min + 1)
+		cnt.Entries = cnt.Capacity - alloc.CountAvailable()
+	}
+	return cnt
+}
+
 type pitFindResult interface {
 	CopyToCPitFindResult(ptr unsafe.Pointer)
 }
@@ -55,3 +86,14 @@ func (cs *Cs) Erase(entry *Entry) {
 func (cs *Cs) ReadDirectArcP() float64 {
 	return float64(cs.ptr().direct.p)
 }
+
+// DiskCounters contains counters related to disk caching.
+type DiskCounters struct {
+	NInsert  uint64 // Data written to disk
+	NDelete  uint64 // Data deleted from disk
+	NFull    uint64 // Data not written to disk due to unavailable slot
+	NHits    uint64 // Interests satisfied by Data read from disk
+	NMisses  uint64 // Interests not satisfied due to disk read failure or changed entry
+	Entries  int    // occupied slots
+	Capacity int    // total slots
+}
@@ -12,10 +12,27 @@ import (
 type Counters struct {
 	NHits            uint64 `json:"nHits" gqldesc:"Lookup hits."`
 	NMisses          uint64 `json:"nMisses" gqldesc:"Lookup misses."`
-	DirectEntries    int    `json:"directEntries" gqldesc:"Direct entries."`
-	DirectCapacity   int    `json:"directCapacity" gqldesc:"Direct capacity."`
-	IndirectEntries  int    `json:"indirectEntries" gqldesc:"Indirect entries."`
-	IndirectCapacity int    `json:"indirectCapacity" gqldesc:"Indirect capacity."`
+	DirectEntries    int    `json:"directEntries" gqldesc:"Direct entries." openmetrics:"gauge"`
+	DirectCapacity   int    `json:"directCapacity" gqldesc:"Direct capacity." openmetrics:"gauge"`
+	IndirectEntries  int    `json:"indirectEntries" gqldesc:"Indirect entries." openmetrics:"gauge"`
+	IndirectCapacity int    `json:"indirectCapacity" gqldesc:"Indirect capacity." openmetrics:"gauge"`
+	DiskEntries      int    `json:"diskEntries" gqldesc:"Entries with Data on disk." openmetrics:"gauge"`
+	DiskCapacity     int    `json:"diskCapacity" gqldesc:"Disk capacity." openmetrics:"gauge"`
+	NDiskHits        uint64 `json:"nDiskHits" gqldesc:"Lookup hits satisfied by Data read from disk."`
+	NDiskMisses      uint64 `json:"nDiskMisses" gqldesc:"Lookups that matched a disk entry but the Data could not be used."`
+	NDiskFull        uint64 `json:"nDiskFull" gqldesc:"Evicted Data not written to disk due to unavailable slot."`
+}
+
+// Since computes the difference between cnt and prev.
+// Entry counts and capacities are taken from cnt.
+func (cnt Counters) Since(prev Counters) (diff Counters) {
+	diff = cnt
+	diff.NHits -= prev.NHits
+	diff.NMisses -= prev.NMisses
+	diff.NDiskHits -= prev.NDiskHits
+	diff.NDiskMisses -= prev.NDiskMisses
+	diff.NDiskFull -= prev.NDiskFull
+	return diff
 }
 
 // ReadCounters retrieves CS counters from PIT and CS.
@@ -25,6 +42,9 @@ func ReadCounters(p *pit.Pit, c *cs.Cs) (cnt Counters) {
 	cnt.NMisses = pitCnt.NInsert + pitCnt.NFound
 	cnt.DirectEntries, cnt.DirectCapacity = readCslCnt(c, cs.ListMd)
 	cnt.IndirectEntries, cnt.IndirectCapacity = readCslCnt(c, cs.ListMi)
+	diskCnt := c.DiskCounters()
+	cnt.DiskEntries, cnt.DiskCapacity = diskCnt.Entries, diskCnt.Capacity
+	cnt.NDiskHits, cnt.NDiskMisses, cnt.NDiskFull = diskCnt.NHits, diskCnt.NMisses, diskCnt.NFull
 	return cnt
 }
 
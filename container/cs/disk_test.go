@@ -0,0 +1,154 @@
+package cs_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/cs"
+	"github.com/usnistgov/ndn-dpdk/container/diskstore"
+	"github.com/usnistgov/ndn-dpdk/container/pcct"
+	"github.com/usnistgov/ndn-dpdk/dpdk/bdev"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/dpdk/spdkenv"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"go4.org/must"
+)
+
+func TestDisk(t *testing.T) {
+	assert, require := makeAR(t)
+	defer ealthread.AllocClear()
+
+	device, e := bdev.NewMalloc(diskstore.BlockSize, 256)
+	require.NoError(e)
+	defer device.Close()
+	th, e := spdkenv.NewThread()
+	require.NoError(e)
+	defer th.Close()
+	require.NoError(ealthread.AllocLaunch(th))
+	store, e := diskstore.New(device, th, 4)
+	require.NoError(e)
+	defer store.Close()
+	const diskCapacity = 20
+	alloc := diskstore.NewAlloc(1, diskCapacity, eal.NumaSocket{})
+	defer alloc.Close()
+
+	var cfg pcct.Config
+	cfg.CsDirectCapacity = 100
+	fixture := NewFixture(cfg)
+	defer fixture.Close()
+	fixture.Cs.SetDisk(store, alloc)
+
+	// T1=[1..100] -> T1=[61..100], T2=[1..60]
+	assert.Equal(100, fixture.InsertBulk(1, 100, "/N/%d", "/N/%d"))
+	assert.Equal(60, fixture.FindBulk(1, 60, "/N/%d"))
+	assert.Zero(fixture.Cs.DiskCounters().NInsert)
+
+	// new entries push T1 entries into B1, some of which are written to disk
+	assert.Equal(100, fixture.InsertBulk(101, 200, "/N/%d", "/N/%d"))
+	time.Sleep(100 * time.Millisecond) // give time for asynchronous PutData operation
+	cnt := fixture.Cs.DiskCounters()
+	assert.Greater(fixture.Cs.CountEntries(cs.ListMdB1), diskCapacity)
+	assert.Greater(cnt.NInsert, uint64(0))
+	assert.Greater(cnt.NFull, uint64(0))
+	assert.Equal(diskCapacity, cnt.Capacity)
+	assert.Equal(diskCapacity, cnt.Entries)
+	assert.EqualValues(cnt.Entries, cnt.NInsert-cnt.NDelete)
+
+	// entries on disk match Interests without having Data in memory
+	onDisk := []int{}
+	for i := 61; i <= 200; i++ {
+		entry := fixture.Find(makeInterest(fmt.Sprintf("/N/%d", i)))
+		if entry == nil || !entry.IsOnDisk() {
+			continue
+		}
+		assert.Nil(entry.Data(), i)
+		slot, dataLen := entry.DiskSlot()
+		assert.NotZero(slot, i)
+		assert.Greater(dataLen, 0, i)
+		onDisk = append(onDisk, i)
+	}
+	require.Len(onDisk, diskCapacity)
+
+	// read Data from the disk slot of entryIndex, on behalf of an Interest for i
+	getData := func(i int, entryIndex int) (interest, data *ndni.Packet) {
+		entry := fixture.Find(makeInterest(fmt.Sprintf("/N/%d", entryIndex)))
+		require.NotNil(entry)
+		slot, dataLen := entry.DiskSlot()
+		interest = makeInterest(fmt.Sprintf("/N/%d", i))
+		dataBuf := ndni.PacketMempool.Get(eal.NumaSocket{}).MustAlloc(1)
+		data, e := store.GetData(slot, dataLen, interest, dataBuf[0])
+		require.NoError(e)
+		require.NotNil(data)
+		return interest, data
+	}
+
+	// Data read from disk is restored into the entry, which moves to T2 and releases its disk slot
+	{
+		interest, _ := getData(onDisk[0], onDisk[0])
+		pitEntry, entry := fixture.Pit.Insert(interest, fixture.FibEntry)
+		assert.Nil(pitEntry)
+		if assert.NotNil(entry) {
+			assert.False(entry.IsOnDisk())
+			slot, _ := entry.DiskSlot()
+			assert.Zero(slot)
+			if data := entry.Data(); assert.NotNil(data) {
+				nameEqual(assert, fmt.Sprintf("/N/%d", onDisk[0]), data.ToNPacket().Data)
+			}
+		}
+		must.Close(interest)
+
+		cnt1 := fixture.Cs.DiskCounters()
+		assert.Equal(cnt.NHits+1, cnt1.NHits)
+		assert.Equal(cnt.NMisses, cnt1.NMisses)
+		assert.Equal(cnt.NDelete+1, cnt1.NDelete)
+		assert.Equal(cnt.Entries-1, cnt1.Entries)
+		cnt = cnt1
+	}
+	if entry := fixture.Find(makeInterest(fmt.Sprintf("/N/%d", onDisk[0]))); assert.NotNil(entry) {
+		assert.NotNil(entry.Data())
+	}
+
+	// Data read from another slot is not used, and the entry stays on disk
+	{
+		interest, data := getData(onDisk[1], onDisk[2])
+		pitEntry, entry := fixture.Pit.Insert(interest, fixture.FibEntry)
+		assert.Nil(entry)
+		require.NotNil(pitEntry)
+		fixture.Pit.Erase(pitEntry)
+		must.Close(data)
+
+		cnt1 := fixture.Cs.DiskCounters()
+		assert.Equal(cnt.NHits, cnt1.NHits)
+		assert.Equal(cnt.NMisses+1, cnt1.NMisses)
+		assert.Equal(cnt.NDelete, cnt1.NDelete)
+		cnt = cnt1
+	}
+	if entry := fixture.Find(makeInterest(fmt.Sprintf("/N/%d", onDisk[1]))); assert.NotNil(entry) {
+		assert.True(entry.IsOnDisk())
+	}
+
+	// Data on disk has been overwritten with another name: the disk slot is released
+	{
+		entry := fixture.Find(makeInterest(fmt.Sprintf("/N/%d", onDisk[3])))
+		require.NotNil(entry)
+		slot, _ := entry.DiskSlot()
+		store.PutData(slot, makeData(fmt.Sprintf("/X/%d", onDisk[3]), time.Second))
+		time.Sleep(100 * time.Millisecond)
+
+		interest, data := getData(onDisk[3], onDisk[3])
+		pitEntry, entry := fixture.Pit.Insert(interest, fixture.FibEntry)
+		assert.Nil(entry)
+		require.NotNil(pitEntry)
+		fixture.Pit.Erase(pitEntry)
+		must.Close(data)
+
+		cnt1 := fixture.Cs.DiskCounters()
+		assert.Equal(cnt.NMisses+1, cnt1.NMisses)
+		assert.Equal(cnt.NDelete+1, cnt1.NDelete)
+		assert.Equal(cnt.Entries-1, cnt1.Entries)
+		cnt = cnt1
+	}
+	assert.Nil(fixture.Find(makeInterest(fmt.Sprintf("/N/%d", onDisk[3]))))
+}
@@ -57,3 +57,18 @@ func (entry *Entry) FreshUntil() eal.TscTime {
 func (entry *Entry) IsFresh(now eal.TscTime) bool {
 	return entry.FreshUntil() > now
 }
+
+// IsOnDisk determines whether the Data packet is stored in DiskStore instead of memory.
+func (entry *Entry) IsOnDisk() bool {
+	return bool(C.CsEntry_IsOnDisk(entry.ptr()))
+}
+
+// DiskSlot returns DiskStore slot number and Data packet length of the direct entry.
+// slot is zero if the Data packet is not on disk.
+func (entry *Entry) DiskSlot() (slot uint64, dataLen int) {
+	direct := C.CsEntry_GetDirect(entry.ptr())
+	if direct.diskSlot == 0 {
+		return 0, 0
+	}
+	return uint64(direct.diskSlot), int(direct.dataLen)
+}
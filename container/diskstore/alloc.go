@@ -0,0 +1,55 @@
+package diskstore
+
+/*
+#include "../../csrc/diskstore/diskalloc.h"
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+// Alloc represents a DiskStore slot allocator.
+type Alloc C.DiskAlloc
+
+// NewAlloc creates an Alloc that allocates slot numbers between min and max, inclusive.
+func NewAlloc(min, max uint64, socket eal.NumaSocket) *Alloc {
+	return (*Alloc)(C.DiskAlloc_New(C.uint64_t(min), C.uint64_t(max), C.int(socket.ID())))
+}
+
+// Ptr returns *C.DiskAlloc pointer.
+func (a *Alloc) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(a)
+}
+
+func (a *Alloc) ptr() *C.DiskAlloc {
+	return (*C.DiskAlloc)(a)
+}
+
+// SlotRange returns a range of slot numbers managed by this allocator.
+func (a *Alloc) SlotRange() (min, max uint64) {
+	return uint64(a.min), uint64(a.max)
+}
+
+// CountAvailable returns number of unallocated slots.
+func (a *Alloc) CountAvailable() int {
+	return int(a.nAvail)
+}
+
+// Alloc allocates a slot.
+// Returns 0 if no slot is available.
+func (a *Alloc) Alloc() uint64 {
+	return uint64(C.DiskAlloc_Alloc(a.ptr()))
+}
+
+// Free releases a slot.
+func (a *Alloc) Free(slotID uint64) {
+	C.DiskAlloc_Free(a.ptr(), C.uint64_t(slotID))
+}
+
+// Close releases memory.
+func (a *Alloc) Close() error {
+	eal.Free(a.Ptr())
+	return nil
+}
@@ -0,0 +1,35 @@
+package diskstore_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/diskstore"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+func TestAlloc(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	a := diskstore.NewAlloc(100, 299, eal.NumaSocket{})
+	defer a.Close()
+
+	min, max := a.SlotRange()
+	assert.Equal(uint64(100), min)
+	assert.Equal(uint64(299), max)
+	assert.Equal(200, a.CountAvailable())
+
+	slots := map[uint64]bool{}
+	for i := 0; i < 200; i++ {
+		slot := a.Alloc()
+		assert.GreaterOrEqual(slot, uint64(100))
+		assert.LessOrEqual(slot, uint64(299))
+		assert.False(slots[slot])
+		slots[slot] = true
+	}
+	assert.Equal(0, a.CountAvailable())
+	assert.Zero(a.Alloc())
+
+	a.Free(150)
+	assert.Equal(1, a.CountAvailable())
+	assert.Equal(uint64(150), a.Alloc())
+}
@@ -0,0 +1,18 @@
+package diskstore
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+)
+
+// Counters contains DiskStore counters.
+type Counters struct {
+	NPutDataFails uint64 `json:"nPutDataFails" gqldesc:"Failed write operations."`
+	NGetDataFails uint64 `json:"nGetDataFails" gqldesc:"Failed read operations."`
+}
+
+// GqlCountersType is the GraphQL type for Counters.
+var GqlCountersType = graphql.NewObject(graphql.ObjectConfig{
+	Name:   "DiskStoreCounters",
+	Fields: gqlserver.BindFields(Counters{}, nil),
+})
@@ -59,11 +59,23 @@ func (store *DiskStore) Close() error {
 	return store.bd.Close()
 }
 
+// Ptr returns *C.DiskStore pointer.
+func (store *DiskStore) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(store.c)
+}
+
 // SlotRange returns a range of possible slot numbers.
 func (store *DiskStore) SlotRange() (min, max uint64) {
 	return 1, uint64(store.bd.DevInfo().CountBlocks()/int(store.c.nBlocksPerSlot) - 1)
 }
 
+// Counters retrieves DiskStore counters.
+func (store *DiskStore) Counters() (cnt Counters) {
+	cnt.NPutDataFails = uint64(store.c.nPutDataFails)
+	cnt.NGetDataFails = uint64(store.c.nGetDataFails)
+	return cnt
+}
+
 // PutData asynchronously stores a Data packet.
 func (store *DiskStore) PutData(slotID uint64, data *ndni.Packet) {
 	C.DiskStore_PutData(store.c, C.uint64_t(slotID), (*C.Packet)(data.Ptr()))
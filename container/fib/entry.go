@@ -3,6 +3,7 @@ package fib
 import (
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
 )
 
 // Entry represents a FIB entry.
@@ -23,3 +24,25 @@ func (entry *Entry) Counters() (cnt fibdef.EntryCounters) {
 	})
 	return
 }
+
+// StrategyInherited determines whether the strategy is inherited from strategy choice table.
+func (entry *Entry) StrategyInherited() (inherited bool) {
+	eal.CallMain(func() {
+		inherited = entry.fib.inherit[entry.Name.String()]
+	})
+	return
+}
+
+// LearnedNexthops returns nexthops added by strategies via LearnNexthop.
+func (entry *Entry) LearnedNexthops() (list []iface.ID) {
+	eal.CallMain(func() {
+		if learned := entry.fib.learned[entry.Name.String()]; learned != nil {
+			for _, nh := range entry.Nexthops {
+				if _, ok := learned.expiry[nh]; ok {
+					list = append(list, nh)
+				}
+			}
+		}
+	})
+	return
+}
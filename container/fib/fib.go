@@ -3,11 +3,13 @@ package fib
 
 import (
 	"fmt"
+	"sync"
 	"unsafe"
 
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibreplica"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibtree"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/core/urcu"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/ndn"
@@ -18,6 +20,14 @@ import (
 type Fib struct {
 	tree     *fibtree.Tree
 	replicas map[eal.NumaSocket]*fibreplica.Table
+
+	choicesLock sync.RWMutex
+	choices     map[string]fibdef.StrategyChoice
+	inherit     map[string]bool // names of entries whose strategy comes from strategy choice table
+
+	learned map[string]*learnedNexthops // learned nexthops, accessed on main thread
+
+	metrics []ndn.Name // names of entries exported as OpenMetrics
 }
 
 // Len returns number of entries.
@@ -69,21 +79,54 @@ func (fib *Fib) Find(name ndn.Name) *Entry {
 }
 
 // Insert inserts or replaces a FIB entry.
+// If entry.Strategy is zero, the strategy and its parameters are inherited from the strategy
+// choice table, and would follow future changes in the strategy choice table; in this case,
+// entry.Params must be empty.
 func (fib *Fib) Insert(entry fibdef.Entry) (e error) {
+	eal.CallMain(func() {
+		if e = fib.doInsert(entry); e == nil {
+			delete(fib.learned, entry.Name.String())
+		}
+	})
+	return e
+}
+
+func (fib *Fib) doInsert(entry fibdef.Entry) error {
+	inherit := entry.Strategy == 0
+	if inherit && len(entry.Params) > 0 {
+		return errInheritParams
+	}
+	if inherit {
+		if choice := fib.LpmStrategyChoice(entry.Name); choice != nil {
+			entry.Strategy, entry.Params = choice.Strategy, choice.Params
+		}
+	}
+
 	if e := entry.Validate(); e != nil {
 		return fmt.Errorf("entry.Validate: %w", e)
 	}
+	if e := checkStrategy(entry.Strategy, entry.Params); e != nil {
+		return e
+	}
 
-	eal.CallMain(func() {
-		e = fib.doUpdate(fib.tree.Insert(entry))
-	})
-	return e
+	if e := fib.doUpdate(fib.tree.Insert(entry)); e != nil {
+		return e
+	}
+	if inherit {
+		fib.inherit[entry.Name.String()] = true
+	} else {
+		delete(fib.inherit, entry.Name.String())
+	}
+	return nil
 }
 
 // Erase deletes a FIB entry.
 func (fib *Fib) Erase(name ndn.Name) (e error) {
 	eal.CallMain(func() {
-		e = fib.doUpdate(fib.tree.Erase(name))
+		if e = fib.doUpdate(fib.tree.Erase(name)); e == nil {
+			delete(fib.inherit, name.String())
+			delete(fib.learned, name.String())
+		}
 	})
 	return e
 }
@@ -116,6 +159,10 @@ func New(cfg fibdef.Config, threads []LookupThread) (*Fib, error) {
 	fib := &Fib{
 		tree:     fibtree.New(cfg.StartDepth),
 		replicas: make(map[eal.NumaSocket]*fibreplica.Table),
+		choices:  make(map[string]fibdef.StrategyChoice),
+		inherit:  make(map[string]bool),
+		learned:  make(map[string]*learnedNexthops),
+		metrics:  cfg.Metrics,
 	}
 
 	threadByNuma := eal.ClassifyByNumaSocket(threads, eal.RewriteAnyNumaSocketFirst).(map[eal.NumaSocket][]LookupThread)
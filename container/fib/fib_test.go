@@ -3,10 +3,13 @@ package fib_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/container/fib"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibreplica"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibtestenv"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
@@ -204,3 +207,241 @@ func TestLpm(t *testing.T) {
 	checkEntryNames()
 	checkLpms(0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
 }
+
+func TestStrategyChoice(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var th0 fibtestenv.LookupThread
+	f, e := fib.New(fibdef.Config{
+		Capacity:   1023,
+		StartDepth: 2,
+	}, []fib.LookupThread{&th0})
+	require.NoError(e)
+	defer f.Close()
+
+	scP := strategycode.MakeEmpty("P")
+	scQ := strategycode.MakeEmpty("Q")
+	defer scP.Close()
+	defer scQ.Close()
+
+	getStrategy := func(name string) int {
+		entry := f.Find(ndn.ParseName(name))
+		if entry == nil {
+			return -1
+		}
+		entryR := f.Replica(th0.Socket).Get(entry.Name)
+		if assert.NotNil(entryR, "%s", name) {
+			assert.Equal(entry.Strategy, entryR.Real().Read().Strategy, "%s", name)
+		}
+		return entry.Strategy
+	}
+
+	// no strategy choice, cannot inherit
+	assert.Error(f.Insert(makeEntry("/A/B", 0, 5000)))
+
+	assert.NoError(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/"), Strategy: scP.ID()}))
+	assert.Error(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/Z"), Strategy: 9999}))
+	assert.Error(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/Z"), Strategy: scP.ID(),
+		Params: map[string]interface{}{"x": 1}}))
+
+	assert.NoError(f.Insert(makeEntry("/A/B", 0, 5000)))
+	assert.NoError(f.Insert(makeEntry("/A/B/C", 0, 5001)))
+	assert.NoError(f.Insert(makeEntry("/A/D", scP, 5002)))
+	assert.NoError(f.Insert(makeEntry("/E", 0, 5003)))
+	assert.Equal(scP.ID(), getStrategy("/A/B"))
+	assert.Equal(scP.ID(), getStrategy("/A/B/C"))
+	assert.Equal(scP.ID(), getStrategy("/A/D"))
+	assert.Equal(scP.ID(), getStrategy("/E"))
+
+	// /A/B and /A/B/C inherit from /A; /A/D has explicit strategy
+	assert.NoError(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/A"), Strategy: scQ.ID()}))
+	assert.Equal(scQ.ID(), getStrategy("/A/B"))
+	assert.Equal(scQ.ID(), getStrategy("/A/B/C"))
+	assert.Equal(scP.ID(), getStrategy("/A/D"))
+	assert.Equal(scP.ID(), getStrategy("/E"))
+	assert.True(f.Find(ndn.ParseName("/A/B")).StrategyInherited())
+	assert.False(f.Find(ndn.ParseName("/A/D")).StrategyInherited())
+
+	// entries inserted later also inherit
+	assert.NoError(f.Insert(makeEntry("/A/F/G", 0, 5004)))
+	assert.Equal(scQ.ID(), getStrategy("/A/F/G"))
+
+	// params without strategy are rejected, instead of being replaced by inherited params
+	withParams := makeEntry("/A/H", 0, 5005)
+	withParams.Params = map[string]interface{}{"x": 1}
+	assert.Error(f.Insert(withParams))
+	assert.Nil(f.Find(ndn.ParseName("/A/H")))
+
+	choice := f.LpmStrategyChoice(ndn.ParseName("/A/F/G"))
+	if assert.NotNil(choice) {
+		nameEqual(assert, "/A", choice.Name)
+	}
+	assert.Len(f.ListStrategyChoices(), 2)
+
+	// unset /A, entries fall back to /
+	assert.NoError(f.UnsetStrategy(ndn.ParseName("/A")))
+	assert.Error(f.UnsetStrategy(ndn.ParseName("/A")))
+	assert.Equal(scP.ID(), getStrategy("/A/B"))
+	assert.Equal(scP.ID(), getStrategy("/A/F/G"))
+	assert.Nil(f.FindStrategyChoice(ndn.ParseName("/A")))
+
+	// explicit strategy on previously inheriting entry stops inheritance
+	assert.NoError(f.Insert(makeEntry("/A/B", scQ, 5000)))
+	assert.NoError(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/A"), Strategy: scP.ID()}))
+	assert.NoError(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/"), Strategy: scQ.ID()}))
+	assert.Equal(scQ.ID(), getStrategy("/A/B"))
+	assert.Equal(scP.ID(), getStrategy("/A/B/C"))
+	assert.Equal(scQ.ID(), getStrategy("/E"))
+
+	// strategy referenced by strategy choice cannot be unloaded via GraphQL
+	fib.GqlFib = f
+	defer func() { fib.GqlFib = nil }()
+	assert.Error(strategycode.GqlStrategyNodeType.Delete(scP))
+	assert.NotNil(strategycode.Get(scP.ID()))
+	scR := strategycode.MakeEmpty("R")
+	assert.NoError(strategycode.GqlStrategyNodeType.Delete(scR))
+	assert.Nil(strategycode.Get(scR.ID()))
+}
+
+func TestReplaceStrategy(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var th0 fibtestenv.LookupThread
+	f, e := fib.New(fibdef.Config{
+		Capacity:   1023,
+		StartDepth: 2,
+	}, []fib.LookupThread{&th0})
+	require.NoError(e)
+	defer f.Close()
+
+	scP := strategycode.MakeEmpty("P")
+	scQ := strategycode.MakeEmpty("Q")
+	defer scP.Close()
+	defer scQ.Close()
+	idP := scP.ID()
+
+	require.NoError(f.SetStrategy(fibdef.StrategyChoice{Name: ndn.ParseName("/"), Strategy: idP}))
+	require.NoError(f.Insert(makeEntry("/A/B", 0, 5000)))
+	require.NoError(f.Insert(makeEntry("/A/C", scP, 5001, 5002)))
+	require.NoError(f.Insert(makeEntry("/D", scQ, 5003)))
+	entryPtrs := map[string]*fibreplica.Entry{}
+	for _, name := range []string{"/A/B", "/A/C", "/D"} {
+		entryPtrs[name] = f.Replica(th0.Socket).Get(ndn.ParseName(name))
+	}
+
+	assert.Error(f.ReplaceStrategy(scP, scP, false))
+
+	scR := strategycode.MakeEmpty("R")
+	idR := scR.ID()
+	assert.NoError(f.ReplaceStrategy(scP, scR, false))
+	assert.Nil(strategycode.Get(idR))
+	assert.Same(scP, strategycode.Get(idP))
+	assert.Equal("R", scP.Name())
+	assert.True(f.UsesStrategy(scP))
+
+	for name, sc := range map[string]*strategycode.Strategy{"/A/B": scP, "/A/C": scP, "/D": scQ} {
+		entry := f.Find(ndn.ParseName(name))
+		if !assert.NotNil(entry, "%s", name) {
+			continue
+		}
+		assert.Equal(sc.ID(), entry.Strategy, "%s", name)
+		entryR := f.Replica(th0.Socket).Get(entry.Name)
+		if assert.NotNil(entryR, "%s", name) {
+			assert.Same(entryPtrs[name], entryR, "%s entry should not be re-inserted", name)
+			entryRead := entryR.Real().Read()
+			assert.Equal(entry.Strategy, entryRead.Strategy, "%s", name)
+			assert.Equal(entry.Nexthops, entryRead.Nexthops, "%s", name)
+		}
+	}
+	assert.True(f.Find(ndn.ParseName("/A/B")).StrategyInherited())
+	assert.False(f.Find(ndn.ParseName("/A/C")).StrategyInherited())
+	assert.Len(f.Find(ndn.ParseName("/A/C")).Nexthops, 2)
+
+	choice := f.FindStrategyChoice(ndn.ParseName("/"))
+	if assert.NotNil(choice) {
+		assert.Equal(idP, choice.Strategy)
+	}
+
+	// entries inserted later inherit the same strategy
+	assert.NoError(f.Insert(makeEntry("/E", 0, 5004)))
+	assert.Equal(idP, f.Find(ndn.ParseName("/E")).Strategy)
+}
+
+func TestLearnNexthop(t *testing.T) {
+	assert, require := makeAR(t)
+
+	var th0 fibtestenv.LookupThread
+	f, e := fib.New(fibdef.Config{
+		Capacity:   1023,
+		StartDepth: 2,
+	}, []fib.LookupThread{&th0})
+	require.NoError(e)
+	defer f.Close()
+
+	scP := strategycode.MakeEmpty("P")
+	defer scP.Close()
+
+	nameA := ndn.ParseName("/A")
+	require.NoError(f.Insert(makeEntry("/A", scP, 5000)))
+	assert.Error(f.LearnNexthop(ndn.ParseName("/B"), 5001, time.Second))
+	assert.Error(f.LearnNexthop(nameA, 5001, 0))
+
+	assert.NoError(f.LearnNexthop(nameA, 5001, 200*time.Millisecond))
+	assert.NoError(f.LearnNexthop(nameA, 5002, 600*time.Millisecond))
+	assert.NoError(f.LearnNexthop(nameA, 5000, time.Second)) // already a nexthop, not learned
+	entry := f.Find(nameA)
+	require.NotNil(entry)
+	assert.Equal([]iface.ID{5000, 5001, 5002}, entry.Nexthops)
+	assert.Equal([]iface.ID{5001, 5002}, entry.LearnedNexthops())
+	assert.Len(f.ListLearnedNexthops(), 2)
+	if entryR := f.Replica(th0.Socket).Get(nameA); assert.NotNil(entryR) {
+		assert.Equal(entry.Nexthops, entryR.Real().Read().Nexthops)
+	}
+
+	// refresh 5001
+	assert.NoError(f.LearnNexthop(nameA, 5001, time.Second))
+	assert.NoError(f.ExpireLearnedNexthops(time.Now().Add(800 * time.Millisecond)))
+	assert.Equal([]iface.ID{5000, 5001}, f.Find(nameA).Nexthops)
+
+	// configured nexthop is unaffected by forget
+	assert.NoError(f.ForgetNexthop(nameA, 5000))
+	assert.NoError(f.ForgetNexthop(nameA, 5001))
+	assert.Equal([]iface.ID{5000}, f.Find(nameA).Nexthops)
+	assert.Len(f.ListLearnedNexthops(), 0)
+
+	// Insert replaces learned nexthops
+	assert.NoError(f.LearnNexthop(nameA, 5003, time.Second))
+	require.NoError(f.Insert(makeEntry("/A", scP, 5004)))
+	assert.Len(f.Find(nameA).LearnedNexthops(), 0)
+	assert.Len(f.ListLearnedNexthops(), 0)
+}
+
+func TestEntryCountersSince(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	prev := fibdef.EntryCounters{
+		NRxInterests: 10, NRxData: 6, NRxNacks: 2, NTxInterests: 12, NRxCongMarks: 1,
+		Nexthops: []fibdef.NexthopCounters{
+			{Nexthop: 4001, SRtt: 10 * time.Millisecond, NSatisfied: 5, NTimeouts: 1, NNacks: 1},
+			{Nexthop: 4002, NSatisfied: 1, NTimeouts: 0, NNacks: 1},
+		},
+	}
+	cnt := fibdef.EntryCounters{
+		NRxInterests: 15, NRxData: 9, NRxNacks: 2, NTxInterests: 18, NRxCongMarks: 3,
+		Nexthops: []fibdef.NexthopCounters{
+			{Nexthop: 4002, NSatisfied: 3, NTimeouts: 1, NNacks: 1},
+			{Nexthop: 4001, SRtt: 20 * time.Millisecond, NSatisfied: 6, NTimeouts: 1, NNacks: 2},
+			{Nexthop: 4003, SRtt: 30 * time.Millisecond, NSatisfied: 1},
+		},
+	}
+
+	// nexthops are matched by FaceID, RTT estimates are taken from cnt, new nexthops are included as is
+	assert.Equal(fibdef.EntryCounters{
+		NRxInterests: 5, NRxData: 3, NRxNacks: 0, NTxInterests: 6, NRxCongMarks: 2,
+		Nexthops: []fibdef.NexthopCounters{
+			{Nexthop: 4002, NSatisfied: 2, NTimeouts: 1, NNacks: 0},
+			{Nexthop: 4001, SRtt: 20 * time.Millisecond, NSatisfied: 1, NTimeouts: 0, NNacks: 1},
+			{Nexthop: 4003, SRtt: 30 * time.Millisecond, NSatisfied: 1},
+		},
+	}, cnt.Since(prev))
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 
 	mathpkg "github.com/pkg/math"
+	"github.com/usnistgov/ndn-dpdk/ndn"
 )
 
 // Limits and defaults.
@@ -22,6 +23,10 @@ type Config struct {
 	Capacity   int `json:"capacity,omitempty"`   // Capacity.
 	NBuckets   int `json:"nBuckets,omitempty"`   // Hashtable buckets.
 	StartDepth int `json:"startDepth,omitempty"` // 'M' in 2-stage LPM algorithm.
+
+	// Metrics lists names of FIB entries whose counters are exported as OpenMetrics.
+	// Default is empty, which disables FIB entry metrics.
+	Metrics []ndn.Name `json:"metrics,omitempty"`
 }
 
 // ApplyDefaults applies defaults.
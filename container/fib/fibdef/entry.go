@@ -2,6 +2,8 @@ package fibdef
 
 import (
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
@@ -11,6 +13,10 @@ import (
 type EntryBody struct {
 	Nexthops []iface.ID `json:"nexthops"`
 	Strategy int        `json:"strategy"`
+
+	// Params contains strategy parameters.
+	// They must conform to the parameters schema declared by the strategy.
+	Params map[string]interface{} `json:"params,omitempty"`
 }
 
 // Equals determines whether two EntryBody records have the same values.
@@ -18,6 +24,9 @@ func (body EntryBody) Equals(other EntryBody) bool {
 	if body.Strategy != other.Strategy || len(body.Nexthops) != len(other.Nexthops) {
 		return false
 	}
+	if (len(body.Params) > 0 || len(other.Params) > 0) && !reflect.DeepEqual(body.Params, other.Params) {
+		return false
+	}
 	for i, n := range body.Nexthops {
 		if n != other.Nexthops[i] {
 			return false
@@ -52,8 +61,87 @@ type EntryCounters struct {
 	NRxData      uint64 `json:"nRxData"`
 	NRxNacks     uint64 `json:"nRxNacks"`
 	NTxInterests uint64 `json:"nTxInterests"`
+	NRxCongMarks uint64 `json:"nRxCongMarks"`
+
+	// Nexthops contains per-nexthop statistics, in the same order as nexthops in the FIB entry.
+	Nexthops []NexthopCounters `json:"nexthops"`
 }
 
 func (cnt EntryCounters) String() string {
-	return fmt.Sprintf("%dI %dD %dN %dO", cnt.NRxInterests, cnt.NRxData, cnt.NRxNacks, cnt.NTxInterests)
+	return fmt.Sprintf("%dI %dD %dN %dO %dM", cnt.NRxInterests, cnt.NRxData, cnt.NRxNacks, cnt.NTxInterests, cnt.NRxCongMarks)
+}
+
+// Since computes the difference between cnt and prev.
+// Per-nexthop statistics are matched by nexthop FaceID; RTT estimates are taken from cnt.
+func (cnt EntryCounters) Since(prev EntryCounters) (diff EntryCounters) {
+	diff.NRxInterests = cnt.NRxInterests - prev.NRxInterests
+	diff.NRxData = cnt.NRxData - prev.NRxData
+	diff.NRxNacks = cnt.NRxNacks - prev.NRxNacks
+	diff.NTxInterests = cnt.NTxInterests - prev.NTxInterests
+	diff.NRxCongMarks = cnt.NRxCongMarks - prev.NRxCongMarks
+
+	prevNh := map[iface.ID]NexthopCounters{}
+	for _, nh := range prev.Nexthops {
+		prevNh[nh.Nexthop] = nh
+	}
+	for _, nh := range cnt.Nexthops {
+		p := prevNh[nh.Nexthop]
+		nh.NSatisfied -= p.NSatisfied
+		nh.NTimeouts -= p.NTimeouts
+		nh.NNacks -= p.NNacks
+		diff.Nexthops = append(diff.Nexthops, nh)
+	}
+	return diff
+}
+
+// NexthopCounters contains per-nexthop statistics maintained by the forwarder.
+type NexthopCounters struct {
+	Nexthop    iface.ID      `json:"nexthop" openmetrics:"-"`
+	SRtt       time.Duration `json:"sRtt" gqldesc:"Smoothed RTT, zero if there is no RTT sample."`
+	RttVar     time.Duration `json:"rttVar" gqldesc:"RTT variation."`
+	Rto        time.Duration `json:"rto" gqldesc:"Retransmission timeout, zero if there is no RTT sample."`
+	NSatisfied uint64        `json:"nSatisfied" gqldesc:"Interests satisfied by Data."`
+	NTimeouts  uint64        `json:"nTimeouts" gqldesc:"Interests expired without Data or Nack."`
+	NNacks     uint64        `json:"nNacks" gqldesc:"Interests answered by Nack."`
+
+	nRttSamples uint64
+}
+
+// Add merges statistics collected by another forwarding thread.
+// RTT estimates are averaged, weighted by the number of satisfied Interests.
+func (cnt *NexthopCounters) Add(other NexthopCounters) {
+	if other.SRtt > 0 && other.NSatisfied > 0 {
+		w0, w1 := time.Duration(cnt.nRttSamples), time.Duration(other.NSatisfied)
+		cnt.SRtt = (cnt.SRtt*w0 + other.SRtt*w1) / (w0 + w1)
+		cnt.RttVar = (cnt.RttVar*w0 + other.RttVar*w1) / (w0 + w1)
+		cnt.Rto = (cnt.Rto*w0 + other.Rto*w1) / (w0 + w1)
+		cnt.nRttSamples += other.NSatisfied
+	}
+	cnt.NSatisfied += other.NSatisfied
+	cnt.NTimeouts += other.NTimeouts
+	cnt.NNacks += other.NNacks
+}
+
+func (cnt NexthopCounters) String() string {
+	return fmt.Sprintf("%d srtt=%dms rto=%dms %dD %dT %dN", cnt.Nexthop,
+		cnt.SRtt.Milliseconds(), cnt.Rto.Milliseconds(), cnt.NSatisfied, cnt.NTimeouts, cnt.NNacks)
+}
+
+// StrategyChoice represents a strategy choice table entry.
+// It selects a strategy for FIB entries at or below its name that do not specify a strategy.
+type StrategyChoice struct {
+	Name     ndn.Name               `json:"name"`
+	Strategy int                    `json:"strategy"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+}
+
+// Validate checks strategy choice fields.
+func (sc *StrategyChoice) Validate() error {
+	if sc.Name.Length() > MaxNameLength {
+		return ErrNameTooLong
+	}
+	if sc.Strategy == 0 {
+		return ErrStrategy
+	}
+	return nil
 }
@@ -15,6 +15,9 @@ const (
 	// ScratchSize is the size of strategy scratch area.
 	ScratchSize = 96
 
+	// MaxParams is the maximum number of strategy parameters in a FIB entry.
+	MaxParams = 4
+
 	_ = "enumgen::Fib"
 )
 
@@ -20,6 +20,14 @@ type RealUpdate struct {
 	Name     ndn.Name
 	Action   UpdateAction
 	WithVirt *VirtUpdate
+
+	// KeepCounters, when replacing an entry, copies counters and per-nexthop statistics
+	// from the old entry instead of resetting them.
+	KeepCounters bool
+
+	// KeepScratch, when replacing an entry, copies strategy scratch areas from the old entry
+	// instead of resetting them.
+	KeepScratch bool
 }
 
 // VirtUpdate represents a virtual entry update command.
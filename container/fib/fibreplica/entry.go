@@ -10,6 +10,7 @@ import (
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/core/cptr"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/iface"
 )
 
@@ -51,12 +52,30 @@ func (entry *Entry) Read() (de fibdef.Entry) {
 // AccCounters adds to counters.
 func (entry *Entry) AccCounters(cnt *fibdef.EntryCounters, t *Table) {
 	c := entry.Real().ptr()
+	if cnt.Nexthops == nil {
+		cnt.Nexthops = make([]fibdef.NexthopCounters, int(c.nNexthops))
+		for j := range cnt.Nexthops {
+			cnt.Nexthops[j].Nexthop = iface.ID(c.nexthops[j])
+		}
+	}
 	for i := 0; i < t.nDyns; i++ {
 		dyn := C.FibEntry_PtrDyn(c, C.int(i))
 		cnt.NRxInterests += uint64(dyn.nRxInterests)
 		cnt.NRxData += uint64(dyn.nRxData)
 		cnt.NRxNacks += uint64(dyn.nRxNacks)
 		cnt.NTxInterests += uint64(dyn.nTxInterests)
+		cnt.NRxCongMarks += uint64(dyn.nRxCongMarks)
+		for j := range cnt.Nexthops {
+			stats := dyn.nhStats[j]
+			cnt.Nexthops[j].Add(fibdef.NexthopCounters{
+				SRtt:       eal.FromTscDuration(int64(stats.sRtt)),
+				RttVar:     eal.FromTscDuration(int64(stats.rttVar)),
+				Rto:        eal.FromTscDuration(int64(stats.rto)),
+				NSatisfied: uint64(stats.nSatisfied),
+				NTimeouts:  uint64(stats.nTimeouts),
+				NNacks:     uint64(stats.nNacks),
+			})
+		}
 	}
 }
 
@@ -88,8 +107,47 @@ func (entry *Entry) assignReal(u *fibdef.RealUpdate) {
 		c.nexthops[i] = C.FaceID(nh)
 	}
 
+	sc := strategycode.Get(u.Strategy)
 	ptrStrategy := C.FibEntry_PtrStrategy(c)
-	*ptrStrategy = (*C.StrategyCode)(strategycode.Get(u.Strategy).Ptr())
+	*ptrStrategy = (*C.StrategyCode)(sc.Ptr())
+
+	params, _ := sc.EncodeParams(u.Params) // validated in fib.Insert
+	for i := range c.params {
+		c.params[i] = 0
+	}
+	for i, v := range params {
+		c.params[i] = C.int64_t(v)
+	}
+}
+
+func (entry *Entry) copyDyn(old *Entry, t *Table, counters, scratch bool) {
+	c, oldC := entry.ptr(), old.ptr()
+	for i := 0; i < t.nDyns; i++ {
+		dyn, oldDyn := C.FibEntry_PtrDyn(c, C.int(i)), C.FibEntry_PtrDyn(oldC, C.int(i))
+		if counters {
+			sc := dyn.scratch
+			*dyn = *oldDyn
+			dyn.scratch = sc
+			copyNexthopStats(dyn, c, oldDyn, oldC)
+		}
+		if scratch {
+			dyn.scratch, dyn.scratchGen = oldDyn.scratch, oldDyn.scratchGen
+		}
+	}
+}
+
+// copyNexthopStats copies per-nexthop statistics by FaceID, because nexthops may have been
+// added, removed, or reordered.
+func copyNexthopStats(dyn *C.FibEntryDyn, c *C.FibEntry, oldDyn *C.FibEntryDyn, oldC *C.FibEntry) {
+	for i := 0; i < int(c.nNexthops); i++ {
+		dyn.nhStats[i] = C.FibNexthopStats{}
+		for j := 0; j < int(oldC.nNexthops); j++ {
+			if c.nexthops[i] == oldC.nexthops[j] {
+				dyn.nhStats[i] = oldDyn.nhStats[j]
+				break
+			}
+		}
+	}
 }
 
 func (entry *Entry) assignVirt(u *fibdef.VirtUpdate, real *Entry) {
@@ -97,6 +97,9 @@ func (u *realUpdate) execute(t *Table, allocated []*Entry) {
 	case fibdef.ActInsert, fibdef.ActReplace:
 		u.newReal = allocated[0]
 		u.newReal.assignReal(u.RealUpdate)
+		if u.oldReal != nil {
+			u.newReal.copyDyn(u.oldReal, t, u.KeepCounters, u.KeepScratch)
+		}
 		if u.WithVirt != nil {
 			u.newVirt = allocated[1]
 			u.newVirt.assignVirt(u.WithVirt, u.newReal)
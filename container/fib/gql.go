@@ -1,13 +1,19 @@
 package fib
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"time"
 
+	"github.com/functionalfoundry/graphqlws"
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
 	"github.com/usnistgov/ndn-dpdk/container/strategycode"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver/gqlsub"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndni"
@@ -25,15 +31,39 @@ var (
 
 // GraphQL types.
 var (
-	GqlEntryCountersType graphql.Type
-	GqlEntryNodeType     *gqlserver.NodeType
-	GqlEntryType         *graphql.Object
+	GqlNexthopCountersType    graphql.Type
+	GqlEntryCountersType      graphql.Type
+	GqlEntryCountersDiffType  *graphql.Object
+	GqlEntryNodeType          *gqlserver.NodeType
+	GqlEntryType              *graphql.Object
+	GqlStrategyChoiceNodeType *gqlserver.NodeType
+	GqlStrategyChoiceType     *graphql.Object
 )
 
+// entryCountersDiff contains counters of a FIB entry, as differences since the previous update.
+type entryCountersDiff struct {
+	Name     ndn.Name             `json:"name" gqldesc:"Entry name."`
+	Counters fibdef.EntryCounters `json:"counters" gqldesc:"Entry counters."`
+}
+
 func init() {
+	GqlNexthopCountersType = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "FibNexthopCounters",
+		Fields: gqlserver.BindFields(fibdef.NexthopCounters{}, nil),
+	})
 	GqlEntryCountersType = graphql.NewObject(graphql.ObjectConfig{
-		Name:   "FibEntryCounters",
-		Fields: gqlserver.BindFields(fibdef.EntryCounters{}, nil),
+		Name: "FibEntryCounters",
+		Fields: gqlserver.BindFields(fibdef.EntryCounters{}, gqlserver.FieldTypes{
+			reflect.TypeOf(fibdef.NexthopCounters{}): GqlNexthopCountersType,
+		}),
+	})
+
+	GqlEntryCountersDiffType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "FibEntryCountersDiff",
+		Fields: gqlserver.BindFields(entryCountersDiff{}, gqlserver.FieldTypes{
+			reflect.TypeOf(ndn.Name{}):             graphql.NewNonNull(ndni.GqlNameType),
+			reflect.TypeOf(fibdef.EntryCounters{}): GqlEntryCountersType,
+		}),
 	})
 
 	GqlEntryNodeType = gqlserver.NewNodeType(Entry{})
@@ -83,6 +113,18 @@ func init() {
 					return list, nil
 				},
 			},
+			"learnedNexthops": &graphql.Field{
+				Description: "FIB nexthops added by strategy learning. null indicates a deleted face.",
+				Type:        gqlserver.NewNonNullList(iface.GqlFaceType, true),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(Entry)
+					var list []iface.Face
+					for _, nh := range entry.LearnedNexthops() {
+						list = append(list, iface.Get(nh))
+					}
+					return list, nil
+				},
+			},
 			"strategy": &graphql.Field{
 				Description: "Forwarding strategy. null indicates a deleted strategy.",
 				Type:        strategycode.GqlStrategyType,
@@ -91,6 +133,22 @@ func init() {
 					return strategycode.Get(entry.Strategy), nil
 				},
 			},
+			"strategyInherited": &graphql.Field{
+				Description: "Whether the strategy is inherited from strategy choice table.",
+				Type:        gqlserver.NonNullBoolean,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(Entry)
+					return entry.StrategyInherited(), nil
+				},
+			},
+			"params": &graphql.Field{
+				Description: "Strategy parameters.",
+				Type:        gqlserver.JSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					entry := p.Source.(Entry)
+					return entry.Params, nil
+				},
+			},
 			"counters": &graphql.Field{
 				Description: "Entry counters.",
 				Type:        graphql.NewNonNull(GqlEntryCountersType),
@@ -130,6 +188,77 @@ func init() {
 		},
 	})
 
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "fibCounters",
+		Description: "Obtain FIB entry counters, as differences since the previous update.",
+		Args: graphql.FieldConfigArgument{
+			"names": &graphql.ArgumentConfig{
+				Description: "Entry names.",
+				Type:        gqlserver.NewNonNullList(ndni.GqlNameType),
+			},
+			"interval": &graphql.ArgumentConfig{
+				Description: "Interval between updates.",
+				Type:        nnduration.GqlNanoseconds,
+			},
+		},
+		Type: gqlserver.NewNonNullList(GqlEntryCountersDiffType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Info.RootValue.([]entryCountersDiff), nil
+		},
+	}, func(ctx context.Context, sub *graphqlws.Subscription, updates chan<- interface{}) {
+		defer close(updates)
+		if GqlFib == nil {
+			return
+		}
+
+		names := []ndn.Name{}
+		for _, item := range gqlsub.GetListArg(sub, "names", ndni.GqlNameType) {
+			name, ok := item.(ndn.Name)
+			if !ok {
+				return
+			}
+			names = append(names, name)
+		}
+
+		interval, ok := gqlsub.GetArg(sub, "interval", nnduration.GqlNanoseconds).(nnduration.Nanoseconds)
+		if !ok {
+			return
+		}
+
+		readCounters := func() (m map[string]fibdef.EntryCounters) {
+			m = map[string]fibdef.EntryCounters{}
+			for _, name := range names {
+				if entry := GqlFib.Find(name); entry != nil {
+					m[name.String()] = entry.Counters()
+				}
+			}
+			return m
+		}
+
+		prev := readCounters()
+		ticker := time.NewTicker(interval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				cnt := readCounters()
+				update := []entryCountersDiff{}
+				for _, name := range names {
+					if c, ok := cnt[name.String()]; ok {
+						update = append(update, entryCountersDiff{
+							Name:     name,
+							Counters: c.Since(prev[name.String()]),
+						})
+					}
+				}
+				updates <- update
+				prev = cnt
+			}
+		}
+	})
+
 	iface.GqlFaceType.AddFieldConfig("fibEntries", &graphql.Field{
 		Description: "FIB entries having this face as nexthop.",
 		Type:        graphql.NewList(graphql.NewNonNull(GqlEntryType)),
@@ -157,6 +286,16 @@ func init() {
 		},
 	})
 
+	deleteStrategy := strategycode.GqlStrategyNodeType.Delete
+	strategycode.GqlStrategyNodeType.Delete = func(source interface{}) error {
+		if GqlFib != nil {
+			if e := GqlFib.checkStrategyUnload(source.(*strategycode.Strategy)); e != nil {
+				return e
+			}
+		}
+		return deleteStrategy(source)
+	}
+
 	strategycode.GqlStrategyType.AddFieldConfig("fibEntries", &graphql.Field{
 		Description: "FIB entries using this strategy.",
 		Type:        graphql.NewList(graphql.NewNonNull(GqlEntryType)),
@@ -193,6 +332,10 @@ func init() {
 				Description: "Forwarding strategy.",
 				Type:        graphql.ID,
 			},
+			"params": &graphql.ArgumentConfig{
+				Description: "Strategy parameters, which must conform to the schema declared by the strategy. Not allowed if strategy is inherited from strategy choice table.",
+				Type:        gqlserver.JSON,
+			},
 		},
 		Type: graphql.NewNonNull(GqlEntryType),
 		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
@@ -216,14 +359,197 @@ func init() {
 					return nil, fmt.Errorf("strategy not found: %w", e)
 				}
 				entry.Strategy = sc.ID()
-			} else if GqlDefaultStrategy != nil {
+			} else if GqlDefaultStrategy != nil && GqlFib.LpmStrategyChoice(entry.Name) == nil {
 				entry.Strategy = GqlDefaultStrategy.ID()
 			}
 
+			if params, ok := p.Args["params"]; ok && params != nil {
+				if entry.Params, ok = params.(map[string]interface{}); !ok {
+					return nil, errors.New("params must be an object")
+				}
+			}
+
 			if e := GqlFib.Insert(entry); e != nil {
 				return nil, e
 			}
 			return *GqlFib.Find(entry.Name), nil
 		},
 	})
+
+	GqlStrategyChoiceNodeType = gqlserver.NewNodeType(fibdef.StrategyChoice{})
+	GqlStrategyChoiceNodeType.GetID = func(source interface{}) string {
+		choice := source.(fibdef.StrategyChoice)
+		return choice.Name.String()
+	}
+	GqlStrategyChoiceNodeType.Retrieve = func(id string) (interface{}, error) {
+		if GqlFib == nil {
+			return nil, errNoGqlFib
+		}
+		choice := GqlFib.FindStrategyChoice(ndn.ParseName(id))
+		if choice == nil {
+			return nil, nil
+		}
+		return *choice, nil
+	}
+	GqlStrategyChoiceNodeType.Delete = func(source interface{}) error {
+		if GqlFib == nil {
+			return errNoGqlFib
+		}
+		choice := source.(fibdef.StrategyChoice)
+		return GqlFib.UnsetStrategy(choice.Name)
+	}
+
+	GqlStrategyChoiceType = graphql.NewObject(GqlStrategyChoiceNodeType.Annotate(graphql.ObjectConfig{
+		Name: "StrategyChoice",
+		Fields: graphql.Fields{
+			"name": &graphql.Field{
+				Description: "Name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					choice := p.Source.(fibdef.StrategyChoice)
+					return choice.Name, nil
+				},
+			},
+			"strategy": &graphql.Field{
+				Description: "Forwarding strategy. null indicates a deleted strategy.",
+				Type:        strategycode.GqlStrategyType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					choice := p.Source.(fibdef.StrategyChoice)
+					return strategycode.Get(choice.Strategy), nil
+				},
+			},
+			"params": &graphql.Field{
+				Description: "Strategy parameters.",
+				Type:        gqlserver.JSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					choice := p.Source.(fibdef.StrategyChoice)
+					return choice.Params, nil
+				},
+			},
+		},
+	}))
+	GqlStrategyChoiceNodeType.Register(GqlStrategyChoiceType)
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "strategyChoices",
+		Description: "List of strategy choice table entries.",
+		Type:        gqlserver.NewNonNullList(GqlStrategyChoiceType),
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Type:        ndni.GqlNameType,
+				Description: "Filter by longest prefix match.",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlFib == nil {
+				return nil, errNoGqlFib
+			}
+
+			if name, ok := p.Args["name"].(ndn.Name); ok {
+				var list []fibdef.StrategyChoice
+				if choice := GqlFib.LpmStrategyChoice(name); choice != nil {
+					list = append(list, *choice)
+				}
+				return list, nil
+			}
+
+			return GqlFib.ListStrategyChoices(), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name:        "setStrategy",
+		Description: "Insert or replace a strategy choice table entry.",
+		Args: graphql.FieldConfigArgument{
+			"name": &graphql.ArgumentConfig{
+				Description: "Name prefix.",
+				Type:        graphql.NewNonNull(ndni.GqlNameType),
+			},
+			"strategy": &graphql.ArgumentConfig{
+				Description: "Forwarding strategy.",
+				Type:        gqlserver.NonNullID,
+			},
+			"params": &graphql.ArgumentConfig{
+				Description: "Strategy parameters, which must conform to the schema declared by the strategy.",
+				Type:        gqlserver.JSON,
+			},
+		},
+		Type: graphql.NewNonNull(GqlStrategyChoiceType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlFib == nil {
+				return nil, errNoGqlFib
+			}
+
+			var choice fibdef.StrategyChoice
+			choice.Name = p.Args["name"].(ndn.Name)
+
+			var sc *strategycode.Strategy
+			if e := gqlserver.RetrieveNodeOfType(strategycode.GqlStrategyNodeType, p.Args["strategy"], &sc); e != nil {
+				return nil, fmt.Errorf("strategy not found: %w", e)
+			}
+			choice.Strategy = sc.ID()
+
+			if params, ok := p.Args["params"]; ok && params != nil {
+				if choice.Params, ok = params.(map[string]interface{}); !ok {
+					return nil, errors.New("params must be an object")
+				}
+			}
+
+			if e := GqlFib.SetStrategy(choice); e != nil {
+				return nil, e
+			}
+			return *GqlFib.FindStrategyChoice(choice.Name), nil
+		},
+	})
+
+	gqlserver.AddMutation(&graphql.Field{
+		Name: "replaceStrategy",
+		Description: "Replace the program of a strategy with a new ELF program. " +
+			"FIB entries and strategy choice table entries using the strategy switch to the new program at once, " +
+			"and then the old program is unloaded. The strategy keeps its ID.",
+		Args: graphql.FieldConfigArgument{
+			"strategy": &graphql.ArgumentConfig{
+				Description: "Forwarding strategy.",
+				Type:        gqlserver.NonNullID,
+			},
+			"name": &graphql.ArgumentConfig{
+				Description: "New short name of strategy. Default is keeping the short name.",
+				Type:        graphql.String,
+			},
+			"elf": &graphql.ArgumentConfig{
+				Description: "ELF program in base64 format.",
+				Type:        graphql.NewNonNull(gqlserver.Bytes),
+			},
+			"keepScratch": &graphql.ArgumentConfig{
+				Description: "Keep strategy scratch areas in FIB entries. Default is resetting them.",
+				Type:        graphql.Boolean,
+			},
+		},
+		Type: graphql.NewNonNull(strategycode.GqlStrategyType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlFib == nil {
+				return nil, errNoGqlFib
+			}
+
+			var oldSc *strategycode.Strategy
+			if e := gqlserver.RetrieveNodeOfType(strategycode.GqlStrategyNodeType, p.Args["strategy"], &oldSc); e != nil {
+				return nil, fmt.Errorf("strategy not found: %w", e)
+			}
+			name, ok := p.Args["name"].(string)
+			if !ok {
+				name = oldSc.Name()
+			}
+			keepScratch, _ := p.Args["keepScratch"].(bool)
+
+			newSc, e := strategycode.Load(name, p.Args["elf"].([]byte))
+			if e != nil {
+				return nil, e
+			}
+			if e := GqlFib.ReplaceStrategy(oldSc, newSc, keepScratch); e != nil {
+				newSc.Close()
+				return nil, e
+			}
+			return oldSc, nil
+		},
+	})
 }
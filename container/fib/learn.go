@@ -0,0 +1,189 @@
+package fib
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"go.uber.org/multierr"
+)
+
+var (
+	errNoEntry      = errors.New("FIB entry not found")
+	errNexthopsFull = fmt.Errorf("FIB entry cannot have more than %d nexthops", fibdef.MaxNexthops)
+	errLastNexthop  = errors.New("cannot remove the only nexthop")
+	errBadLifetime  = errors.New("lifetime must be positive")
+)
+
+// learnedNexthops contains learned nexthops of a FIB entry.
+type learnedNexthops struct {
+	name   ndn.Name
+	expiry map[iface.ID]time.Time
+}
+
+// LearnedNexthop describes a nexthop added by a strategy.
+type LearnedNexthop struct {
+	Name    ndn.Name  `json:"name"`
+	Nexthop iface.ID  `json:"nexthop"`
+	Expiry  time.Time `json:"expiry"`
+}
+
+// LearnNexthop adds a learned nexthop to a FIB entry, or extends the lifetime of a learned nexthop.
+// The FIB entry must exist. The learned nexthop is appended after existing nexthops, and is
+// removed when ExpireLearnedNexthops is called after its lifetime.
+// If nh is already a nexthop that was not learned, this has no effect.
+//
+// The FIB entry keeps its counters and strategy scratch areas, so that the strategy can keep
+// track of its learned nexthops.
+func (fib *Fib) LearnNexthop(name ndn.Name, nh iface.ID, lifetime time.Duration) (e error) {
+	if lifetime <= 0 {
+		return errBadLifetime
+	}
+	eal.CallMain(func() {
+		e = fib.doLearnNexthop(name, nh, time.Now().Add(lifetime))
+	})
+	return e
+}
+
+func (fib *Fib) doLearnNexthop(name ndn.Name, nh iface.ID, expiry time.Time) error {
+	entry := fib.tree.Find(name)
+	if entry == nil {
+		return errNoEntry
+	}
+
+	key := name.String()
+	learned := fib.learned[key]
+	if learned != nil {
+		if _, ok := learned.expiry[nh]; ok {
+			learned.expiry[nh] = expiry
+			return nil
+		}
+	}
+	for _, existing := range entry.Nexthops {
+		if existing == nh {
+			return nil
+		}
+	}
+	if len(entry.Nexthops) >= fibdef.MaxNexthops {
+		return errNexthopsFull
+	}
+
+	entry.Nexthops = append(append([]iface.ID{}, entry.Nexthops...), nh)
+	if e := fib.doReplaceNexthops(*entry); e != nil {
+		return e
+	}
+	if learned == nil {
+		learned = &learnedNexthops{
+			name:   name,
+			expiry: map[iface.ID]time.Time{},
+		}
+		fib.learned[key] = learned
+	}
+	learned.expiry[nh] = expiry
+	return nil
+}
+
+// ForgetNexthop removes a learned nexthop from a FIB entry.
+// If nh is not a learned nexthop, this has no effect.
+// If nh is the only nexthop of the FIB entry, it stays in the FIB entry as a regular nexthop, and
+// an error is returned; either way, nh is no longer tracked as a learned nexthop.
+func (fib *Fib) ForgetNexthop(name ndn.Name, nh iface.ID) (e error) {
+	eal.CallMain(func() {
+		e = fib.doForgetNexthop(name, nh)
+	})
+	return e
+}
+
+func (fib *Fib) doForgetNexthop(name ndn.Name, nh iface.ID) error {
+	key := name.String()
+	learned := fib.learned[key]
+	if learned == nil {
+		return nil
+	}
+	if _, ok := learned.expiry[nh]; !ok {
+		return nil
+	}
+	// learned record is removed before updating the FIB entry, so that it does not go stale if nh
+	// cannot be removed from the FIB entry
+	fib.deleteLearned(key, learned, nh)
+
+	entry := fib.tree.Find(name)
+	if entry == nil {
+		return nil
+	}
+	nexthops := make([]iface.ID, 0, len(entry.Nexthops))
+	for _, existing := range entry.Nexthops {
+		if existing != nh {
+			nexthops = append(nexthops, existing)
+		}
+	}
+	switch len(nexthops) {
+	case len(entry.Nexthops):
+		return nil
+	case 0:
+		return errLastNexthop
+	}
+
+	entry.Nexthops = nexthops
+	return fib.doReplaceNexthops(*entry)
+}
+
+// deleteLearned removes a learned nexthop record, and deletes the entry record if it becomes empty.
+func (fib *Fib) deleteLearned(key string, learned *learnedNexthops, nh iface.ID) {
+	delete(learned.expiry, nh)
+	if len(learned.expiry) == 0 {
+		delete(fib.learned, key)
+	}
+}
+
+// ExpireLearnedNexthops removes learned nexthops whose lifetime has expired.
+func (fib *Fib) ExpireLearnedNexthops(now time.Time) (e error) {
+	eal.CallMain(func() {
+		var errs []error
+		for _, ln := range fib.doListLearnedNexthops() {
+			if ln.Expiry.After(now) {
+				continue
+			}
+			if e := fib.doForgetNexthop(ln.Name, ln.Nexthop); e != nil {
+				errs = append(errs, fmt.Errorf("FIB entry %s nexthop %d: %w", ln.Name, ln.Nexthop, e))
+			}
+		}
+		e = multierr.Combine(errs...)
+	})
+	return e
+}
+
+// ListLearnedNexthops lists learned nexthops.
+func (fib *Fib) ListLearnedNexthops() (list []LearnedNexthop) {
+	eal.CallMain(func() {
+		list = fib.doListLearnedNexthops()
+	})
+	return list
+}
+
+func (fib *Fib) doListLearnedNexthops() (list []LearnedNexthop) {
+	for _, learned := range fib.learned {
+		for nh, expiry := range learned.expiry {
+			list = append(list, LearnedNexthop{
+				Name:    learned.name,
+				Nexthop: nh,
+				Expiry:  expiry,
+			})
+		}
+	}
+	return list
+}
+
+// doReplaceNexthops updates nexthops of a FIB entry, keeping its counters and scratch areas.
+func (fib *Fib) doReplaceNexthops(entry fibdef.Entry) error {
+	if e := entry.Validate(); e != nil {
+		return fmt.Errorf("entry.Validate: %w", e)
+	}
+	tu := fib.tree.Insert(entry)
+	tu.Real().KeepCounters, tu.Real().KeepScratch = true, true
+	return fib.doUpdate(tu)
+}
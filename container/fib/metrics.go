@@ -0,0 +1,27 @@
+package fib
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+)
+
+func init() {
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		if GqlFib == nil {
+			return
+		}
+		for _, name := range GqlFib.metrics {
+			entry := GqlFib.Find(name)
+			if entry == nil {
+				continue
+			}
+			labels := openmetrics.Labels{"name": name.String()}
+			cnt := entry.Counters()
+			s.AddStruct("ndndpdk_fib_", labels, cnt)
+			for _, nh := range cnt.Nexthops {
+				s.AddStruct("ndndpdk_fib_nexthop_", labels.With("nexthop", strconv.Itoa(int(nh.Nexthop))), nh)
+			}
+		}
+	})
+}
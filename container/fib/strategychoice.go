@@ -0,0 +1,133 @@
+package fib
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"go.uber.org/multierr"
+)
+
+var (
+	errNoStrategyChoice = errors.New("strategy choice not found")
+	errInheritParams    = errors.New("strategy parameters cannot be specified without strategy")
+)
+
+// checkStrategyUnload returns an error if a strategy choice table entry references the strategy.
+// Strategy choices refer to strategies by ID, so that unloading such a strategy would prevent
+// FIB entries from inheriting it.
+func (fib *Fib) checkStrategyUnload(sc *strategycode.Strategy) error {
+	id := sc.ID()
+	for _, choice := range fib.ListStrategyChoices() {
+		if choice.Strategy == id {
+			return fmt.Errorf("strategy %d is used by strategy choice %s", id, choice.Name)
+		}
+	}
+	return nil
+}
+
+func checkStrategy(id int, params map[string]interface{}) error {
+	sc := strategycode.Get(id)
+	if sc == nil {
+		return fmt.Errorf("strategy %d not found", id)
+	}
+	if _, e := sc.EncodeParams(params); e != nil {
+		return fmt.Errorf("strategy.EncodeParams: %w", e)
+	}
+	return nil
+}
+
+// ListStrategyChoices lists strategy choice table entries.
+func (fib *Fib) ListStrategyChoices() (list []fibdef.StrategyChoice) {
+	fib.choicesLock.RLock()
+	defer fib.choicesLock.RUnlock()
+	for _, choice := range fib.choices {
+		list = append(list, choice)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name.Compare(list[j].Name) < 0 })
+	return list
+}
+
+// FindStrategyChoice retrieves a strategy choice table entry by exact match.
+func (fib *Fib) FindStrategyChoice(name ndn.Name) *fibdef.StrategyChoice {
+	fib.choicesLock.RLock()
+	defer fib.choicesLock.RUnlock()
+	if choice, ok := fib.choices[name.String()]; ok {
+		return &choice
+	}
+	return nil
+}
+
+// LpmStrategyChoice retrieves a strategy choice table entry by longest prefix match.
+func (fib *Fib) LpmStrategyChoice(name ndn.Name) *fibdef.StrategyChoice {
+	fib.choicesLock.RLock()
+	defer fib.choicesLock.RUnlock()
+	for i := len(name); i >= 0; i-- {
+		if choice, ok := fib.choices[name.GetPrefix(i).String()]; ok {
+			return &choice
+		}
+	}
+	return nil
+}
+
+// SetStrategy inserts or replaces a strategy choice table entry.
+// FIB entries at or below its name that inherit strategy from the strategy choice table are
+// updated accordingly.
+// While the entry exists, the strategy cannot be unloaded via GraphQL.
+func (fib *Fib) SetStrategy(choice fibdef.StrategyChoice) (e error) {
+	if e := choice.Validate(); e != nil {
+		return fmt.Errorf("choice.Validate: %w", e)
+	}
+	if e := checkStrategy(choice.Strategy, choice.Params); e != nil {
+		return e
+	}
+
+	eal.CallMain(func() {
+		fib.choicesLock.Lock()
+		fib.choices[choice.Name.String()] = choice
+		fib.choicesLock.Unlock()
+		e = fib.reapplyStrategyChoices(choice.Name)
+	})
+	return e
+}
+
+// UnsetStrategy deletes a strategy choice table entry.
+// FIB entries at or below its name that inherit strategy from the strategy choice table are
+// updated to use the strategy from a shorter prefix.
+// If there is no such strategy, those FIB entries retain their current strategy, and an error is
+// returned.
+func (fib *Fib) UnsetStrategy(name ndn.Name) (e error) {
+	eal.CallMain(func() {
+		fib.choicesLock.Lock()
+		key := name.String()
+		_, ok := fib.choices[key]
+		delete(fib.choices, key)
+		fib.choicesLock.Unlock()
+
+		if !ok {
+			e = errNoStrategyChoice
+			return
+		}
+		e = fib.reapplyStrategyChoices(name)
+	})
+	return e
+}
+
+// reapplyStrategyChoices updates FIB entries under prefix that inherit strategy from strategy choice table.
+func (fib *Fib) reapplyStrategyChoices(prefix ndn.Name) error {
+	var errs []error
+	for _, entry := range fib.tree.List() {
+		if !fib.inherit[entry.Name.String()] || !prefix.IsPrefixOf(entry.Name) {
+			continue
+		}
+		entry.Strategy, entry.Params = 0, nil
+		if e := fib.doInsert(entry); e != nil {
+			errs = append(errs, fmt.Errorf("FIB entry %s: %w", entry.Name, e))
+		}
+	}
+	return multierr.Combine(errs...)
+}
@@ -0,0 +1,85 @@
+package fib
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+var errParamsEncoding = errors.New("strategy parameters would be encoded differently by new strategy")
+
+// ReplaceStrategy replaces the program of sc with newSc, such as a freshly loaded ELF program.
+// FIB entries and strategy choice table entries keep referencing sc, along with their nexthops,
+// strategy parameters, and counters; newSc is closed afterwards.
+// If keepScratch is true, strategy scratch areas are kept; otherwise, they are reset.
+//
+// Strategy parameters of every affected entry are validated against the schema of newSc before
+// any change is made, and must encode to the same slot values as before.
+// The program is swapped via RCU, so that forwarding threads see either the old or the new
+// program, and all FIB entries switch at once without being re-inserted.
+func (fib *Fib) ReplaceStrategy(sc, newSc *strategycode.Strategy, keepScratch bool) (e error) {
+	eal.CallMain(func() {
+		e = fib.doReplaceStrategy(sc, newSc, keepScratch)
+	})
+	return e
+}
+
+func (fib *Fib) doReplaceStrategy(sc, newSc *strategycode.Strategy, keepScratch bool) error {
+	id := sc.ID()
+	for _, entry := range fib.tree.List() {
+		if entry.Strategy != id {
+			continue
+		}
+		if e := checkReplaceParams(sc, newSc, entry.Params); e != nil {
+			return fmt.Errorf("FIB entry %s: %w", entry.Name, e)
+		}
+	}
+
+	for _, choice := range fib.ListStrategyChoices() {
+		if choice.Strategy != id {
+			continue
+		}
+		if e := checkStrategy(newSc.ID(), choice.Params); e != nil {
+			return fmt.Errorf("strategy choice %s: %w", choice.Name, e)
+		}
+	}
+
+	return sc.Replace(newSc, !keepScratch)
+}
+
+// checkReplaceParams ensures FIB entry strategy parameters encode to the same slot values under
+// the new schema, because slot values in existing FIB entries are not re-encoded.
+func checkReplaceParams(sc, newSc *strategycode.Strategy, params map[string]interface{}) error {
+	oldValues, _ := sc.EncodeParams(params)
+	newValues, e := newSc.EncodeParams(params)
+	if e != nil {
+		return fmt.Errorf("strategy.EncodeParams: %w", e)
+	}
+	if len(oldValues) != len(newValues) {
+		return errParamsEncoding
+	}
+	for i, v := range oldValues {
+		if newValues[i] != v {
+			return errParamsEncoding
+		}
+	}
+	return nil
+}
+
+// UsesStrategy determines whether any FIB entry or strategy choice table entry uses a strategy.
+func (fib *Fib) UsesStrategy(sc *strategycode.Strategy) bool {
+	id := sc.ID()
+	for _, entry := range fib.tree.List() {
+		if entry.Strategy == id {
+			return true
+		}
+	}
+	for _, choice := range fib.ListStrategyChoices() {
+		if choice.Strategy == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,303 @@
+package ndt
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/logging"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"go.uber.org/zap"
+)
+
+var logger = logging.New("ndt")
+
+// Balancer defaults.
+const (
+	DefaultBalancerHighLoad    = 0.8
+	DefaultBalancerMinLoadDiff = 0.2
+	DefaultBalancerMaxMoves    = 4
+	DefaultBalancerHoldDown    = 30000
+
+	balancerLogCapacity = 64
+)
+
+// BalancerConfig contains NDT load balancer configuration.
+type BalancerConfig struct {
+	// Interval is the duration between balancing rounds.
+	//
+	// If this value is zero, the load balancer is disabled.
+	Interval nnduration.Milliseconds `json:"interval,omitempty" gqldesc:"Duration between balancing rounds in milliseconds."`
+
+	// HighLoad is the busy ratio above which a forwarding thread is considered overloaded.
+	// Busy ratio is the fraction of polls that processed non-zero items.
+	//
+	// If this value is zero, it defaults to DefaultBalancerHighLoad.
+	HighLoad float64 `json:"highLoad,omitempty" gqldesc:"Busy ratio above which a forwarding thread is overloaded."`
+
+	// MinLoadDiff is the minimum busy ratio difference between the most loaded and the least
+	// loaded forwarding threads, for the balancer to move NDT entries between them.
+	//
+	// If this value is zero, it defaults to DefaultBalancerMinLoadDiff.
+	MinLoadDiff float64 `json:"minLoadDiff,omitempty" gqldesc:"Minimum busy ratio difference to trigger rebalancing."`
+
+	// MaxMoves is the maximum number of NDT entries changed in each round.
+	//
+	// If this value is zero, it defaults to DefaultBalancerMaxMoves.
+	MaxMoves int `json:"maxMoves,omitempty" gqldesc:"Maximum number of NDT entries changed in each round."`
+
+	// HoldDown is the minimum duration before a changed NDT entry can be changed again.
+	//
+	// If this value is zero, it defaults to DefaultBalancerHoldDown.
+	HoldDown nnduration.Milliseconds `json:"holdDown,omitempty" gqldesc:"Minimum duration before a changed NDT entry can be changed again, in milliseconds."`
+}
+
+func (c *BalancerConfig) applyDefaults() {
+	if c.HighLoad <= 0 {
+		c.HighLoad = DefaultBalancerHighLoad
+	}
+	if c.MinLoadDiff <= 0 {
+		c.MinLoadDiff = DefaultBalancerMinLoadDiff
+	}
+	if c.MaxMoves <= 0 {
+		c.MaxMoves = DefaultBalancerMaxMoves
+	}
+	if c.HoldDown == 0 {
+		c.HoldDown = DefaultBalancerHoldDown
+	}
+}
+
+// holdDownRounds returns HoldDown expressed in number of rounds.
+func (c BalancerConfig) holdDownRounds() int {
+	if c.Interval == 0 {
+		return 1
+	}
+	return int((c.HoldDown + c.Interval - 1) / c.Interval)
+}
+
+// LoadReader provides workload statistics of a forwarding thread.
+type LoadReader interface {
+	ThreadLoadStat() ealthread.LoadStat
+}
+
+// BalancerDecision records an NDT entry change made by the load balancer.
+type BalancerDecision struct {
+	Round    int     `json:"round" gqldesc:"Balancing round number."`
+	Index    int     `json:"index" gqldesc:"NDT entry index."`
+	From     int     `json:"from" gqldesc:"Old entry value, i.e. forwarding thread index."`
+	To       int     `json:"to" gqldesc:"New entry value, i.e. forwarding thread index."`
+	Hits     uint32  `json:"hits" gqldesc:"Sampled hits of the entry during the round."`
+	FromLoad float64 `json:"fromLoad" gqldesc:"Busy ratio of the old forwarding thread."`
+	ToLoad   float64 `json:"toLoad" gqldesc:"Busy ratio of the new forwarding thread."`
+}
+
+// BalancerInfo contains load balancer status.
+type BalancerInfo struct {
+	NRounds   int                `json:"nRounds" gqldesc:"Number of completed balancing rounds."`
+	NMoves    int                `json:"nMoves" gqldesc:"Number of NDT entry changes."`
+	Loads     []float64          `json:"loads" gqldesc:"Busy ratio of each forwarding thread in the last round."`
+	Decisions []BalancerDecision `json:"decisions" gqldesc:"Recent decisions, oldest first."`
+}
+
+// Balancer is an NDT load balancer.
+//
+// It periodically reads NDT hit counters and forwarding thread workload statistics,
+// and changes hot NDT entries from the most loaded forwarding thread to the least loaded one.
+type Balancer struct {
+	cfg   BalancerConfig
+	ndt   *Ndt
+	loads []LoadReader
+
+	mutex     sync.Mutex
+	prevLoads []ealthread.LoadStat
+	prevHits  []uint32
+	heldUntil map[int]int
+	info      BalancerInfo
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Config returns effective configuration.
+func (b *Balancer) Config() BalancerConfig {
+	return b.cfg
+}
+
+// Info returns load balancer status.
+func (b *Balancer) Info() (info BalancerInfo) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	info = b.info
+	info.Loads = append([]float64{}, b.info.Loads...)
+	info.Decisions = append([]BalancerDecision{}, b.info.Decisions...)
+	return info
+}
+
+// Start launches a goroutine that invokes Step periodically.
+func (b *Balancer) Start() {
+	if b.stop != nil || b.cfg.Interval == 0 {
+		return
+	}
+	b.stop, b.done = make(chan struct{}), make(chan struct{})
+	go b.run(b.stop, b.done)
+}
+
+func (b *Balancer) run(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(b.cfg.Interval.Duration())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			b.Step()
+		}
+	}
+}
+
+// Close stops the load balancer.
+// NDT entries are left as is.
+func (b *Balancer) Close() error {
+	if b.stop != nil {
+		close(b.stop)
+		<-b.done
+		b.stop, b.done = nil, nil
+	}
+	return nil
+}
+
+// Step performs one balancing round, and returns changed NDT entries.
+//
+// The first round only collects baseline counters, and does not change any NDT entry.
+func (b *Balancer) Step() (decisions []BalancerDecision) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	loadStats := make([]ealthread.LoadStat, len(b.loads))
+	loads := make([]float64, len(b.loads))
+	for i, lr := range b.loads {
+		loadStats[i] = lr.ThreadLoadStat()
+		if b.prevLoads != nil {
+			diff := loadStats[i].Sub(b.prevLoads[i])
+			if nPolls := diff.EmptyPolls + diff.ValidPolls; nPolls > 0 {
+				loads[i] = float64(diff.ValidPolls) / float64(nPolls)
+			}
+		}
+	}
+
+	entries := b.ndt.List()
+	hits := make([]uint32, len(entries))
+	for i, entry := range entries {
+		hits[i] = entry.Hits
+	}
+
+	if b.prevLoads != nil {
+		for i, entry := range entries {
+			entries[i].Hits = entry.Hits - b.prevHits[i] // uint32 wraparound is intended
+		}
+		decisions = b.decide(entries, loads)
+	}
+
+	b.prevLoads, b.prevHits = loadStats, hits
+	b.info.NRounds++
+	b.info.Loads = loads
+	return decisions
+}
+
+func (b *Balancer) decide(entries []Entry, loads []float64) (decisions []BalancerDecision) {
+	for index, until := range b.heldUntil {
+		if until <= b.info.NRounds {
+			delete(b.heldUntil, index)
+		}
+	}
+
+	if len(loads) < 2 {
+		return nil
+	}
+
+	src, dst := 0, 0
+	for i, load := range loads {
+		if load > loads[src] {
+			src = i
+		}
+		if load < loads[dst] {
+			dst = i
+		}
+	}
+	if loads[src] < b.cfg.HighLoad || loads[src]-loads[dst] < b.cfg.MinLoadDiff {
+		return nil
+	}
+
+	var srcHits uint64
+	candidates := []Entry{}
+	for _, entry := range entries {
+		if entry.Value != src || entry.Hits == 0 {
+			continue
+		}
+		srcHits += uint64(entry.Hits)
+		if b.heldUntil[entry.Index] > b.info.NRounds {
+			continue
+		}
+		candidates = append(candidates, entry)
+	}
+	if srcHits == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Hits > candidates[j].Hits })
+
+	// Assuming workload is proportional to hits, moving this many hits would equalize busy ratios.
+	// Entries hotter than the remaining budget are skipped, so that the hot spot is not merely shifted.
+	budget := float64(srcHits) * (loads[src] - loads[dst]) / (2 * loads[src])
+	for _, entry := range candidates {
+		if len(decisions) >= b.cfg.MaxMoves {
+			break
+		}
+		if float64(entry.Hits) > budget {
+			continue
+		}
+		budget -= float64(entry.Hits)
+
+		b.ndt.Update(uint64(entry.Index), uint8(dst))
+		b.heldUntil[entry.Index] = b.info.NRounds + b.cfg.holdDownRounds()
+		decision := BalancerDecision{
+			Round:    b.info.NRounds,
+			Index:    entry.Index,
+			From:     src,
+			To:       dst,
+			Hits:     entry.Hits,
+			FromLoad: loads[src],
+			ToLoad:   loads[dst],
+		}
+		decisions = append(decisions, decision)
+		logger.Info("NDT entry moved",
+			zap.Int("index", decision.Index),
+			zap.Int("from", decision.From),
+			zap.Int("to", decision.To),
+			zap.Uint32("hits", decision.Hits),
+			zap.Float64("from-load", decision.FromLoad),
+			zap.Float64("to-load", decision.ToLoad),
+		)
+	}
+
+	b.info.NMoves += len(decisions)
+	b.info.Decisions = append(b.info.Decisions, decisions...)
+	if n := len(b.info.Decisions); n > balancerLogCapacity {
+		b.info.Decisions = append([]BalancerDecision{}, b.info.Decisions[n-balancerLogCapacity:]...)
+	}
+	return decisions
+}
+
+// NewBalancer creates a Balancer.
+// loads[i] provides workload statistics of the forwarding thread whose index is i.
+// The balancer is not started until Start is invoked.
+func NewBalancer(ndt *Ndt, loads []LoadReader, cfg BalancerConfig) *Balancer {
+	cfg.applyDefaults()
+	return &Balancer{
+		cfg:       cfg,
+		ndt:       ndt,
+		loads:     loads,
+		heldUntil: map[int]int{},
+	}
+}
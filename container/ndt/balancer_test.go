@@ -0,0 +1,101 @@
+package ndt_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/ndt"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+type balancerTestLoad struct {
+	ealthread.LoadStat
+}
+
+func (l *balancerTestLoad) ThreadLoadStat() ealthread.LoadStat {
+	return l.LoadStat
+}
+
+func (l *balancerTestLoad) Add(validPolls, emptyPolls uint64) {
+	l.ValidPolls += validPolls
+	l.EmptyPolls += emptyPolls
+}
+
+func TestBalancer(t *testing.T) {
+	assert, require := makeAR(t)
+
+	table := ndt.New(ndt.Config{Capacity: 64}, make([]eal.NumaSocket, 1))
+	defer table.Close()
+	for i := 0; i < 64; i++ {
+		table.Update(uint64(i), 0)
+	}
+	ndq := table.Queriers()[0]
+
+	var names []ndn.Name
+	for i := 0; i < 256; i++ {
+		names = append(names, ndn.ParseName(fmt.Sprintf("/B/%d", i)))
+	}
+	lookupAll := func() {
+		for i, name := range names {
+			for j := 0; j < 1+i%8; j++ {
+				ndq.Lookup(name)
+			}
+		}
+	}
+
+	loads := []*balancerTestLoad{{}, {}, {}}
+	b := ndt.NewBalancer(table, []ndt.LoadReader{loads[0], loads[1], loads[2]}, ndt.BalancerConfig{
+		Interval: 1000,
+		MaxMoves: 6,
+		HoldDown: 5000,
+	})
+	defer b.Close()
+
+	// first round collects baseline only
+	lookupAll()
+	loads[0].Add(1000, 0)
+	assert.Empty(b.Step())
+
+	// FWD0 is overloaded, FWD2 is idle
+	lookupAll()
+	loads[0].Add(1000, 0)
+	loads[1].Add(500, 500)
+	loads[2].Add(100, 900)
+	decisions := b.Step()
+	require.NotEmpty(decisions)
+	assert.LessOrEqual(len(decisions), 6)
+	moved := map[int]bool{}
+	for _, d := range decisions {
+		assert.Equal(0, d.From)
+		assert.Equal(2, d.To)
+		assert.InDelta(1.0, d.FromLoad, 0.01)
+		assert.InDelta(0.1, d.ToLoad, 0.01)
+		assert.Equal(2, table.Get(uint64(d.Index)).Value)
+		moved[d.Index] = true
+	}
+
+	// moved entries are in hold-down
+	lookupAll()
+	loads[0].Add(1000, 0)
+	loads[2].Add(1000, 0)
+	loads[1].Add(0, 1000)
+	for _, d := range b.Step() {
+		assert.Equal(0, d.From)
+		assert.Equal(1, d.To)
+		assert.False(moved[d.Index])
+	}
+
+	// balanced
+	lookupAll()
+	for _, l := range loads {
+		l.Add(700, 300)
+	}
+	assert.Empty(b.Step())
+
+	info := b.Info()
+	assert.Equal(4, info.NRounds)
+	assert.Equal(len(info.Decisions), info.NMoves)
+	assert.Len(info.Loads, 3)
+}
@@ -2,9 +2,11 @@ package ndt
 
 import (
 	"errors"
+	"reflect"
 
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 )
@@ -13,6 +15,9 @@ var (
 	// GqlNdt is the NDT instance accessible via GraphQL.
 	GqlNdt *Ndt
 
+	// GqlBalancer is the NDT load balancer instance accessible via GraphQL.
+	GqlBalancer *Balancer
+
 	errNoGqlNdt = errors.New("NDT unavailable")
 	//lint:ignore ST1005 'Index' is a field name
 	errNoIndex = errors.New("Index is unspecified")
@@ -20,8 +25,11 @@ var (
 
 // GraphQL types.
 var (
-	GqlConfigType *graphql.Object
-	GqlEntryType  *graphql.Object
+	GqlConfigType           *graphql.Object
+	GqlEntryType            *graphql.Object
+	GqlBalancerConfigType   *graphql.Object
+	GqlBalancerDecisionType *graphql.Object
+	GqlBalancerType         *graphql.Object
 )
 
 func init() {
@@ -108,4 +116,42 @@ func init() {
 			return GqlNdt.Get(index), nil
 		},
 	})
+
+	GqlBalancerConfigType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "NdtBalancerConfig",
+		Fields: gqlserver.BindFields(BalancerConfig{}, gqlserver.FieldTypes{
+			reflect.TypeOf(nnduration.Milliseconds(0)): nnduration.GqlMilliseconds,
+		}),
+	})
+
+	GqlBalancerDecisionType = graphql.NewObject(graphql.ObjectConfig{
+		Name:   "NdtBalancerDecision",
+		Fields: gqlserver.BindFields(BalancerDecision{}, nil),
+	})
+
+	GqlBalancerType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "NdtBalancer",
+		Fields: gqlserver.BindFields(BalancerInfo{}, gqlserver.FieldTypes{
+			reflect.TypeOf(BalancerDecision{}): GqlBalancerDecisionType,
+		}),
+	})
+	GqlBalancerType.AddFieldConfig("config", &graphql.Field{
+		Description: "Load balancer configuration.",
+		Type:        graphql.NewNonNull(GqlBalancerConfigType),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return GqlBalancer.Config(), nil
+		},
+	})
+
+	gqlserver.AddQuery(&graphql.Field{
+		Name:        "ndtBalancer",
+		Description: "NDT load balancer status, null if load balancer is disabled.",
+		Type:        GqlBalancerType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			if GqlBalancer == nil {
+				return nil, nil
+			}
+			return GqlBalancer.Info(), nil
+		},
+	})
 }
@@ -21,6 +21,11 @@ type Config struct {
 	PcctCapacity       int `json:"pcctCapacity,omitempty"`
 	CsDirectCapacity   int `json:"csDirectCapacity,omitempty"`
 	CsIndirectCapacity int `json:"csIndirectCapacity,omitempty"`
+
+	// CsDiskCapacity is the number of DiskStore slots available to the CS.
+	// Zero means disk caching is disabled.
+	// This option takes effect only if the application assigns a DiskStore to the CS.
+	CsDiskCapacity int `json:"csDiskCapacity,omitempty"`
 }
 
 func (cfg *Config) applyDefaults() {
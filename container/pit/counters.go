@@ -9,7 +9,7 @@ import (
 
 // Counters contains PIT counters.
 type Counters struct {
-	NEntries  uint64 `json:"nEntries" gqldesc:"Current number of entries."`
+	NEntries  uint64 `json:"nEntries" gqldesc:"Current number of entries." openmetrics:"gauge"`
 	NInsert   uint64 `json:"nInsert" gqldesc:"Insertions that created a new PIT entry."`
 	NFound    uint64 `json:"nFound" gqldesc:"Insertions that found an existing PIT entry."`
 	NCsMatch  uint64 `json:"nCsMatch" gqldesc:"Insertions that matched a CS entry."`
@@ -28,6 +28,22 @@ func (cnt Counters) String() string {
 		cnt.NDataHit, cnt.NDataMiss, cnt.NNackHit, cnt.NNackMiss, cnt.NExpired)
 }
 
+// Since computes the difference between cnt and prev.
+// NEntries is taken from cnt.
+func (cnt Counters) Since(prev Counters) (diff Counters) {
+	diff.NEntries = cnt.NEntries
+	diff.NInsert = cnt.NInsert - prev.NInsert
+	diff.NFound = cnt.NFound - prev.NFound
+	diff.NCsMatch = cnt.NCsMatch - prev.NCsMatch
+	diff.NAllocErr = cnt.NAllocErr - prev.NAllocErr
+	diff.NDataHit = cnt.NDataHit - prev.NDataHit
+	diff.NDataMiss = cnt.NDataMiss - prev.NDataMiss
+	diff.NNackHit = cnt.NNackHit - prev.NNackHit
+	diff.NNackMiss = cnt.NNackMiss - prev.NNackMiss
+	diff.NExpired = cnt.NExpired - prev.NExpired
+	return diff
+}
+
 // Counters reads counters from this PIT.
 func (pit *Pit) Counters() (cnt Counters) {
 	cnt.NEntries = uint64(pit.nEntries)
@@ -160,3 +160,14 @@ func TestToken(t *testing.T) {
 	assert.EqualValues(len(records), cnt.NNackHit)
 	assert.EqualValues(len(records), cnt.NNackMiss)
 }
+
+func TestCountersSince(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	prev := pit.Counters{NEntries: 5, NInsert: 10, NFound: 2, NCsMatch: 3, NAllocErr: 1,
+		NDataHit: 4, NDataMiss: 1, NNackHit: 2, NNackMiss: 0, NExpired: 3}
+	cnt := pit.Counters{NEntries: 7, NInsert: 15, NFound: 4, NCsMatch: 3, NAllocErr: 1,
+		NDataHit: 6, NDataMiss: 2, NNackHit: 3, NNackMiss: 1, NExpired: 6}
+	assert.Equal(pit.Counters{NEntries: 7, NInsert: 5, NFound: 2, NCsMatch: 0, NAllocErr: 0,
+		NDataHit: 2, NDataMiss: 1, NNackHit: 1, NNackMiss: 1, NExpired: 3}, cnt.Since(prev))
+}
@@ -1,6 +1,7 @@
 package strategycode
 
 import (
+	"encoding/json"
 	"strconv"
 
 	"github.com/graphql-go/graphql"
@@ -46,6 +47,19 @@ func init() {
 					return strategy.Name(), nil
 				},
 			},
+			"schema": &graphql.Field{
+				Description: "Parameters schema. null indicates the strategy does not accept parameters.",
+				Type:        gqlserver.JSON,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					strategy := p.Source.(*Strategy)
+					if ps := strategy.Schema(); ps != nil {
+						var doc interface{}
+						e := json.Unmarshal(ps.doc, &doc)
+						return doc, e
+					}
+					return nil, nil
+				},
+			},
 		},
 	}))
 	GqlStrategyNodeType.Register(GqlStrategyType)
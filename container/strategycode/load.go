@@ -19,7 +19,7 @@ var (
 	NXsyms int
 )
 
-func makeStrategyCode(name string, bpf *C.struct_rte_bpf) (sc *Strategy, e error) {
+func makeStrategyCode(name string, bpf *C.struct_rte_bpf, schema *ParamsSchema) (sc *Strategy, e error) {
 	if bpf == nil {
 		return nil, eal.GetErrno()
 	}
@@ -40,9 +40,13 @@ func makeStrategyCode(name string, bpf *C.struct_rte_bpf) (sc *Strategy, e error
 	c.id = C.int(lastID)
 	c.name = C.CString(name)
 	c.nRefs = 1
-	c.bpf = bpf
-	c.jit = jit._func
+	c.prog = (*C.StrategyCodeProg)(eal.Zmalloc("StrategyProg", C.sizeof_StrategyCodeProg, eal.NumaSocket{}))
+	c.prog.bpf = bpf
+	c.prog.jit = jit._func
 	table[lastID] = sc
+	if schema != nil {
+		schemas[lastID] = schema
+	}
 	return sc, nil
 }
 
@@ -67,6 +71,13 @@ func Load(name string, elf []byte) (sc *Strategy, e error) {
 // LoadFile loads a strategy BPF program from ELF file.
 // If filename is empty, search for an ELF file in default locations.
 func LoadFile(name, filename string) (sc *Strategy, e error) {
+	return LoadFileXsyms(name, filename, Xsyms, NXsyms)
+}
+
+// LoadFileXsyms loads a strategy BPF program from ELF file, with a custom set of external symbols.
+// xsyms should be a C array of struct rte_bpf_xsym, which is used in place of Xsyms.
+// This is useful for running a strategy outside the forwarder, such as in sgtest package.
+func LoadFileXsyms(name, filename string, xsyms unsafe.Pointer, nXsyms int) (sc *Strategy, e error) {
 	if filename == "" {
 		filename, e = bpf.Strategy.Find(name)
 		if e != nil {
@@ -74,15 +85,20 @@ func LoadFile(name, filename string) (sc *Strategy, e error) {
 		}
 	}
 
+	schema, e := readParamsSchema(filename)
+	if e != nil {
+		return nil, e
+	}
+
 	var prm C.struct_rte_bpf_prm
-	prm.xsym = (*C.struct_rte_bpf_xsym)(Xsyms)
-	prm.nb_xsym = (C.uint32_t)(NXsyms)
+	prm.xsym = (*C.struct_rte_bpf_xsym)(xsyms)
+	prm.nb_xsym = (C.uint32_t)(nXsyms)
 	prm.prog_arg._type = C.RTE_BPF_ARG_RAW
 
 	filenameC := C.CString(filename)
 	defer C.free(unsafe.Pointer(filenameC))
 	bpf := C.rte_bpf_elf_load(&prm, filenameC, dotTextSection)
-	return makeStrategyCode(name, bpf)
+	return makeStrategyCode(name, bpf, schema)
 }
 
 // MakeEmpty creates an empty BPF program.
@@ -93,7 +109,7 @@ func MakeEmpty(name string) *Strategy {
 	prm.prog_arg._type = C.RTE_BPF_ARG_RAW
 
 	bpf := C.rte_bpf_load(&prm)
-	sc, e := makeStrategyCode(name, bpf)
+	sc, e := makeStrategyCode(name, bpf, nil)
 	if e != nil {
 		panic(e)
 	}
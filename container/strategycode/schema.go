@@ -0,0 +1,179 @@
+package strategycode
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/usnistgov/ndn-dpdk/container/fib/fibdef"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaSection is the ELF section that contains strategy parameters schema.
+// It is declared with SGPARAMS_SCHEMA macro in the strategy program.
+const SchemaSection = ".sgschema"
+
+var (
+	errNoParams   = errors.New("strategy does not accept parameters")
+	errSchemaType = errors.New("strategy parameters schema must be an object with properties")
+)
+
+// ParamsSchema describes strategy parameters.
+//
+// It is a JSON schema of an object.
+// Each property of the object must be an integer or a boolean, and is assigned to a numeric slot
+// in the order of appearance in the schema document.
+// If a property is absent, its "default" value is used, or zero if the schema has no default.
+type ParamsSchema struct {
+	doc      json.RawMessage
+	schema   *gojsonschema.Schema
+	keys     []string
+	defaults []int64
+}
+
+// MarshalJSON returns the JSON schema document.
+func (ps *ParamsSchema) MarshalJSON() ([]byte, error) {
+	return ps.doc, nil
+}
+
+// Keys returns parameter names in slot order.
+func (ps *ParamsSchema) Keys() []string {
+	return ps.keys
+}
+
+// Encode validates parameters and converts them to slot values.
+func (ps *ParamsSchema) Encode(params map[string]interface{}) (values []int64, e error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	res, e := ps.schema.Validate(gojsonschema.NewGoLoader(params))
+	if e != nil {
+		return nil, e
+	}
+	if !res.Valid() {
+		var msgs []string
+		for _, re := range res.Errors() {
+			msgs = append(msgs, re.String())
+		}
+		return nil, fmt.Errorf("invalid strategy parameters: %s", strings.Join(msgs, "; "))
+	}
+
+	values = make([]int64, len(ps.keys))
+	for i, key := range ps.keys {
+		v, ok := params[key]
+		if !ok {
+			values[i] = ps.defaults[i]
+			continue
+		}
+		if values[i], e = paramValue(v); e != nil {
+			return nil, fmt.Errorf("strategy parameter %s: %w", key, e)
+		}
+	}
+	return values, nil
+}
+
+// ParseParamsSchema parses strategy parameters schema from a JSON document.
+func ParseParamsSchema(doc []byte) (ps *ParamsSchema, e error) {
+	ps = &ParamsSchema{
+		doc: json.RawMessage(bytes.TrimRight(doc, "\x00")),
+	}
+	if ps.schema, e = gojsonschema.NewSchema(gojsonschema.NewBytesLoader(ps.doc)); e != nil {
+		return nil, e
+	}
+
+	var top struct {
+		Properties json.RawMessage `json:"properties"`
+	}
+	if e := json.Unmarshal(ps.doc, &top); e != nil {
+		return nil, e
+	}
+	if ps.keys, e = orderedKeys(top.Properties); e != nil {
+		return nil, e
+	}
+	if len(ps.keys) > fibdef.MaxParams {
+		return nil, fmt.Errorf("strategy parameters schema declares more than %d properties", fibdef.MaxParams)
+	}
+
+	var props map[string]struct {
+		Type    string      `json:"type"`
+		Default interface{} `json:"default"`
+	}
+	if e := json.Unmarshal(top.Properties, &props); e != nil {
+		return nil, e
+	}
+	ps.defaults = make([]int64, len(ps.keys))
+	for i, key := range ps.keys {
+		prop := props[key]
+		if prop.Type != "integer" && prop.Type != "boolean" {
+			return nil, fmt.Errorf("strategy parameter %s must have integer or boolean type", key)
+		}
+		if prop.Default != nil {
+			if ps.defaults[i], e = paramValue(prop.Default); e != nil {
+				return nil, fmt.Errorf("strategy parameter %s default: %w", key, e)
+			}
+		}
+	}
+	return ps, nil
+}
+
+// readParamsSchema reads strategy parameters schema from an ELF file.
+// Returns nil if the ELF file does not have a schema section.
+func readParamsSchema(filename string) (ps *ParamsSchema, e error) {
+	file, e := elf.Open(filename)
+	if e != nil {
+		return nil, e
+	}
+	defer file.Close()
+
+	section := file.Section(SchemaSection)
+	if section == nil {
+		return nil, nil
+	}
+	doc, e := section.Data()
+	if e != nil {
+		return nil, e
+	}
+	return ParseParamsSchema(doc)
+}
+
+func orderedKeys(obj json.RawMessage) (keys []string, e error) {
+	d := json.NewDecoder(bytes.NewReader(obj))
+	if token, e := d.Token(); e != nil || token != json.Delim('{') {
+		return nil, errSchemaType
+	}
+	for d.More() {
+		token, e := d.Token()
+		if e != nil {
+			return nil, e
+		}
+		keys = append(keys, token.(string))
+
+		var value json.RawMessage
+		if e := d.Decode(&value); e != nil {
+			return nil, e
+		}
+	}
+	return keys, nil
+}
+
+func paramValue(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case float64:
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("unsupported value type %T", v)
+}
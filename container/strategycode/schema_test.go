@@ -0,0 +1,51 @@
+package strategycode_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+)
+
+func TestParamsSchema(t *testing.T) {
+	assert, require := makeAR(t)
+
+	ps, e := strategycode.ParseParamsSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"z": { "type": "integer", "minimum": 1, "default": 20 },
+			"a": { "type": "boolean" },
+			"m": { "type": "integer" }
+		},
+		"additionalProperties": false
+	}` + "\x00"))
+	require.NoError(e)
+	assert.Equal([]string{"z", "a", "m"}, ps.Keys())
+
+	values, e := ps.Encode(nil)
+	assert.NoError(e)
+	assert.Equal([]int64{20, 0, 0}, values)
+
+	values, e = ps.Encode(map[string]interface{}{"a": true, "m": -5.0})
+	assert.NoError(e)
+	assert.Equal([]int64{20, 1, -5}, values)
+
+	_, e = ps.Encode(map[string]interface{}{"z": 0})
+	assert.Error(e)
+	_, e = ps.Encode(map[string]interface{}{"b": 1})
+	assert.Error(e)
+
+	_, e = strategycode.ParseParamsSchema([]byte(`{"type":"object","properties":{"s":{"type":"string"}}}`))
+	assert.Error(e)
+	_, e = strategycode.ParseParamsSchema([]byte(`{"type":"object","properties":{
+		"a":{"type":"integer"},"b":{"type":"integer"},"c":{"type":"integer"},
+		"d":{"type":"integer"},"e":{"type":"integer"}}}`))
+	assert.Error(e)
+
+	sc := strategycode.MakeEmpty("P")
+	defer sc.Close()
+	assert.Nil(sc.Schema())
+	_, e = sc.EncodeParams(nil)
+	assert.NoError(e)
+	_, e = sc.EncodeParams(map[string]interface{}{"a": 1})
+	assert.Error(e)
+}
@@ -0,0 +1,124 @@
+package sgtest
+
+/*
+#include "../../../csrc/strategycode/sgtest.h"
+*/
+import "C"
+import (
+	"fmt"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+// CallKind indicates which strategy API function was called.
+type CallKind int
+
+// CallKind values.
+const (
+	CallSetTimer        CallKind = C.SgTestCallSetTimer
+	CallForwardInterest CallKind = C.SgTestCallForwardInterest
+	CallReturnNacks     CallKind = C.SgTestCallReturnNacks
+	CallLearnNexthop    CallKind = C.SgTestCallLearnNexthop
+)
+
+func (kind CallKind) String() string {
+	switch kind {
+	case CallSetTimer:
+		return "SgSetTimer"
+	case CallForwardInterest:
+		return "SgForwardInterest"
+	case CallReturnNacks:
+		return "SgReturnNacks"
+	case CallLearnNexthop:
+		return "SgLearnNexthop"
+	}
+	return fmt.Sprintf("CallKind(%d)", int(kind))
+}
+
+// Call records a strategy API function call.
+type Call struct {
+	Kind    CallKind
+	After   time.Duration // SgSetTimer duration, or SgLearnNexthop lifetime
+	Nexthop iface.ID      // SgForwardInterest or SgLearnNexthop nexthop
+	Reason  uint8         // SgReturnNacks reason
+}
+
+// SetTimer constructs a Call of SgSetTimer.
+func SetTimer(after time.Duration) Call {
+	return Call{Kind: CallSetTimer, After: after}
+}
+
+// ForwardInterest constructs a Call of SgForwardInterest.
+func ForwardInterest(nh iface.ID) Call {
+	return Call{Kind: CallForwardInterest, Nexthop: nh}
+}
+
+// ReturnNacks constructs a Call of SgReturnNacks.
+func ReturnNacks(reason uint8) Call {
+	return Call{Kind: CallReturnNacks, Reason: reason}
+}
+
+// LearnNexthop constructs a Call of SgLearnNexthop.
+func LearnNexthop(nh iface.ID, lifetime time.Duration) Call {
+	return Call{Kind: CallLearnNexthop, Nexthop: nh, After: lifetime}
+}
+
+// ForgetNexthop constructs a Call of SgForgetNexthop, which is SgLearnNexthop with zero lifetime.
+func ForgetNexthop(nh iface.ID) Call {
+	return Call{Kind: CallLearnNexthop, Nexthop: nh}
+}
+
+func (call Call) String() string {
+	switch call.Kind {
+	case CallSetTimer:
+		return fmt.Sprintf("%s(%v)", call.Kind, call.After)
+	case CallForwardInterest:
+		return fmt.Sprintf("%s(%d)", call.Kind, call.Nexthop)
+	case CallReturnNacks:
+		return fmt.Sprintf("%s(%s)", call.Kind, an.NackReasonString(call.Reason))
+	case CallLearnNexthop:
+		if call.After == 0 {
+			return fmt.Sprintf("SgForgetNexthop(%d)", call.Nexthop)
+		}
+		return fmt.Sprintf("%s(%d, %v)", call.Kind, call.Nexthop, call.After)
+	}
+	return call.Kind.String()
+}
+
+// ForwardResult is the return value of SgForwardInterest.
+type ForwardResult int
+
+// ForwardResult values.
+const (
+	ForwardOK         ForwardResult = C.SGFWDI_OK
+	ForwardBadFace    ForwardResult = C.SGFWDI_BADFACE
+	ForwardAllocErr   ForwardResult = C.SGFWDI_ALLOCERR
+	ForwardNoNonce    ForwardResult = C.SGFWDI_NONONCE
+	ForwardSuppressed ForwardResult = C.SGFWDI_SUPPRESSED
+	ForwardHopZero    ForwardResult = C.SGFWDI_HOPZERO
+)
+
+// Result is the outcome of a strategy invocation.
+type Result struct {
+	// Status is the return value of SgMain.
+	Status uint64
+
+	// Calls contains strategy API function calls in order.
+	// SgGetNackReason calls are not recorded.
+	Calls []Call
+
+	// Overflow indicates some calls are omitted because there are too many.
+	Overflow bool
+}
+
+// Forwarded returns nexthops passed to SgForwardInterest, in order.
+func (res Result) Forwarded() (nexthops []iface.ID) {
+	for _, call := range res.Calls {
+		if call.Kind == CallForwardInterest {
+			nexthops = append(nexthops, call.Nexthop)
+		}
+	}
+	return nexthops
+}
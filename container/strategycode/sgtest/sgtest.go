@@ -0,0 +1,257 @@
+// Package sgtest provides a harness for unit testing forwarding strategies outside the forwarder.
+package sgtest
+
+/*
+#include "../../../csrc/strategycode/sgtest.h"
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/iface"
+)
+
+// EalArgs contains EAL arguments sufficient for running the harness.
+// They select a single lcore, and do not require hugepages or PCI devices.
+var EalArgs = []string{"-l", "0", "--no-huge", "-m", "256", "--no-pci", "--in-memory", "--file-prefix", "sgtest"}
+
+var (
+	xsyms  unsafe.Pointer
+	nXsyms int
+)
+
+func init() {
+	var n C.int
+	xsyms = unsafe.Pointer(C.SgTest_GetXsyms(&n))
+	nXsyms = int(n)
+}
+
+var (
+	errTooManyNexthops = fmt.Errorf("FIB entry cannot have more than %d nexthops", C.FibMaxNexthops)
+	errNotNexthop      = errors.New("face is not a nexthop")
+)
+
+// Harness runs a strategy against fake FIB and PIT entries.
+//
+// The harness keeps a single FIB entry and a single PIT entry.
+// The FIB entry scratch area persists across invocations.
+// The PIT entry is created upon Interest, and erased after Data or after the strategy returns Nacks.
+type Harness struct {
+	c         *C.SgTestCtx
+	sc        *strategycode.Strategy
+	now       eal.TscTime
+	pitActive bool
+	timer     time.Duration
+}
+
+// New loads a strategy ELF file and creates a harness.
+// If filename is empty, search for an ELF file in default locations.
+// EAL must be initialized.
+func New(name, filename string) (h *Harness, e error) {
+	sc, e := strategycode.LoadFileXsyms(name, filename, xsyms, nXsyms)
+	if e != nil {
+		return nil, e
+	}
+
+	h = &Harness{
+		c:   (*C.SgTestCtx)(eal.Zmalloc("SgTestCtx", C.sizeof_SgTestCtx, eal.NumaSocket{})),
+		sc:  sc,
+		now: eal.TscNow(),
+	}
+	h.c.global.tscHz = C.uint64_t(eal.TscHz)
+	h.c.timerResult = true
+	return h, nil
+}
+
+// Close unloads the strategy and releases memory.
+func (h *Harness) Close() error {
+	eal.Free(h.c)
+	return h.sc.Close()
+}
+
+// Strategy returns the loaded strategy.
+func (h *Harness) Strategy() *strategycode.Strategy {
+	return h.sc
+}
+
+// SetFibEntry assigns FIB entry nexthops and strategy parameters.
+// This also clears FIB entry scratch area and resets all configured results.
+func (h *Harness) SetFibEntry(nexthops []iface.ID, params map[string]interface{}) error {
+	if len(nexthops) > C.FibMaxNexthops {
+		return errTooManyNexthops
+	}
+	values, e := h.sc.EncodeParams(params)
+	if e != nil {
+		return fmt.Errorf("strategy.EncodeParams: %w", e)
+	}
+
+	h.c.fibEntry = C.SgFibEntry{}
+	h.c.fibEntryDyn = C.SgFibEntryDyn{}
+	h.c.fibEntry.nNexthops = C.uint8_t(len(nexthops))
+	for i, nh := range nexthops {
+		h.c.fibEntry.nexthops[i] = C.FaceID(nh)
+		h.c.fwdResult[i] = C.SGFWDI_OK
+		h.c.nackReason[i] = C.SgNackNone
+	}
+	for i, value := range values {
+		h.c.fibEntry.params[i] = C.int64_t(value)
+	}
+	return nil
+}
+
+// SetNexthops changes FIB entry nexthops, as if the control plane has updated the FIB entry.
+// FIB entry scratch area and strategy parameters are kept.
+func (h *Harness) SetNexthops(nexthops []iface.ID) error {
+	if len(nexthops) > C.FibMaxNexthops {
+		return errTooManyNexthops
+	}
+	h.c.fibEntry.nNexthops = C.uint8_t(len(nexthops))
+	for i, nh := range nexthops {
+		h.c.fibEntry.nexthops[i] = C.FaceID(nh)
+		h.c.fwdResult[i] = C.SGFWDI_OK
+		h.c.nackReason[i] = C.SgNackNone
+	}
+	return nil
+}
+
+// SetForwardResult configures the return value of SgForwardInterest on a nexthop.
+// Calling SgForwardInterest on a face that is not a nexthop always returns ForwardBadFace.
+func (h *Harness) SetForwardResult(nh iface.ID, res ForwardResult) error {
+	for i := 0; i < int(h.c.fibEntry.nNexthops); i++ {
+		if iface.ID(h.c.fibEntry.nexthops[i]) == nh {
+			h.c.fwdResult[i] = C.uint8_t(res)
+			return nil
+		}
+	}
+	return errNotNexthop
+}
+
+// SetTimerResult configures the return value of SgSetTimer.
+func (h *Harness) SetTimerResult(ok bool) {
+	h.c.timerResult = C.bool(ok)
+}
+
+// FibScratch returns a copy of FIB entry scratch area.
+func (h *Harness) FibScratch() []byte {
+	return C.GoBytes(unsafe.Pointer(&h.c.fibEntryDyn.scratch[0]), C.int(len(h.c.fibEntryDyn.scratch)))
+}
+
+// PitScratch returns a copy of PIT entry scratch area.
+func (h *Harness) PitScratch() []byte {
+	return C.GoBytes(unsafe.Pointer(&h.c.pitEntry.scratch[0]), C.SG_PIT_ENTRY_SCRATCH)
+}
+
+// Advance moves the harness clock forward.
+func (h *Harness) Advance(d time.Duration) {
+	h.now = h.now.Add(d)
+}
+
+// Interest invokes the strategy with an Interest arriving on dnFace.
+// If there is no PIT entry, a new PIT entry is created; otherwise, dnFace is added as a downstream.
+// Nexthops matching a downstream face are excluded by ctx->nhFlt.
+func (h *Harness) Interest(dnFace iface.ID) Result {
+	if !h.pitActive {
+		h.c.pitEntry = C.SgPitEntry{}
+		for i := range h.c.nackReason {
+			h.c.nackReason[i] = C.SgNackNone
+		}
+		h.pitActive = true
+	}
+	for i := range h.c.pitEntry.dns {
+		dn := &h.c.pitEntry.dns[i]
+		if dn.face == 0 || iface.ID(dn.face) == dnFace {
+			dn.face = C.FaceID(dnFace)
+			dn.expiry = C.TscTime(h.now.Add(4 * time.Second))
+			break
+		}
+	}
+
+	h.c.sg.nhFlt = 0
+	C.SgTestCtx_RejectNexthop(h.c, C.FaceID(dnFace))
+	return h.invoke(C.SGEVT_INTEREST)
+}
+
+// Data invokes the strategy with a Data arriving on upFace.
+// The PIT entry is erased afterwards.
+func (h *Harness) Data(upFace iface.ID) Result {
+	h.c.pkt.rxFace = C.FaceID(upFace)
+	h.c.pkt.nackReason = C.SgNackNone
+	h.c.sg.nhFlt = ^C.SgFibNexthopFilter(0)
+	res := h.invoke(C.SGEVT_DATA)
+	h.pitActive = false
+	return res
+}
+
+// Nack invokes the strategy with a Nack arriving on upFace.
+// The Nack reason is remembered for SgGetNackReason, until the Interest is forwarded to upFace again.
+// Nexthops matching upFace or a downstream face are excluded by ctx->nhFlt.
+func (h *Harness) Nack(upFace iface.ID, reason uint8) Result {
+	h.c.pkt.rxFace = C.FaceID(upFace)
+	h.c.pkt.nackReason = C.uint8_t(reason)
+
+	h.c.sg.nhFlt = 0
+	if i := C.SgTestCtx_RejectNexthop(h.c, C.FaceID(upFace)); i >= 0 {
+		h.c.nackReason[i] = C.uint8_t(reason)
+	}
+	for _, dn := range h.c.pitEntry.dns {
+		if dn.face != 0 {
+			C.SgTestCtx_RejectNexthop(h.c, dn.face)
+		}
+	}
+	return h.invoke(C.SGEVT_NACK)
+}
+
+// Timer invokes the strategy as if the timer set by SgSetTimer expires.
+// The harness clock is advanced by the timer duration.
+// Nexthops matching a downstream face are excluded by ctx->nhFlt.
+func (h *Harness) Timer() Result {
+	h.Advance(h.timer)
+	h.c.sg.nhFlt = 0
+	for _, dn := range h.c.pitEntry.dns {
+		if dn.face != 0 {
+			C.SgTestCtx_RejectNexthop(h.c, dn.face)
+		}
+	}
+	return h.invoke(C.SGEVT_TIMER)
+}
+
+func (h *Harness) invoke(evt C.SgEvent) (res Result) {
+	h.timer = 0 // any invocation cancels the timer
+	h.c.sg.eventKind = evt
+	h.c.sg.now = C.TscTime(h.now)
+	res.Status = uint64(C.SgTestCtx_Invoke(h.c, (*C.StrategyCode)(h.sc.Ptr())))
+
+	n := int(h.c.nCalls)
+	if n > C.SgTestMaxCalls {
+		res.Overflow = true
+		n = C.SgTestMaxCalls
+	}
+	for _, c := range h.c.calls[:n] {
+		call := Call{Kind: CallKind(c.kind)}
+		switch call.Kind {
+		case CallSetTimer:
+			call.After = eal.FromTscDuration(int64(c.after))
+			if h.c.timerResult {
+				h.timer = call.After
+			}
+		case CallForwardInterest:
+			call.Nexthop = iface.ID(c.nh)
+		case CallReturnNacks:
+			call.Reason = uint8(c.reason)
+		case CallLearnNexthop:
+			call.Nexthop = iface.ID(c.nh)
+			call.After = time.Duration(c.lifetime) * time.Millisecond
+		}
+		res.Calls = append(res.Calls, call)
+	}
+
+	if h.c.nackReturned {
+		h.pitActive = false
+	}
+	return res
+}
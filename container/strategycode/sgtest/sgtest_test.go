@@ -0,0 +1,190 @@
+package sgtest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode/sgtest"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+type step struct {
+	event  func(h *sgtest.Harness) sgtest.Result
+	status uint64
+	calls  []sgtest.Call
+}
+
+func interest(dn iface.ID) func(h *sgtest.Harness) sgtest.Result {
+	return func(h *sgtest.Harness) sgtest.Result { return h.Interest(dn) }
+}
+
+func data(up iface.ID) func(h *sgtest.Harness) sgtest.Result {
+	return func(h *sgtest.Harness) sgtest.Result { return h.Data(up) }
+}
+
+func nack(up iface.ID, reason uint8) func(h *sgtest.Harness) sgtest.Result {
+	return func(h *sgtest.Harness) sgtest.Result { return h.Nack(up, reason) }
+}
+
+func timer(h *sgtest.Harness) sgtest.Result {
+	return h.Timer()
+}
+
+func runSteps(t *testing.T, h *sgtest.Harness, steps []step) {
+	assert, _ := makeAR(t)
+	for i, st := range steps {
+		res := st.event(h)
+		assert.False(res.Overflow, "step %d", i)
+		assert.Equal(st.status, res.Status, "step %d", i)
+		if !assert.Len(res.Calls, len(st.calls), "step %d %v", i, res.Calls) {
+			continue
+		}
+		for j, call := range res.Calls {
+			expected := st.calls[j]
+			assert.InDelta(expected.After, call.After, float64(time.Millisecond), "step %d call %d", i, j)
+			call.After, expected.After = 0, 0
+			assert.Equal(expected, call, "step %d call %d", i, j)
+		}
+	}
+}
+
+func TestBestroute(t *testing.T) {
+	_, require := makeAR(t)
+	h, e := sgtest.New("bestroute", "")
+	require.NoError(e)
+	defer h.Close()
+
+	tests := []struct {
+		name  string
+		setup func(h *sgtest.Harness)
+		steps []step
+	}{
+		{
+			name: "forward",
+			steps: []step{
+				{interest(2000), 0, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+				{data(1001), 2, nil},
+			},
+		},
+		{
+			name: "exclude-downstream",
+			steps: []step{
+				{interest(1001), 0, []sgtest.Call{sgtest.ForwardInterest(1002)}},
+			},
+		},
+		{
+			name: "skip-badface",
+			setup: func(h *sgtest.Harness) {
+				require.NoError(h.SetForwardResult(1001, sgtest.ForwardBadFace))
+			},
+			steps: []step{
+				{interest(2000), 0, []sgtest.Call{sgtest.ForwardInterest(1001), sgtest.ForwardInterest(1002)}},
+			},
+		},
+		{
+			name: "retry-then-nack",
+			steps: []step{
+				{interest(2000), 0, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+				{nack(1001, an.NackNoRoute), 11, []sgtest.Call{sgtest.ForwardInterest(1002)}},
+				{nack(1002, an.NackCongestion), 11, []sgtest.Call{sgtest.ForwardInterest(1003)}},
+				{nack(1003, an.NackDuplicate), 13, []sgtest.Call{sgtest.ReturnNacks(an.NackCongestion)}},
+				{interest(2000), 0, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+			},
+		},
+		{
+			name: "retry-suppressed",
+			setup: func(h *sgtest.Harness) {
+				require.NoError(h.SetForwardResult(1002, sgtest.ForwardSuppressed))
+			},
+			steps: []step{
+				{interest(2000), 0, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+				{nack(1001, an.NackNoRoute), 12, []sgtest.Call{sgtest.ForwardInterest(1002)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(h.SetFibEntry([]iface.ID{1001, 1002, 1003}, nil))
+			if tt.setup != nil {
+				tt.setup(h)
+			}
+			runSteps(t, h, tt.steps)
+			h.Data(1001) // erase PIT entry
+		})
+	}
+}
+
+func TestDelay(t *testing.T) {
+	assert, require := makeAR(t)
+	h, e := sgtest.New("delay", "")
+	require.NoError(e)
+	defer h.Close()
+
+	assert.Error(h.SetFibEntry([]iface.ID{1001}, map[string]interface{}{"delay": -1}))
+	require.NoError(h.SetFibEntry([]iface.ID{1001}, map[string]interface{}{"delay": 50}))
+	runSteps(t, h, []step{
+		{interest(2000), 0, []sgtest.Call{sgtest.SetTimer(50 * time.Millisecond)}},
+		{timer, 0, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+	})
+
+	h.SetTimerResult(false)
+	runSteps(t, h, []step{
+		{interest(2001), 3, []sgtest.Call{sgtest.SetTimer(50 * time.Millisecond)}},
+	})
+}
+
+func TestSelfLearning(t *testing.T) {
+	_, require := makeAR(t)
+	h, e := sgtest.New("selflearning", "")
+	require.NoError(e)
+	defer h.Close()
+
+	require.NoError(h.SetFibEntry([]iface.ID{1001}, map[string]interface{}{"lifetime": 1000, "timeout": 200}))
+	runSteps(t, h, []step{
+		{interest(2000), 12, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+		{data(1005), 21, []sgtest.Call{sgtest.LearnNexthop(1005, 1000*time.Millisecond)}},
+	})
+
+	// learned nexthop is installed by control plane
+	require.NoError(h.SetNexthops([]iface.ID{1001, 1005}))
+	runSteps(t, h, []step{
+		{interest(2000), 11, []sgtest.Call{sgtest.ForwardInterest(1005), sgtest.SetTimer(200 * time.Millisecond)}},
+		{data(1005), 0, nil},
+	})
+
+	// refresh after half of lifetime
+	h.Advance(600 * time.Millisecond)
+	runSteps(t, h, []step{
+		{interest(2000), 11, []sgtest.Call{sgtest.ForwardInterest(1005), sgtest.SetTimer(200 * time.Millisecond)}},
+		{data(1005), 21, []sgtest.Call{sgtest.LearnNexthop(1005, 1000*time.Millisecond)}},
+	})
+
+	// unicast Interest times out
+	runSteps(t, h, []step{
+		{interest(2000), 11, []sgtest.Call{sgtest.ForwardInterest(1005), sgtest.SetTimer(200 * time.Millisecond)}},
+		{timer, 31, []sgtest.Call{sgtest.ForgetNexthop(1005), sgtest.ForwardInterest(1001)}},
+	})
+
+	// learned nexthop is removed by control plane
+	require.NoError(h.SetNexthops([]iface.ID{1001}))
+	runSteps(t, h, []step{
+		{interest(2000), 12, []sgtest.Call{sgtest.ForwardInterest(1001)}},
+		{data(1006), 21, []sgtest.Call{sgtest.LearnNexthop(1006, 1000*time.Millisecond)}},
+	})
+
+	// nexthop installed upon request from another forwarding thread is learned in this thread
+	require.NoError(h.SetNexthops([]iface.ID{1001, 1007}))
+	runSteps(t, h, []step{
+		{interest(2000), 12, []sgtest.Call{sgtest.ForwardInterest(1001), sgtest.ForwardInterest(1007)}},
+		{data(1007), 21, []sgtest.Call{sgtest.LearnNexthop(1007, 1000*time.Millisecond)}},
+		{interest(2000), 11, []sgtest.Call{sgtest.ForwardInterest(1007), sgtest.SetTimer(200 * time.Millisecond)}},
+		{data(1007), 0, nil},
+	})
+
+	// flooding after timeout excludes downstream faces
+	runSteps(t, h, []step{
+		{interest(1001), 11, []sgtest.Call{sgtest.ForwardInterest(1007), sgtest.SetTimer(200 * time.Millisecond)}},
+		{timer, 31, []sgtest.Call{sgtest.ForgetNexthop(1007)}},
+	})
+}
@@ -0,0 +1,18 @@
+package sgtest_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/container/strategycode/sgtest"
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealinit"
+)
+
+func TestMain(m *testing.M) {
+	if e := ealinit.Init(sgtest.EalArgs); e != nil {
+		panic(e)
+	}
+	testenv.Exit(m.Run())
+}
+
+var makeAR = testenv.MakeAR
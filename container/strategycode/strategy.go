@@ -6,8 +6,11 @@ package strategycode
 */
 import "C"
 import (
+	"errors"
 	"fmt"
 	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
 )
 
 // Strategy is a reference of a forwarding strategy BPF program.
@@ -29,7 +32,32 @@ func (sc *Strategy) ID() int {
 
 // Name returns short name.
 func (sc *Strategy) Name() string {
-	return C.GoString(sc.name)
+	tableLock.Lock()
+	defer tableLock.Unlock()
+	return sc.name()
+}
+
+func (sc *Strategy) name() string {
+	return C.GoString(sc.ptr().name)
+}
+
+// Schema returns parameters schema, or nil if the strategy does not accept parameters.
+func (sc *Strategy) Schema() *ParamsSchema {
+	tableLock.Lock()
+	defer tableLock.Unlock()
+	return schemas[sc.ID()]
+}
+
+// EncodeParams validates strategy parameters and converts them to slot values.
+func (sc *Strategy) EncodeParams(params map[string]interface{}) (values []int64, e error) {
+	ps := sc.Schema()
+	if ps == nil {
+		if len(params) > 0 {
+			return nil, errNoParams
+		}
+		return nil, nil
+	}
+	return ps.Encode(params)
 }
 
 // CountRefs returns number of references.
@@ -39,12 +67,38 @@ func (sc *Strategy) CountRefs() int {
 	return int(sc.nRefs)
 }
 
+// Replace moves the BPF program, short name, and parameters schema of src into sc, and then closes src.
+// Existing references to sc, such as FIB entries, keep working: forwarding threads see either the
+// old or the new program via RCU, and the old program is unloaded after a grace period.
+// If resetScratch is true, FIB entry scratch areas are cleared before the new program runs on them.
+//
+// Caller is responsible for ensuring existing strategy parameters are compatible with the new schema.
+func (sc *Strategy) Replace(src *Strategy, resetScratch bool) error {
+	if src == sc {
+		return errors.New("cannot replace strategy with itself")
+	}
+
+	tableLock.Lock()
+	old := C.StrategyCode_Replace(sc.ptr(), src.ptr(), C.bool(resetScratch))
+	if schema := schemas[src.ID()]; schema != nil {
+		schemas[sc.ID()] = schema
+	} else {
+		delete(schemas, sc.ID())
+	}
+	tableLock.Unlock()
+
+	urcu.Synchronize()
+	C.StrategyCodeProg_Free(old)
+	return src.Close()
+}
+
 // Close reduces the number of references by one.
 // The strategy will be unloaded when its reference count reaches zero.
 func (sc *Strategy) Close() error {
 	tableLock.Lock()
 	defer tableLock.Unlock()
 	delete(table, sc.ID())
+	delete(schemas, sc.ID())
 	C.StrategyCode_Unref(sc.ptr())
 	return nil
 }
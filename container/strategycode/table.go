@@ -12,6 +12,7 @@ import (
 var (
 	lastID    int
 	table     = make(map[int]*Strategy)
+	schemas   = make(map[int]*ParamsSchema)
 	tableLock sync.Mutex
 )
 
@@ -32,7 +33,7 @@ func Find(name string) *Strategy {
 	tableLock.Lock()
 	defer tableLock.Unlock()
 	for _, sc := range table {
-		if sc.Name() == name {
+		if sc.name() == name {
 			return sc
 		}
 	}
@@ -45,11 +45,11 @@ func findField(sub *graphqlws.Subscription) *ast.Field {
 	return nil
 }
 
-// GetArg extracts argument value from AST field.
-func GetArg(sub *graphqlws.Subscription, argName string, scalar *graphql.Scalar) interface{} {
+// findArg extracts argument AST value from AST field, or variable value if the argument is a variable.
+func findArg(sub *graphqlws.Subscription, argName string) (value ast.Value, variable interface{}) {
 	field := findField(sub)
 	if field == nil {
-		return nil
+		return nil, nil
 	}
 
 	for _, arg := range field.Arguments {
@@ -57,15 +57,50 @@ func GetArg(sub *graphqlws.Subscription, argName string, scalar *graphql.Scalar)
 			continue
 		}
 
-		if variable, ok := arg.Value.(*ast.Variable); ok {
-			val := sub.Variables[variable.Name.Value]
-			if val != nil {
-				return scalar.ParseValue(val)
-			}
-			return nil
+		if v, ok := arg.Value.(*ast.Variable); ok {
+			return nil, sub.Variables[v.Name.Value]
 		}
+		return arg.Value, nil
+	}
+	return nil, nil
+}
 
-		return scalar.ParseLiteral(arg.Value)
+// GetArg extracts argument value from AST field.
+func GetArg(sub *graphqlws.Subscription, argName string, scalar *graphql.Scalar) interface{} {
+	value, variable := findArg(sub, argName)
+	switch {
+	case variable != nil:
+		return scalar.ParseValue(variable)
+	case value != nil:
+		return scalar.ParseLiteral(value)
 	}
 	return nil
 }
+
+// GetListArg extracts list argument value from AST field.
+// Each list item is parsed with the scalar type.
+// Returns nil if the argument is missing or is not a list.
+func GetListArg(sub *graphqlws.Subscription, argName string, scalar *graphql.Scalar) (list []interface{}) {
+	value, variable := findArg(sub, argName)
+	switch {
+	case variable != nil:
+		items, ok := variable.([]interface{})
+		if !ok {
+			return nil
+		}
+		list = []interface{}{}
+		for _, item := range items {
+			list = append(list, scalar.ParseValue(item))
+		}
+	case value != nil:
+		lv, ok := value.(*ast.ListValue)
+		if !ok {
+			return nil
+		}
+		list = []interface{}{}
+		for _, item := range lv.Values {
+			list = append(list, scalar.ParseLiteral(item))
+		}
+	}
+	return list
+}
@@ -0,0 +1,70 @@
+package gqlsub_test
+
+import (
+	"testing"
+
+	"github.com/functionalfoundry/graphqlws"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver/gqlsub"
+)
+
+func makeSubscription(t *testing.T, query string, vars map[string]interface{}) *graphqlws.Subscription {
+	_, require := makeAR(t)
+	doc, e := parser.Parse(parser.ParseParams{Source: query})
+	require.NoError(e)
+	return &graphqlws.Subscription{
+		Query:     query,
+		Variables: vars,
+		Document:  doc,
+	}
+}
+
+func TestGetArg(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	literal := makeSubscription(t, `
+		subscription {
+			watch(id: "A", n: 5)
+		}
+	`, nil)
+	assert.Equal("A", gqlsub.GetArg(literal, "id", graphql.ID))
+	assert.Equal(5, gqlsub.GetArg(literal, "n", graphql.Int))
+	assert.Nil(gqlsub.GetArg(literal, "missing", graphql.Int))
+
+	variable := makeSubscription(t, `
+		subscription watch($id: ID!, $n: Int) {
+			watch(id: $id, n: $n)
+		}
+	`, map[string]interface{}{"id": "B"})
+	assert.Equal("B", gqlsub.GetArg(variable, "id", graphql.ID))
+	assert.Nil(gqlsub.GetArg(variable, "n", graphql.Int))
+}
+
+func TestGetListArg(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	literal := makeSubscription(t, `
+		subscription {
+			watch(names: ["/A", "/B"], empty: [], scalar: "/C")
+		}
+	`, nil)
+	assert.Equal([]interface{}{"/A", "/B"}, gqlsub.GetListArg(literal, "names", graphql.String))
+	assert.Equal([]interface{}{}, gqlsub.GetListArg(literal, "empty", graphql.String))
+	assert.Nil(gqlsub.GetListArg(literal, "scalar", graphql.String))
+	assert.Nil(gqlsub.GetListArg(literal, "missing", graphql.String))
+
+	variable := makeSubscription(t, `
+		subscription watch($names: [String!]!, $empty: [String!]!, $scalar: String!, $unset: [String!]) {
+			watch(names: $names, empty: $empty, scalar: $scalar, unset: $unset)
+		}
+	`, map[string]interface{}{
+		"names":  []interface{}{"/A", "/B", "/C"},
+		"empty":  []interface{}{},
+		"scalar": "/D",
+	})
+	assert.Equal([]interface{}{"/A", "/B", "/C"}, gqlsub.GetListArg(variable, "names", graphql.String))
+	assert.Equal([]interface{}{}, gqlsub.GetListArg(variable, "empty", graphql.String))
+	assert.Nil(gqlsub.GetListArg(variable, "scalar", graphql.String))
+	assert.Nil(gqlsub.GetListArg(variable, "unset", graphql.String))
+}
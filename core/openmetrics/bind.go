@@ -0,0 +1,96 @@
+package openmetrics
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// MetricName converts a JSON field name to a metric name suffix.
+// A leading "n" before an uppercase letter is removed, and camelCase is converted to snake_case.
+// For example, "nRxInterests" becomes "rx_interests".
+func MetricName(jsonName string) string {
+	runes := []rune(jsonName)
+	if len(runes) >= 2 && runes[0] == 'n' && unicode.IsUpper(runes[1]) {
+		runes = runes[1:]
+	}
+
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// AddStruct appends a sample for each numeric field of a struct.
+// prefix is prepended to metric names derived from JSON field names via MetricName.
+//
+// A field tagged `openmetrics:"-"` is skipped.
+// A field tagged `openmetrics:"gauge"`, a floating point field, or a time.Duration field is a gauge;
+// other integer fields are counters.
+// Fields of other types are skipped, except that fields of embedded structs are included.
+// The metric description is taken from `gqldesc` tag.
+func (s *Set) AddStruct(prefix string, labels Labels, value interface{}) {
+	val := reflect.ValueOf(value)
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return
+		}
+		val = val.Elem()
+	}
+
+	for _, field := range reflect.VisibleFields(val.Type()) {
+		if !field.IsExported() {
+			continue
+		}
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		omTag := field.Tag.Get("openmetrics")
+		if jsonName == "" || jsonName == "-" || omTag == "-" {
+			continue
+		}
+
+		f := Family{
+			Name: prefix + MetricName(jsonName),
+			Help: field.Tag.Get("gqldesc"),
+			Type: Counter,
+		}
+		switch field.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if field.Type == durationType {
+				f.Name += "_seconds"
+				f.Type = Gauge
+			}
+		case reflect.Float32, reflect.Float64:
+			f.Type = Gauge
+		default:
+			continue
+		}
+		if omTag == "gauge" {
+			f.Type = Gauge
+		}
+
+		fv := val.FieldByIndex(field.Index)
+		if field.Type == durationType {
+			s.Add(f, labels, time.Duration(fv.Int()))
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			s.Add(f, labels, fv.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			s.Add(f, labels, fv.Uint())
+		default:
+			s.Add(f, labels, fv.Float())
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// Package openmetrics provides a minimal OpenMetrics text format exporter.
+package openmetrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the HTTP Content-Type of OpenMetrics text format.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// MetricType indicates the type of a metric family.
+type MetricType string
+
+// MetricType values.
+const (
+	Counter MetricType = "counter"
+	Gauge   MetricType = "gauge"
+)
+
+// Family describes a metric family.
+type Family struct {
+	// Name is the metric family name.
+	// For a counter, the "_total" suffix is appended to sample names.
+	Name string
+	// Help is a description of the metric family.
+	Help string
+	// Type is the metric type.
+	Type MetricType
+}
+
+// Labels contains metric labels.
+type Labels map[string]string
+
+// With returns a copy of labels with additional key-value pairs.
+func (labels Labels) With(kv ...string) Labels {
+	l := Labels{}
+	for k, v := range labels {
+		l[k] = v
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		l[kv[i]] = kv[i+1]
+	}
+	return l
+}
+
+func (labels Labels) write(b *bufio.Writer) {
+	if len(labels) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteString(`="`)
+		labelValueEscaper.WriteString(b, labels[k])
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+type sample struct {
+	labels Labels
+	value  string
+}
+
+type familySamples struct {
+	Family
+	samples []sample
+}
+
+// Set is a set of metric samples, grouped by family.
+type Set struct {
+	families []*familySamples
+	index    map[string]*familySamples
+}
+
+// Add appends a sample.
+// value must be an integer or floating point number; time.Duration is converted to seconds.
+func (s *Set) Add(f Family, labels Labels, value interface{}) {
+	if s.index == nil {
+		s.index = map[string]*familySamples{}
+	}
+	fs := s.index[f.Name]
+	if fs == nil {
+		fs = &familySamples{Family: f}
+		s.families = append(s.families, fs)
+		s.index[f.Name] = fs
+	}
+	fs.samples = append(fs.samples, sample{labels, formatValue(value)})
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Duration:
+		return strconv.FormatFloat(v.Seconds(), 'g', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	}
+	panic(fmt.Errorf("openmetrics: unsupported value type %T", value))
+}
+
+// WriteTo writes the set in OpenMetrics text format.
+func (s *Set) WriteTo(w io.Writer) (n int64, e error) {
+	cw := &countWriter{w: w}
+	b := bufio.NewWriter(cw)
+	for _, fs := range s.families {
+		name, sampleName := fs.Name, fs.Name
+		if fs.Type == Counter {
+			name = strings.TrimSuffix(name, "_total")
+			sampleName = name + "_total"
+		}
+
+		fmt.Fprintf(b, "# TYPE %s %s\n", name, fs.Type)
+		if fs.Help != "" {
+			fmt.Fprintf(b, "# HELP %s %s\n", name, helpEscaper.Replace(fs.Help))
+		}
+		for _, smp := range fs.samples {
+			b.WriteString(sampleName)
+			smp.labels.write(b)
+			b.WriteByte(' ')
+			b.WriteString(smp.value)
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString("# EOF\n")
+	e = b.Flush()
+	return cw.n, e
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (n int, e error) {
+	n, e = cw.w.Write(p)
+	cw.n += int64(n)
+	return n, e
+}
+
+// Collector appends metric samples to a Set.
+type Collector func(s *Set)
+
+var (
+	collectors      []Collector
+	collectorsMutex sync.RWMutex
+)
+
+// AddCollector registers a Collector.
+// Collectors are invoked in registration order during each scrape.
+func AddCollector(c Collector) {
+	collectorsMutex.Lock()
+	defer collectorsMutex.Unlock()
+	collectors = append(collectors, c)
+}
+
+// Collect invokes all collectors.
+func Collect() (s *Set) {
+	collectorsMutex.RLock()
+	defer collectorsMutex.RUnlock()
+	s = &Set{}
+	for _, c := range collectors {
+		c(s)
+	}
+	return s
+}
+
+// Handler returns an HTTP handler that serves collected metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s := Collect()
+		w.Header().Set("Content-Type", ContentType)
+		s.WriteTo(w)
+	})
+}
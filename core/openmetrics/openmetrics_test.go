@@ -0,0 +1,96 @@
+package openmetrics_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+)
+
+func TestMetricName(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	assert.Equal("rx_interests", openmetrics.MetricName("nRxInterests"))
+	assert.Equal("rx_frames", openmetrics.MetricName("rxFrames"))
+	assert.Equal("s_rtt", openmetrics.MetricName("sRtt"))
+	assert.Equal("items", openmetrics.MetricName("items"))
+	assert.Equal("nack", openmetrics.MetricName("nack"))
+}
+
+type testCountersInner struct {
+	NInner uint64 `json:"nInner"`
+}
+
+type testCounters struct {
+	testCountersInner
+	NPackets  uint64        `json:"nPackets" gqldesc:"Packets."`
+	NEntries  int           `json:"nEntries" openmetrics:"gauge"`
+	Rtt       time.Duration `json:"rtt"`
+	Ratio     float64       `json:"ratio"`
+	Skipped   uint64        `json:"skipped" openmetrics:"-"`
+	NoTag     uint64
+	Name      string   `json:"name"`
+	PerThread []uint64 `json:"perThread"`
+}
+
+func TestScrape(t *testing.T) {
+	assert, require := makeAR(t)
+
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		for i, face := range []string{"1", "2"} {
+			s.AddStruct("test_", openmetrics.Labels{"face": face}, testCounters{
+				testCountersInner: testCountersInner{NInner: 7},
+				NPackets:          uint64(100 + i),
+				NEntries:          3,
+				Rtt:               1500 * time.Millisecond,
+				Ratio:             0.25,
+				Skipped:           1,
+				NoTag:             1,
+			})
+		}
+		s.Add(openmetrics.Family{
+			Name: "test_escape",
+			Help: "Line\nbreak.",
+			Type: openmetrics.Gauge,
+		}, openmetrics.Labels{"b": `quote"back\slash`, "a": "x"}, -1)
+	})
+
+	server := httptest.NewServer(openmetrics.Handler())
+	defer server.Close()
+
+	res, e := http.Get(server.URL)
+	require.NoError(e)
+	defer res.Body.Close()
+	assert.Equal(http.StatusOK, res.StatusCode)
+	assert.Equal(openmetrics.ContentType, res.Header.Get("Content-Type"))
+	body, e := io.ReadAll(res.Body)
+	require.NoError(e)
+
+	assert.Equal(strings.Join([]string{
+		"# TYPE test_inner counter",
+		`test_inner_total{face="1"} 7`,
+		`test_inner_total{face="2"} 7`,
+		"# TYPE test_packets counter",
+		"# HELP test_packets Packets.",
+		`test_packets_total{face="1"} 100`,
+		`test_packets_total{face="2"} 101`,
+		"# TYPE test_entries gauge",
+		`test_entries{face="1"} 3`,
+		`test_entries{face="2"} 3`,
+		"# TYPE test_rtt_seconds gauge",
+		`test_rtt_seconds{face="1"} 1.5`,
+		`test_rtt_seconds{face="2"} 1.5`,
+		"# TYPE test_ratio gauge",
+		`test_ratio{face="1"} 0.25`,
+		`test_ratio{face="2"} 0.25`,
+		"# TYPE test_escape gauge",
+		`# HELP test_escape Line\nbreak.`,
+		`test_escape{a="x",b="quote\"back\\slash"} -1`,
+		"# EOF",
+		"",
+	}, "\n"), string(body))
+}
@@ -0,0 +1,23 @@
+#include "diskalloc.h"
+
+DiskAlloc*
+DiskAlloc_New(uint64_t min, uint64_t max, int numaSocket)
+{
+  NDNDPDK_ASSERT(min > 0 && min <= max);
+  uint64_t nSlots = max - min + 1;
+  NDNDPDK_ASSERT(nSlots <= UINT32_MAX);
+  uint32_t bmpSize = rte_bitmap_get_memory_footprint(nSlots);
+
+  DiskAlloc* a = rte_zmalloc_socket("DiskAlloc", sizeof(DiskAlloc) + bmpSize, RTE_CACHE_LINE_SIZE,
+                                    numaSocket);
+  if (unlikely(a == NULL)) {
+    abort();
+  }
+  a->min = min;
+  a->max = max;
+  a->nAvail = nSlots;
+
+  a->bmp = rte_bitmap_init_with_all_set(nSlots, a->bmpMem, bmpSize);
+  NDNDPDK_ASSERT(a->bmp != NULL);
+  return a;
+}
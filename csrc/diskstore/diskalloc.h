@@ -0,0 +1,61 @@
+#ifndef NDNDPDK_DISKSTORE_DISKALLOC_H
+#define NDNDPDK_DISKSTORE_DISKALLOC_H
+
+/** @file */
+
+#include "../core/common.h"
+#include <rte_bitmap.h>
+
+/**
+ * @brief DiskStore slot allocator.
+ *
+ * This allocates slot numbers within a contiguous range. It is not thread safe.
+ */
+typedef struct DiskAlloc
+{
+  uint64_t min;
+  uint64_t max;
+  uint64_t nAvail;
+  struct rte_bitmap* bmp; ///< bit set means slot is available
+  uint8_t bmpMem[] __rte_cache_aligned;
+} DiskAlloc;
+
+/**
+ * @brief Create a DiskAlloc.
+ * @param min minimum slot number, must be positive.
+ * @param max maximum slot number, inclusive.
+ */
+__attribute__((returns_nonnull)) DiskAlloc*
+DiskAlloc_New(uint64_t min, uint64_t max, int numaSocket);
+
+/**
+ * @brief Allocate a slot.
+ * @retval 0 no slot available.
+ * @return slot number.
+ */
+__attribute__((nonnull)) static inline uint64_t
+DiskAlloc_Alloc(DiskAlloc* a)
+{
+  uint32_t pos = 0;
+  uint64_t slab = 0;
+  if (unlikely(rte_bitmap_scan(a->bmp, &pos, &slab) == 0)) {
+    return 0;
+  }
+  pos += rte_bsf64(slab);
+  rte_bitmap_clear(a->bmp, pos);
+  --a->nAvail;
+  return a->min + pos;
+}
+
+/** @brief Free a slot. */
+__attribute__((nonnull)) static inline void
+DiskAlloc_Free(DiskAlloc* a, uint64_t slotID)
+{
+  NDNDPDK_ASSERT(slotID >= a->min && slotID <= a->max);
+  uint32_t pos = slotID - a->min;
+  NDNDPDK_ASSERT(rte_bitmap_get(a->bmp, pos) == 0);
+  rte_bitmap_set(a->bmp, pos);
+  ++a->nAvail;
+}
+
+#endif // NDNDPDK_DISKSTORE_DISKALLOC_H
@@ -20,6 +20,9 @@ typedef struct DiskStore
   struct spdk_io_channel* ch;
   uint64_t nBlocksPerSlot;
   uint32_t blockSize;
+
+  uint64_t nPutDataFails; ///< PutData failures, updated on SPDK thread
+  uint64_t nGetDataFails; ///< GetData failures, updated on SPDK thread
 } DiskStore;
 
 /**
@@ -37,16 +37,20 @@ DiskStore_GetData_End(struct spdk_bdev_io* io, bool success, void* npkt0)
   PInterest* interest = Packet_GetInterestHdr(npkt);
   uint64_t slotID = interest->diskSlot;
   struct rte_mbuf* dataPkt = Packet_ToMbuf(interest->diskData);
-  struct rte_ring* reply = ((DiskStore_GetDataRequest*)rte_mbuf_to_priv(dataPkt))->reply;
+  DiskStore_GetDataRequest* req = (DiskStore_GetDataRequest*)rte_mbuf_to_priv(dataPkt);
+  DiskStore* store = req->store;
+  struct rte_ring* reply = req->reply;
 
   if (unlikely(!success)) {
     N_LOGW("GetData_End slot=%" PRIu64 " npkt=%p fail=io-err", slotID, npkt);
+    ++store->nGetDataFails;
     DiskStore_GetData_Fail(reply, npkt);
   } else {
     Mbuf_SetTimestamp(dataPkt, rte_get_tsc_cycles());
     if (unlikely(!Packet_Parse(interest->diskData)) ||
         unlikely(Packet_GetType(interest->diskData) != PktData)) {
       N_LOGW("GetData_End slot=%" PRIu64 " npkt=%p fail=not-Data", slotID, npkt);
+      ++store->nGetDataFails;
       DiskStore_GetData_Fail(reply, npkt);
     } else if (unlikely(rte_ring_enqueue(reply, npkt) != 0)) {
       N_LOGW("GetData_End slot=%" PRIu64 " npkt=%p fail=enqueue", slotID, npkt);
@@ -73,6 +77,7 @@ DiskStore_GetData_Begin(void* npkt0)
                                 store->blockSize, DiskStore_GetData_End, (uintptr_t)npkt);
   if (unlikely(res != 0)) {
     N_LOGW("GetData_Begin slot=%" PRIu64 " npkt=%p fail=read(%d)", slotID, npkt, res);
+    ++store->nGetDataFails;
     DiskStore_GetData_Fail(req->reply, npkt);
   }
 }
@@ -22,6 +22,7 @@ DiskStore_PutData_End(struct spdk_bdev_io* io, bool success, void* npkt0)
 
   if (unlikely(!success)) {
     N_LOGW("PutData_End slot=%" PRIu64 " npkt=%p fail=io-err", slotID, npkt);
+    ++req->store->nPutDataFails;
   }
 
   rte_pktmbuf_free(Packet_ToMbuf(npkt));
@@ -44,6 +45,7 @@ DiskStore_PutData_Begin(void* npkt0)
                          store->blockSize, DiskStore_PutData_End, (uintptr_t)npkt);
   if (unlikely(res != 0)) {
     N_LOGW("PutData_Begin slot=%" PRIu64 " npkt=%p fail=write(%d)", slotID, npkt, res);
+    ++store->nPutDataFails;
     rte_pktmbuf_free(Packet_ToMbuf(npkt));
   }
 }
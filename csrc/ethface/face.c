@@ -13,7 +13,13 @@ EthRxFlow_RxBurst_Unchecked(RxGroup* rxg, struct rte_mbuf** pkts, uint16_t nPkts
     struct rte_mbuf* m = pkts[i];
     Mbuf_SetTimestamp(m, now);
     m->port = rxf->faceID;
-    rte_pktmbuf_adj(m, rxf->hdrLen);
+  }
+
+  if (unlikely(rxf->pdump != NULL) && nRx > 0) {
+    PdumpSourceRef_Process(rxf->pdump, pkts, nRx);
+  }
+  for (uint16_t i = 0; i < nRx; ++i) {
+    rte_pktmbuf_adj(pkts[i], rxf->hdrLen);
   }
   return nRx;
 }
@@ -25,13 +31,21 @@ EthRxFlow_RxBurst_Checked(RxGroup* rxg, struct rte_mbuf** pkts, uint16_t nPkts)
   uint16_t nInput = rte_eth_rx_burst(rxf->port, rxf->queue, pkts, nPkts);
   uint64_t now = rte_get_tsc_cycles();
 
+  for (uint16_t i = 0; i < nInput; ++i) {
+    struct rte_mbuf* m = pkts[i];
+    Mbuf_SetTimestamp(m, now);
+    m->port = rxf->faceID;
+  }
+
+  if (unlikely(rxf->pdump != NULL) && nInput > 0) {
+    PdumpSourceRef_Process(rxf->pdump, pkts, nInput); // include frames rejected by EthRxMatch
+  }
+
   uint16_t nRx = 0, nRej = 0;
   struct rte_mbuf* rejects[MaxBurstSize];
   for (uint16_t i = 0; i < nInput; ++i) {
     struct rte_mbuf* m = pkts[i];
     if (likely(EthRxMatch_Match(rxf->rxMatch, m))) {
-      Mbuf_SetTimestamp(m, now);
-      m->port = rxf->faceID;
       pkts[nRx++] = m;
     } else {
       rejects[nRej++] = m;
@@ -15,7 +15,8 @@ typedef struct EthRxFlow
   uint16_t port;
   uint16_t queue;
   uint16_t hdrLen;
-  EthRxMatch* rxMatch; // when not flow isolated
+  EthRxMatch* rxMatch;   // when not flow isolated
+  PdumpSourceRef* pdump; // shared by all faces on the port, NULL if unavailable
 } __rte_cache_aligned EthRxFlow;
 
 /** @brief Ethernet face private data. */
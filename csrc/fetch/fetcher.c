@@ -2,6 +2,9 @@
 
 #include "../core/logger.h"
 #include "../ndni/nni.h"
+#include "../ndni/tlv-decoder.h"
+
+#include <unistd.h>
 
 N_LOG_INIT(FetchProc);
 
@@ -59,6 +62,35 @@ FetchProc_Decode(FetchProc* fp, Packet* npkt, FetchLogicRxData* lpkt)
          Nni_Decode(seqNumComp[1], RTE_PTR_ADD(seqNumComp, 2), &lpkt->segNum);
 }
 
+__attribute__((nonnull)) static void
+FetchProc_WriteContent(FetchProc* fp, Packet* npkt, uint64_t segNum)
+{
+  TlvDecoder d;
+  TlvDecoder_Init(&d, Packet_ToMbuf(npkt));
+  uint32_t length0;
+  TlvDecoder_ReadTL(&d, &length0);
+
+  TlvDecoder_EachTL (&d, type, length) {
+    if (type != TtContent) {
+      TlvDecoder_Skip(&d, length);
+      continue;
+    }
+
+    if (likely(length <= fp->segmentLen)) {
+      uint8_t scratch[FetchMaxSegmentLen];
+      const uint8_t* content = TlvDecoder_Read(&d, scratch, length);
+      off_t offset = (off_t)segNum * fp->segmentLen;
+      if (likely(pwrite(fp->fd, content, length, offset) == (ssize_t)length)) {
+        return;
+      }
+    }
+    ++fp->nWriteErrs;
+    N_LOGW("%p write-error seg=%" PRIu64 " length=%" PRIu32 " errno=%d", fp, segNum, length, errno);
+    return;
+  }
+  // no Content element, i.e. empty payload
+}
+
 __attribute__((nonnull)) static uint32_t
 FetchProc_RxBurst(FetchProc* fp)
 {
@@ -71,6 +103,9 @@ FetchProc_RxBurst(FetchProc* fp)
   for (uint16_t i = 0; i < nRx; ++i) {
     bool ok = FetchProc_Decode(fp, npkts[i], &lpkts[count]);
     if (likely(ok)) {
+      if (fp->fd >= 0) {
+        FetchProc_WriteContent(fp, npkts[i], lpkts[count].segNum);
+      }
       ++count;
     }
   }
@@ -8,6 +8,12 @@
 #include "../iface/pktqueue.h"
 #include "logic.h"
 
+enum
+{
+  /** @brief Maximum segment payload length when writing to a file. */
+  FetchMaxSegmentLen = 16384,
+};
+
 /** @brief Fetch procedure that fetches from one prefix. */
 typedef struct FetchProc
 {
@@ -15,6 +21,9 @@ typedef struct FetchProc
   PktQueue rxQueue;
   FetchLogic logic;
   uint8_t pitToken;
+  int fd;              ///< file descriptor for writing segment payloads, -1 to discard
+  uint32_t segmentLen; ///< segment payload length, for computing file offset
+  uint64_t nWriteErrs; ///< number of failed file writes
   InterestTemplate tpl;
 } FetchProc;
 
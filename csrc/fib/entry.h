@@ -4,19 +4,35 @@
 /** @file */
 
 #include "../core/urcu.h"
+#include "../dpdk/tsc.h"
 #include "../iface/faceid.h"
 #include "../strategycode/strategy-code.h"
 #include "enum.h"
 #include <urcu/rculfhash.h>
 
+/** @brief Per-nexthop statistics maintained by forwarding. */
+typedef struct FibNexthopStats
+{
+  TscDuration sRtt;    ///< smoothed RTT, zero if there is no RTT sample
+  TscDuration rttVar;  ///< RTT variation
+  TscDuration rto;     ///< retransmission timeout, zero if there is no RTT sample
+  uint32_t nSatisfied; ///< Interests satisfied by Data
+  uint32_t nTimeouts;  ///< Interests expired without Data or Nack
+  uint32_t nNacks;     ///< Interests answered by Nack
+  char pad_[4];
+} FibNexthopStats;
+
 typedef struct FibEntryDyn
 {
   uint32_t nRxInterests;
   uint32_t nRxData;
   uint32_t nRxNacks;
   uint32_t nTxInterests;
-  char pad_[16];
+  uint32_t nRxCongMarks; ///< Data and Nacks arriving with congestion mark
+  uint32_t scratchGen;   ///< StrategyCodeProg.scratchGen when scratch was last cleared
+  char pad_[8];
   char scratch[FibScratchSize];
+  FibNexthopStats nhStats[FibMaxNexthops]; ///< per-nexthop statistics, same order as nexthops
 } FibEntryDyn;
 static_assert(sizeof(FibEntryDyn) % RTE_CACHE_LINE_SIZE == 0, "");
 
@@ -61,7 +77,7 @@ struct FibEntry
 
   FaceID nexthops[FibMaxNexthops];
 
-  char padB_[32];
+  int64_t params[FibMaxParams]; ///< strategy parameters, in the order of schema properties
   char cachelineB_[0];
   FibEntryDyn dyn[0];
 };
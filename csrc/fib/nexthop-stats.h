@@ -0,0 +1,51 @@
+#ifndef NDNDPDK_FIB_NEXTHOP_STATS_H
+#define NDNDPDK_FIB_NEXTHOP_STATS_H
+
+/** @file */
+
+#include "entry.h"
+
+#define FIB_NEXTHOP_STATS_MAXRTO_MS 60000
+
+/**
+ * @brief Find per-nexthop statistics.
+ * @return statistics of @p nh , or NULL if @p nh is not a nexthop of @p entry .
+ */
+__attribute__((nonnull)) static inline FibNexthopStats*
+FibEntryDyn_FindNexthopStats(FibEntryDyn* dyn, const FibEntry* entry, FaceID nh)
+{
+  for (uint8_t i = 0; i < entry->nNexthops; ++i) {
+    if (entry->nexthops[i] == nh) {
+      return &dyn->nhStats[i];
+    }
+  }
+  return NULL;
+}
+
+/**
+ * @brief Record an RTT sample.
+ * @sa https://tools.ietf.org/html/rfc6298
+ */
+__attribute__((nonnull)) static inline void
+FibNexthopStats_AddRtt(FibNexthopStats* stats, TscDuration rtt)
+{
+  rtt = RTE_MAX(rtt, 1);
+  if (stats->sRtt == 0) {
+    stats->sRtt = rtt;
+    stats->rttVar = rtt / 2;
+  } else {
+    TscDuration delta = stats->sRtt > rtt ? stats->sRtt - rtt : rtt - stats->sRtt;
+    stats->rttVar = (3 * stats->rttVar + delta) / 4;
+    stats->sRtt = (7 * stats->sRtt + rtt) / 8;
+  }
+  stats->rto = stats->sRtt + 4 * stats->rttVar;
+}
+
+/** @brief Back off the RTO upon timeout. */
+__attribute__((nonnull)) static inline void
+FibNexthopStats_Backoff(FibNexthopStats* stats)
+{
+  stats->rto = RTE_MIN(2 * stats->rto, TscDuration_FromMillis(FIB_NEXTHOP_STATS_MAXRTO_MS));
+}
+
+#endif // NDNDPDK_FIB_NEXTHOP_STATS_H
@@ -72,16 +72,48 @@ FileServerFd_UpdateStatx(FileServer* p, FileServerFd* entry, TscTime now, bool*
   return res;
 }
 
+/** @brief Compute last segment number from payload length. */
+static __rte_always_inline uint64_t
+FileServerFd_LastSeg(FileServer* p, uint64_t size)
+{
+  return DIV_CEIL(size, p->segmentLen) - (uint64_t)(size > 0);
+}
+
+/** @brief Prepare FinalBlockId from entry->lastSeg. */
 __attribute__((nonnull)) static inline void
-FileServerFd_PrepapeMeta(FileServer* p, FileServerFd* entry)
+FileServerFd_PrepareFinalBlock(FileServerFd* entry)
+{
+  uint8_t* segment = RTE_PTR_ADD(entry->nameV, entry->versionedL);
+  segment[0] = TtSegmentNameComponent;
+  segment[1] = Nni_Encode(&segment[2], entry->lastSeg);
+  entry->segmentL = entry->versionedL + 2 + segment[1];
+
+  DataEnc_MustPrepareMetaInfo(&entry->meta, ContentBlob, 0,
+                              ((LName){ .length = 2 + segment[1], .value = segment }));
+}
+
+/** @brief Discard cached directory listing. */
+__attribute__((nonnull)) static inline void
+FileServerFd_ClearLs(FileServerFd* entry)
 {
-  entry->lastSeg = DIV_CEIL(entry->st.stx_size, p->segmentLen) - (uint64_t)(entry->st.stx_size > 0);
+  rte_free(entry->lsV);
+  entry->lsV = NULL;
+  entry->lsL = UINT32_MAX;
+}
 
+__attribute__((nonnull)) static inline void
+FileServerFd_PrepapeMeta(FileServer* p, FileServerFd* entry)
+{
   uint16_t nameL = entry->prefixL;
   if (unlikely(FileServerFd_IsDir(entry))) {
     rte_memcpy(RTE_PTR_ADD(entry->nameV, nameL), FileServer_KeywordLs,
                sizeof(FileServer_KeywordLs));
     nameL += sizeof(FileServer_KeywordLs);
+    // listing is gathered upon request, and then lastSeg and FinalBlockId are updated
+    FileServerFd_ClearLs(entry);
+    entry->lastSeg = 0;
+  } else {
+    entry->lastSeg = FileServerFd_LastSeg(p, entry->st.stx_size);
   }
 
   uint8_t* version = RTE_PTR_ADD(entry->nameV, nameL);
@@ -89,13 +121,7 @@ FileServerFd_PrepapeMeta(FileServer* p, FileServerFd* entry)
   version[1] = Nni_Encode(&version[2], entry->version);
   entry->versionedL = (nameL += 2 + version[1]);
 
-  uint8_t* segment = RTE_PTR_ADD(entry->nameV, nameL);
-  segment[0] = TtSegmentNameComponent;
-  segment[1] = Nni_Encode(&segment[2], entry->lastSeg);
-  entry->segmentL = (nameL += 2 + segment[1]);
-
-  DataEnc_MustPrepareMetaInfo(&entry->meta, ContentBlob, 0,
-                              ((LName){ .length = 2 + segment[1], .value = segment }));
+  FileServerFd_PrepareFinalBlock(entry);
 }
 
 __attribute__((nonnull)) static inline FileServerFd*
@@ -183,7 +209,10 @@ FileServerFd_New(FileServer* p, const PName* name, LName prefix, uint64_t hash,
   }
 
   entry->mbuf = mbuf;
+  entry->lsV = NULL;
+  entry->lsL = UINT32_MAX;
   entry->refcnt = 1;
+  entry->mount = mount;
   entry->prefixL = prefix.length;
   rte_memcpy(entry->nameV, prefix.value, prefix.length);
   FileServerFd_PrepapeMeta(p, entry);
@@ -239,6 +268,7 @@ FileServerFd_Unref(FileServer* p, FileServerFd* entry)
   TAILQ_REMOVE(&p->fdQ, evict, queueNode);
   --p->fdQCount;
   close(evict->fd);
+  FileServerFd_ClearLs(evict);
   rte_pktmbuf_free(evict->mbuf);
   ++p->cnt.fdClose;
 }
@@ -251,6 +281,7 @@ FileServerFd_Clear(FileServer* p)
   HASH_ITER (hh, p->fdHt, entry, tmp) {
     N_LOGD("Clear close fd=%d refcnt=%" PRIu16, entry->fd, entry->refcnt);
     close(entry->fd);
+    FileServerFd_ClearLs(entry);
     rte_pktmbuf_free(entry->mbuf);
   }
   HASH_CLEAR(hh, p->fdHt);
@@ -320,11 +351,13 @@ FileServerFd_EncodeMetadata(FileServer* p, FileServerFd* entry, struct rte_mbuf*
   return true;
 }
 
-bool
-FileServerFd_EncodeLs(FileServer* p, FileServerFd* entry, struct rte_mbuf* payload,
-                      uint16_t segmentLen)
+/**
+ * @brief Gather directory listing into entry->lsV.
+ * @return whether success.
+ */
+__attribute__((nonnull)) static bool
+FileServerFd_PrepareLs(FileServer* p, FileServerFd* entry)
 {
-  NDNDPDK_ASSERT(FileServerFd_IsDir(entry));
   int dfd = dup(entry->fd);
   if (unlikely(dfd < 0)) {
     N_LOGD("Ls dup-err fd=%d" N_LOG_ERROR_ERRNO, entry->fd, errno);
@@ -338,7 +371,8 @@ FileServerFd_EncodeLs(FileServer* p, FileServerFd* entry, struct rte_mbuf* paylo
     return false;
   }
 
-  char* value = rte_pktmbuf_mtod(payload, char*);
+  char* value = NULL;
+  size_t capacity = 0;
   size_t off = 0;
   struct dirent* ent = NULL;
   while ((ent = readdir(dir)) != NULL) {
@@ -357,10 +391,20 @@ FileServerFd_EncodeLs(FileServer* p, FileServerFd* entry, struct rte_mbuf* paylo
     if (unlikely(entNameL <= 2 && strspn(ent->d_name, ".") == entNameL)) { // . or ..
       continue;
     }
-    uint16_t lineL = entNameL + (uint16_t)isDir + 1;
-    if (unlikely(off + lineL > segmentLen)) {
-      break;
+    size_t lineL = entNameL + (size_t)isDir + 1;
+    if (unlikely(off + lineL > capacity)) {
+      size_t newCapacity = RTE_MAX(RTE_MAX(2 * capacity, (size_t)p->segmentLen), off + lineL);
+      char* newValue = rte_realloc(value, newCapacity, 0);
+      if (unlikely(newValue == NULL)) {
+        N_LOGW("Ls realloc-err fd=%d capacity=%zu", entry->fd, newCapacity);
+        rte_free(value);
+        closedir(dir);
+        return false;
+      }
+      value = newValue;
+      capacity = newCapacity;
     }
+
     rte_memcpy(&value[off], ent->d_name, entNameL);
     off += entNameL;
     if (isDir) {
@@ -368,9 +412,42 @@ FileServerFd_EncodeLs(FileServer* p, FileServerFd* entry, struct rte_mbuf* paylo
     }
     value[off++] = '\0';
   }
-
   closedir(dir);
-  const char* room = rte_pktmbuf_append(payload, off);
-  NDNDPDK_ASSERT(room != NULL);
+
+  if (unlikely(off >= UINT32_MAX)) {
+    rte_free(value);
+    return false;
+  }
+  entry->lsV = value;
+  entry->lsL = off;
+  entry->lastSeg = FileServerFd_LastSeg(p, off);
+  FileServerFd_PrepareFinalBlock(entry);
+  N_LOGD("Ls prepared fd=%d size=%zu lastseg=%" PRIu64, entry->fd, off, entry->lastSeg);
+  return true;
+}
+
+bool
+FileServerFd_EncodeLs(FileServer* p, FileServerFd* entry, struct rte_mbuf* payload,
+                      uint64_t segment)
+{
+  NDNDPDK_ASSERT(FileServerFd_IsDir(entry));
+  if (entry->lsL == UINT32_MAX && unlikely(!FileServerFd_PrepareLs(p, entry))) {
+    return false;
+  }
+  if (unlikely(segment > entry->lastSeg)) {
+    N_LOGD("Ls fd=%d segment-out-of-range segment=%" PRIu64 " lastseg=%" PRIu64, entry->fd, segment,
+           entry->lastSeg);
+    return false;
+  }
+
+  uint64_t off = segment * p->segmentLen;
+  uint16_t segmentL = RTE_MIN((uint64_t)p->segmentLen, entry->lsL - off);
+  char* room = rte_pktmbuf_append(payload, segmentL);
+  if (unlikely(room == NULL)) {
+    return false;
+  }
+  if (likely(segmentL > 0)) {
+    rte_memcpy(room, &entry->lsV[off], segmentL);
+  }
   return true;
 }
@@ -25,6 +25,9 @@ typedef struct FileServerFd
   uint16_t prefixL;                    ///< mount+path TLV-LENGTH
   uint16_t versionedL;                 ///< mount+path+[32=ls]+version TLV-LENGTH
   uint16_t segmentL;                   ///< mount+path+[32=ls]+version+finalSeg TLV-LENGTH
+  uint8_t mount;                       ///< mount index
+  char* lsV;                           ///< directory listing
+  uint32_t lsL;                        ///< directory listing length, UINT32_MAX if not prepared
   uint8_t nameV[NameMaxLength];        ///< name TLV-VALUE
 } FileServerFd;
 
@@ -95,17 +98,20 @@ __attribute__((nonnull)) bool
 FileServerFd_EncodeMetadata(FileServer* p, FileServerFd* entry, struct rte_mbuf* payload);
 
 /**
- * @brief Encode directory listing.
+ * @brief Encode directory listing segment.
  * @param entry a valid FileServerFd entry.
  * @param payload payload mbuf.
- * @param segmentLen maximum payload length.
+ * @param segment segment number.
  * @pre FileServerFd_IsDir(entry) is true.
+ * @return whether success.
  *
- * This is a limited implementation that returns one segment a subset of directory entries that
- * can fit in one segment. It also depends on dirent.d_type field being available.
+ * The directory listing is gathered upon first request and cached in @p entry until the
+ * directory is changed. It is then split into segments of @c p->segmentLen octets.
+ * @c entry->lastSeg and @c entry->meta are updated to reflect the number of segments.
+ * This depends on dirent.d_type field being available.
  */
 __attribute__((nonnull)) bool
 FileServerFd_EncodeLs(FileServer* p, FileServerFd* entry, struct rte_mbuf* payload,
-                      uint16_t segmentLen);
+                      uint64_t segment);
 
 #endif // NDNDPDK_FILESERVER_FD_H
@@ -9,7 +9,6 @@
 N_LOG_INIT(FileServer);
 
 static DataEnc_MetaInfoBuffer(15) MetaInfo_Metadata;
-static DataEnc_MetaInfoBuffer(15) MetaInfo_Ls;
 static DataEnc_MetaInfoBuffer(15) MetaInfo_Nack;
 
 RTE_INIT(InitMetaInfo)
@@ -18,7 +17,6 @@ RTE_INIT(InitMetaInfo)
   LName finalBlock = (LName){ .length = sizeof(segment0), .value = segment0 };
   DataEnc_MustPrepareMetaInfo(&MetaInfo_Metadata, ContentBlob, FileServerMetadataFreshness,
                               finalBlock);
-  DataEnc_MustPrepareMetaInfo(&MetaInfo_Ls, ContentBlob, 0, finalBlock);
   DataEnc_MustPrepareMetaInfo(&MetaInfo_Nack, ContentNack, FileServerMetadataFreshness,
                               (LName){ 0 });
 }
@@ -100,6 +98,16 @@ FileServerRx_Read(FileServer* p, RxBurstCtx* ctx, FileServerRequestName rn)
   Packet* npkt = Packet_FromMbuf(interest);
   PInterest* pi = Packet_GetInterestHdr(npkt);
 
+  if (p->signedCache != NULL) {
+    Packet* data = FileServer_FindSigned(p, &pi->name, Packet_GetLpL3Hdr(npkt), ctx->now);
+    if (data != NULL) {
+      N_LOGV("I signed-cache-hit");
+      ctx->data[ctx->dataCount++] = data;
+      ctx->discard[ctx->discardIndex++] = interest;
+      return;
+    }
+  }
+
   LName prefix = FileServer_GetPrefix(&pi->name);
   struct rte_mbuf* payload = ctx->payload[ctx->payloadIndex];
   payload->data_off = p->payloadHeadroom;
@@ -155,12 +163,8 @@ __attribute__((nonnull)) static __rte_noinline void
 FileServerRx_Ls(FileServer* p, RxBurstCtx* ctx, FileServerRequestName rn)
 {
   ++p->cnt.reqLs;
-  if (rn.segment != 0) {
-    N_LOGD("Ls drop=not-segment0");
-    return;
-  }
-
   struct rte_mbuf* interest = ctx->interest[ctx->interestIndex];
+  ctx->discard[ctx->discardIndex++] = interest;
   Packet* npkt = Packet_FromMbuf(interest);
   PInterest* pi = Packet_GetInterestHdr(npkt);
   LName name = PName_ToLName(&pi->name);
@@ -182,21 +186,19 @@ FileServerRx_Ls(FileServer* p, RxBurstCtx* ctx, FileServerRequestName rn)
 
   struct rte_mbuf* payload = ctx->payload[ctx->payloadIndex];
   payload->data_off = p->payloadHeadroom;
-  bool ok = FileServerFd_EncodeLs(p, fd, payload, p->segmentLen);
-  FileServerFd_Unref(p, fd);
-  if (unlikely(!ok)) {
-    goto ENCERR;
+  Packet* data = NULL;
+  if (likely(FileServerFd_EncodeLs(p, fd, payload, rn.segment))) {
+    data = FileServer_EncodeData(p, fd->mount, name, (LName){ 0 }, &fd->meta, payload,
+                                 Packet_GetLpL3Hdr(npkt), ctx->now, false);
   }
-
-  Packet* data = DataEnc_EncodePayload(name, (LName){ 0 }, &MetaInfo_Ls, payload);
+  FileServerFd_Unref(p, fd);
   if (unlikely(data == NULL)) {
     goto ENCERR;
   }
   ++ctx->payloadIndex;
-
-  Mbuf_SetTimestamp(payload, ctx->now);
-  *Packet_GetLpL3Hdr(data) = *Packet_GetLpL3Hdr(npkt);
-  ctx->data[ctx->dataCount++] = data;
+  if (data != FileServer_Offloaded) {
+    ctx->data[ctx->dataCount++] = data;
+  }
   return;
 
 ENCERR:
@@ -223,13 +225,18 @@ FileServerRx_Metadata(FileServer* p, RxBurstCtx* ctx, FileServerRequestName rn)
   struct rte_mbuf* payload = ctx->payload[ctx->payloadIndex];
   payload->data_off = p->payloadHeadroom;
   const void* metaInfo = NULL;
+  int mount = -1;
 
   if (unlikely(fd == FileServer_NotFound)) {
     metaInfo = &MetaInfo_Nack;
+    mount = LNamePrefixFilter_Find(FileServer_GetPrefix(&pi->name), FileServerMaxMounts,
+                                   p->mountPrefixL, p->mountPrefixV);
   } else if (unlikely((rn.kind & FileServerRequestLs) != 0 && !FileServerFd_IsDir(fd))) {
+    mount = fd->mount;
     FileServerFd_Unref(p, fd);
     metaInfo = &MetaInfo_Nack;
   } else {
+    mount = fd->mount;
     bool ok = FileServerFd_EncodeMetadata(p, fd, payload);
     FileServerFd_Unref(p, fd);
     if (unlikely(!ok)) {
@@ -251,15 +258,15 @@ FileServerRx_Metadata(FileServer* p, RxBurstCtx* ctx, FileServerRequestName rn)
   suffixV[suffix.length++] = 1;
   suffixV[suffix.length++] = 0;
 
-  Packet* data = DataEnc_EncodePayload(name, suffix, metaInfo, payload);
+  Packet* data = FileServer_EncodeData(p, mount, name, suffix, metaInfo, payload,
+                                       Packet_GetLpL3Hdr(npkt), ctx->now, false);
   if (unlikely(data == NULL)) {
     goto ENCERR;
   }
   ++ctx->payloadIndex;
-
-  Mbuf_SetTimestamp(payload, ctx->now);
-  *Packet_GetLpL3Hdr(data) = *Packet_GetLpL3Hdr(npkt);
-  ctx->data[ctx->dataCount++] = data;
+  if (data != FileServer_Offloaded) {
+    ctx->data[ctx->dataCount++] = data;
+  }
   return;
 
 ENCERR:
@@ -0,0 +1,218 @@
+#include "server.h"
+
+#include "../core/logger.h"
+#include "../ndni/tlv-decoder.h"
+
+N_LOG_INIT(FileServer);
+
+__attribute__((nonnull)) static inline void
+FileServerSign_SetL3(Packet* data, const LpL3* lpl3, TscTime now)
+{
+  Mbuf_SetTimestamp(Packet_ToMbuf(data), now);
+  *Packet_GetLpL3Hdr(data) = *lpl3;
+}
+
+/**
+ * @brief Determine whether precomputed Data has the specified name.
+ * @param data a Data packet in the signed cache, not parsed.
+ */
+__attribute__((nonnull)) static bool
+FileServerSign_MatchName(Packet* data, LName name)
+{
+  TlvDecoder d;
+  TlvDecoder_Init(&d, Packet_ToMbuf(data));
+  uint32_t length0, type0 = TlvDecoder_ReadTL(&d, &length0);
+  uint32_t nameL, nameT = TlvDecoder_ReadTL(&d, &nameL);
+  if (unlikely(type0 != TtData || nameT != TtName || nameL != name.length)) {
+    return false;
+  }
+  uint8_t scratch[NameMaxLength];
+  const uint8_t* nameV = TlvDecoder_Read(&d, scratch, nameL);
+  return nameV != NULL && memcmp(nameV, name.value, nameL) == 0;
+}
+
+__attribute__((nonnull)) static Packet*
+FileServerSign_Clone(FileServer* p, Packet* data, const LpL3* lpl3, TscTime now)
+{
+  Packet* clone = Packet_Clone(data, &p->mp, Face_PacketTxAlign(p->face));
+  if (unlikely(clone == NULL)) {
+    return NULL;
+  }
+  FileServerSign_SetL3(clone, lpl3, now);
+  return clone;
+}
+
+Packet*
+FileServer_FindSigned(FileServer* p, const PName* name, const LpL3* lpl3, TscTime now)
+{
+  LName lname = PName_ToLName(name);
+  Packet* data = p->signedCache[LName_ComputeHash(lname) & p->signedCacheMask];
+  if (data == NULL || !FileServerSign_MatchName(data, lname)) {
+    return NULL;
+  }
+  Packet* clone = FileServerSign_Clone(p, data, lpl3, now);
+  if (likely(clone != NULL)) {
+    ++p->cnt.signedCacheHit;
+  }
+  return clone;
+}
+
+/**
+ * @brief Insert signed Data into signed cache.
+ * @return Data to be transmitted, which is either a clone of @p data or @p data itself.
+ */
+__attribute__((nonnull)) static Packet*
+FileServerSign_Insert(FileServer* p, LName name, Packet* data, const LpL3* lpl3, TscTime now)
+{
+  Packet* clone = FileServerSign_Clone(p, data, lpl3, now);
+  if (unlikely(clone == NULL)) {
+    FileServerSign_SetL3(data, lpl3, now);
+    return data;
+  }
+
+  Packet** slot = &p->signedCache[LName_ComputeHash(name) & p->signedCacheMask];
+  if (*slot != NULL) {
+    rte_pktmbuf_free(Packet_ToMbuf(*slot));
+  }
+  *slot = data;
+  ++p->cnt.signedCacheInsert;
+  return clone;
+}
+
+void
+FileServer_ClearSigned(FileServer* p)
+{
+  if (p->signedCache == NULL) {
+    return;
+  }
+  for (uint32_t i = 0; i <= p->signedCacheMask; ++i) {
+    Packet* data = p->signedCache[i];
+    if (data != NULL) {
+      rte_pktmbuf_free(Packet_ToMbuf(data));
+      p->signedCache[i] = NULL;
+    }
+  }
+}
+
+/**
+ * @brief Stage Data for signing in cryptodev.
+ * @return whether success; if false, signing should be performed in the current thread.
+ */
+__attribute__((nonnull)) static bool
+FileServerSign_Offload(FileServer* p, const FileServerSigner* s, struct rte_mbuf* m,
+                       const LpL3* lpl3, TscTime now)
+{
+  if (unlikely(p->nCryptoStaged >= RTE_DIM(p->cryptoStaged))) {
+    return false;
+  }
+  struct rte_crypto_op* op = rte_crypto_op_alloc(p->cryptoOpPool, RTE_CRYPTO_OP_TYPE_SYMMETRIC);
+  if (unlikely(op == NULL)) {
+    return false;
+  }
+
+  uint32_t signedL = m->pkt_len;
+  uint8_t* sigValue = NULL;
+  Packet* data = DataEnc_AppendSigValue(m, s->sigValueL, &sigValue);
+  if (unlikely(data == NULL)) {
+    rte_crypto_op_free(op);
+    return false;
+  }
+  FileServerSign_SetL3(data, lpl3, now);
+
+  op->sym->m_src = m;
+  op->sym->xform = (struct rte_crypto_sym_xform*)&s->xform;
+  op->sym->auth.data.offset = m->pkt_len - 2 - s->sigValueL - signedL;
+  op->sym->auth.data.length = signedL;
+  op->sym->auth.digest.data = sigValue;
+  p->cryptoStaged[p->nCryptoStaged++] = op;
+  ++p->cnt.signOffload;
+  return true;
+}
+
+Packet*
+FileServer_EncodeData(FileServer* p, int mount, LName prefix, LName suffix, const void* metaBuf,
+                      struct rte_mbuf* payload, const LpL3* lpl3, TscTime now, bool cacheable)
+{
+  const FileServerSigner* s = mount < 0 ? NULL : p->signer[mount];
+  if (s == NULL) {
+    Packet* data = DataEnc_EncodePayload(prefix, suffix, metaBuf, payload);
+    if (likely(data != NULL)) {
+      FileServerSign_SetL3(data, lpl3, now);
+    }
+    return data;
+  }
+
+  LName sigInfo = (LName){ .length = s->sigInfoL, .value = s->sigInfoV };
+  if (unlikely(!DataEnc_EncodeSignedPortion(prefix, suffix, metaBuf, sigInfo, payload))) {
+    return NULL;
+  }
+
+  if (s->offload && FileServerSign_Offload(p, s, payload, lpl3, now)) {
+    return FileServer_Offloaded;
+  }
+
+  uint8_t sig[FileServerMaxSigValueLen];
+  size_t sigLen = FileServerSigner_Sign(s, rte_pktmbuf_mtod(payload, const uint8_t*),
+                                        payload->pkt_len, sig);
+  if (unlikely(sigLen == 0)) {
+    ++p->cnt.signFail;
+    return NULL;
+  }
+  ++p->cnt.signInline;
+
+  uint8_t* sigValue = NULL;
+  Packet* data = DataEnc_AppendSigValue(payload, (uint8_t)sigLen, &sigValue);
+  if (unlikely(data == NULL)) {
+    return NULL;
+  }
+  rte_memcpy(sigValue, sig, sigLen);
+
+  if (cacheable && s->precompute && p->signedCache != NULL) {
+    // cacheable Data is a file segment, whose name is entirely in prefix
+    NDNDPDK_ASSERT(suffix.length == 0);
+    Mbuf_SetTimestamp(payload, now);
+    return FileServerSign_Insert(p, prefix, data, lpl3, now);
+  }
+
+  FileServerSign_SetL3(data, lpl3, now);
+  return data;
+}
+
+uint32_t
+FileServer_CryptoBurst(FileServer* p)
+{
+  if (p->nCryptoStaged > 0) {
+    uint16_t nEnq =
+      rte_cryptodev_enqueue_burst(p->cqp.dev, p->cqp.qp, p->cryptoStaged, p->nCryptoStaged);
+    for (uint16_t i = nEnq; i < p->nCryptoStaged; ++i) {
+      struct rte_crypto_op* op = p->cryptoStaged[i];
+      rte_pktmbuf_free(op->sym->m_src);
+      rte_crypto_op_free(op);
+    }
+    if (unlikely(nEnq < p->nCryptoStaged)) {
+      N_LOGD("CryptoBurst drop=enqueue-full count=%" PRIu16, p->nCryptoStaged - nEnq);
+      p->cnt.signFail += p->nCryptoStaged - nEnq;
+    }
+    p->nCryptoStaged = 0;
+  }
+
+  struct rte_crypto_op* ops[MaxBurstSize];
+  uint16_t nDeq = rte_cryptodev_dequeue_burst(p->cqp.dev, p->cqp.qp, ops, RTE_DIM(ops));
+  Packet* data[MaxBurstSize];
+  uint16_t nData = 0;
+  for (uint16_t i = 0; i < nDeq; ++i) {
+    struct rte_crypto_op* op = ops[i];
+    struct rte_mbuf* m = op->sym->m_src;
+    if (likely(CryptoOp_GetStatus(op) == RTE_CRYPTO_OP_STATUS_SUCCESS)) {
+      data[nData++] = Packet_FromMbuf(m);
+    } else {
+      N_LOGD("CryptoBurst drop=op-error status=%d", (int)CryptoOp_GetStatus(op));
+      ++p->cnt.signFail;
+      rte_pktmbuf_free(m);
+    }
+    rte_crypto_op_free(op);
+  }
+
+  Face_TxBurst(p->face, data, nData);
+  return nDeq;
+}
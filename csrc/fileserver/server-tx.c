@@ -79,19 +79,20 @@ FileServerTx_ProcessCqe(FileServer* p, TxBurstCtx* ctx, uint32_t index)
     totalLen -= segmentLen;
     rte_pktmbuf_append(payload, segmentLen);
 
-    Packet* data = DataEnc_EncodePayload(name, (LName){ 0 }, &fd->meta, payload);
+    LpL3 dataL3 = *Packet_GetLpL3Hdr(interest);
+    dataL3.congMark = RTE_MAX(dataL3.congMark, ctx->congMark);
+    Packet* data = FileServer_EncodeData(p, fd->mount, name, (LName){ 0 }, &fd->meta, payload,
+                                         &dataL3, ctx->now, true);
     if (unlikely(data == NULL)) {
       N_LOGD("CQE drop=dataenc-error");
       ctx->discard[--ctx->discardPayloadIndex] = payload;
       continue;
     }
 
-    Mbuf_SetTimestamp(payload, ctx->now);
-    LpL3* dataL3 = Packet_GetLpL3Hdr(data);
-    *dataL3 = *Packet_GetLpL3Hdr(interest);
-    dataL3->congMark = RTE_MAX(dataL3->congMark, ctx->congMark);
     ctx->congMark = 0;
-    ctx->data[ctx->nData++] = data;
+    if (data != FileServer_Offloaded) {
+      ctx->data[ctx->nData++] = data;
+    }
   }
 
 FINISH:
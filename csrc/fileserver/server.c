@@ -22,9 +22,18 @@ FileServer_Run(FileServer* p)
   while (ThreadCtrl_Continue(p->ctrl, nProcessed)) {
     nProcessed += FileServer_RxBurst(p);
     nProcessed += FileServer_TxBurst(p);
+    if (p->cryptoOpPool != NULL) {
+      nProcessed += FileServer_CryptoBurst(p);
+    }
   }
 
+  if (p->cryptoOpPool != NULL) {
+    // flush staged crypto operations and transmit Data already signed
+    while (FileServer_CryptoBurst(p) > 0) {
+    }
+  }
   io_uring_queue_exit(&p->uring);
   FileServerFd_Clear(p);
+  FileServer_ClearSigned(p);
   return 0;
 }
@@ -7,6 +7,7 @@
 #include "../iface/face.h"
 #include "../iface/pktqueue.h"
 #include "enum.h"
+#include "signer.h"
 #include <liburing.h>
 
 typedef struct FileServerFd FileServerFd;
@@ -24,8 +25,18 @@ typedef struct FileServerCounters
   uint64_t uringSubmitWait;
   uint64_t sqeSubmit;
   uint64_t cqeFail;
+  uint64_t signInline;
+  uint64_t signOffload;
+  uint64_t signFail;
+  uint64_t signedCacheHit;
+  uint64_t signedCacheInsert;
 } FileServerCounters;
 
+enum
+{
+  FileServerCryptoStageCapacity = 2 * MaxBurstSize * FileServerMaxIovecs,
+};
+
 /** @brief File server. */
 typedef struct FileServer
 {
@@ -53,6 +64,15 @@ typedef struct FileServer
   uint16_t mountPrefixL[FileServerMaxMounts];
   uint8_t mountPrefixV[FileServerMaxMounts * NameMaxLength];
 
+  FileServerSigner* signer[FileServerMaxMounts]; ///< NULL means Null signature
+  Packet** signedCache;                          ///< precomputed signed Data, indexed by name hash
+  uint32_t signedCacheMask;                      ///< signedCache capacity minus one
+  PacketMempools mp;                             ///< mempools for cloning precomputed Data
+  struct rte_mempool* cryptoOpPool;              ///< NULL if signing offload is disabled
+  CryptoQueuePair cqp;
+  uint16_t nCryptoStaged; ///< cryptoStaged[:nCryptoStaged] are pending enqueue
+  struct rte_crypto_op* cryptoStaged[FileServerCryptoStageCapacity];
+
   uint32_t uringCapacity;
   uint32_t nFdHtBuckets;
 } FileServer;
@@ -63,6 +83,42 @@ FileServer_RxBurst(FileServer* p);
 __attribute__((nonnull)) uint32_t
 FileServer_TxBurst(FileServer* p);
 
+/** @brief Sentinel value to indicate Data has been passed to cryptodev. */
+#define FileServer_Offloaded ((Packet*)(uintptr_t)1)
+
+/**
+ * @brief Encode Data with payload and sign it according to mount configuration.
+ * @param mount mount index, or -1 for Null signature.
+ * @param lpl3 LpL3 header to be copied into Data.
+ * @param cacheable whether Data may be inserted into signed cache.
+ * @return encoded packet ready for transmission.
+ * @retval NULL encoding failure; @p payload is still owned by the caller.
+ * @retval FileServer_Offloaded Data will be transmitted in @c FileServer_CryptoBurst .
+ */
+__attribute__((nonnull)) Packet*
+FileServer_EncodeData(FileServer* p, int mount, LName prefix, LName suffix, const void* metaBuf,
+                      struct rte_mbuf* payload, const LpL3* lpl3, TscTime now, bool cacheable);
+
+/**
+ * @brief Retrieve precomputed signed Data.
+ * @param name Interest name.
+ * @param lpl3 LpL3 header to be copied into Data.
+ * @return cloned Data ready for transmission, or NULL if not found.
+ */
+__attribute__((nonnull)) Packet*
+FileServer_FindSigned(FileServer* p, const PName* name, const LpL3* lpl3, TscTime now);
+
+/** @brief Release precomputed signed Data. */
+__attribute__((nonnull)) void
+FileServer_ClearSigned(FileServer* p);
+
+/**
+ * @brief Enqueue staged crypto operations, and transmit Data signed by cryptodev.
+ * @return number of dequeued crypto operations.
+ */
+__attribute__((nonnull)) uint32_t
+FileServer_CryptoBurst(FileServer* p);
+
 __attribute__((nonnull)) int
 FileServer_Run(FileServer* p);
 
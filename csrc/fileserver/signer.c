@@ -0,0 +1,109 @@
+#include "signer.h"
+#include "../core/logger.h"
+#include "../ndni/an.h"
+
+N_LOG_INIT(FileServer);
+
+static bool
+FileServerSigner_InitHmac(FileServerSigner* s, const uint8_t* key, size_t keyLen)
+{
+  if (unlikely(keyLen == 0 || keyLen > sizeof(s->hmacKey))) {
+    return false;
+  }
+  s->key = EVP_PKEY_new_raw_private_key(EVP_PKEY_HMAC, NULL, key, keyLen);
+  if (unlikely(s->key == NULL)) {
+    return false;
+  }
+  s->sigValueL = 32;
+
+  rte_memcpy(s->hmacKey, key, keyLen);
+  s->xform = (const struct rte_crypto_sym_xform){ 0 };
+  s->xform.type = RTE_CRYPTO_SYM_XFORM_AUTH;
+  s->xform.auth.op = RTE_CRYPTO_AUTH_OP_GENERATE;
+  s->xform.auth.algo = RTE_CRYPTO_AUTH_SHA256_HMAC;
+  s->xform.auth.key.data = s->hmacKey;
+  s->xform.auth.key.length = keyLen;
+  s->xform.auth.digest_length = s->sigValueL;
+  return true;
+}
+
+static bool
+FileServerSigner_InitEcdsa(FileServerSigner* s, const uint8_t* key, size_t keyLen)
+{
+  if (unlikely(s->offload)) {
+    N_LOGE("Signer_Init ECDSA cannot be offloaded");
+    return false;
+  }
+  const uint8_t* der = key;
+  s->key = d2i_AutoPrivateKey(NULL, &der, keyLen);
+  if (unlikely(s->key == NULL || EVP_PKEY_base_id(s->key) != EVP_PKEY_EC)) {
+    return false;
+  }
+  int size = EVP_PKEY_size(s->key);
+  if (unlikely(size <= 0 || size > FileServerMaxSigValueLen)) {
+    return false;
+  }
+  s->sigValueL = size;
+  return true;
+}
+
+bool
+FileServerSigner_Init(FileServerSigner* s, const uint8_t* key, size_t keyLen)
+{
+  s->key = NULL;
+  bool ok = false;
+  switch (s->sigType) {
+    case SigSha256:
+      s->sigValueL = 32;
+      s->xform = theSha256DigestXform;
+      ok = true;
+      break;
+    case SigHmacWithSha256:
+      ok = key != NULL && FileServerSigner_InitHmac(s, key, keyLen);
+      break;
+    case SigSha256WithEcdsa:
+      ok = key != NULL && FileServerSigner_InitEcdsa(s, key, keyLen);
+      break;
+  }
+
+  if (unlikely(!ok)) {
+    N_LOGE("Signer_Init error sig-type=%" PRIu8, s->sigType);
+    FileServerSigner_Close(s);
+  }
+  return ok;
+}
+
+void
+FileServerSigner_Close(FileServerSigner* s)
+{
+  if (s->key != NULL) {
+    EVP_PKEY_free(s->key);
+    s->key = NULL;
+  }
+  memset(s->hmacKey, 0, sizeof(s->hmacKey));
+}
+
+size_t
+FileServerSigner_Sign(const FileServerSigner* s, const uint8_t* input, size_t inputLen,
+                      uint8_t sig[FileServerMaxSigValueLen])
+{
+  if (s->key == NULL) {
+    unsigned int sigLen = 0;
+    if (unlikely(EVP_Digest(input, inputLen, sig, &sigLen, EVP_sha256(), NULL) != 1)) {
+      return 0;
+    }
+    return sigLen;
+  }
+
+  EVP_MD_CTX* ctx = EVP_MD_CTX_new();
+  if (unlikely(ctx == NULL)) {
+    return 0;
+  }
+  size_t sigLen = FileServerMaxSigValueLen;
+  if (unlikely(EVP_DigestSignInit(ctx, NULL, EVP_sha256(), NULL, s->key) != 1 ||
+               EVP_DigestSign(ctx, sig, &sigLen, input, inputLen) != 1)) {
+    sigLen = 0;
+  }
+  EVP_MD_CTX_free(ctx);
+  return sigLen;
+}
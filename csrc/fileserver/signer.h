@@ -0,0 +1,47 @@
+#ifndef NDNDPDK_FILESERVER_SIGNER_H
+#define NDNDPDK_FILESERVER_SIGNER_H
+
+/** @file */
+
+#include "../dpdk/cryptodev.h"
+#include "enum.h"
+#include <openssl/evp.h>
+
+/** @brief Data signer of a mount. */
+typedef struct FileServerSigner
+{
+  struct rte_crypto_sym_xform xform;         ///< cryptodev transform, used if @c offload is set
+  EVP_PKEY* key;                             ///< private key or HMAC key, NULL for DigestSha256
+  uint8_t sigType;                           ///< SigType
+  uint8_t sigValueL;                         ///< maximum DSigValue TLV-LENGTH
+  bool offload;                              ///< whether to offload signing to cryptodev
+  bool precompute;                           ///< whether to cache signed segments
+  uint16_t sigInfoL;                         ///< DSigInfo TLV length
+  uint8_t sigInfoV[FileServerMaxSigInfoLen]; ///< DSigInfo TLV
+  uint8_t hmacKey[FileServerMaxHmacKeyLen];  ///< HMAC key referenced by @c xform
+} FileServerSigner;
+
+/**
+ * @brief Initialize signer key.
+ * @param key HMAC secret, or DER encoded private key for ECDSA, ignored for DigestSha256.
+ * @pre sigType, offload, precompute, sigInfoL, sigInfoV are assigned.
+ * @return whether success.
+ */
+__attribute__((nonnull(1))) bool
+FileServerSigner_Init(FileServerSigner* s, const uint8_t* key, size_t keyLen);
+
+/** @brief Release signer key. */
+__attribute__((nonnull)) void
+FileServerSigner_Close(FileServerSigner* s);
+
+/**
+ * @brief Compute signature in the current thread.
+ * @param input signed portion.
+ * @param[out] sig signature buffer.
+ * @return signature length, or 0 upon failure.
+ */
+__attribute__((nonnull)) size_t
+FileServerSigner_Sign(const FileServerSigner* s, const uint8_t* input, size_t inputLen,
+                      uint8_t sig[FileServerMaxSigValueLen]);
+
+#endif // NDNDPDK_FILESERVER_SIGNER_H
@@ -2,6 +2,7 @@
 #include "strategy.h"
 
 #include "../core/logger.h"
+#include "../fib/nexthop-stats.h"
 #include "../pcct/pit-iterator.h"
 
 N_LOG_INIT(FwFwd);
@@ -32,6 +33,30 @@ FwFwd_DataNeedDigest(FwFwd* fwd, FwFwdCtx* ctx)
   }
 }
 
+/** @brief Update statistics of the nexthop that returned Data. */
+__attribute__((nonnull)) static void
+FwFwd_DataUpdateNexthopStats(FwFwd* fwd, FwFwdCtx* ctx)
+{
+  FibNexthopStats* stats =
+    FibEntryDyn_FindNexthopStats(ctx->fibEntryDyn, ctx->fibEntry, ctx->rxFace);
+  if (unlikely(stats == NULL)) {
+    return;
+  }
+  ++stats->nSatisfied;
+
+  PitUpIt it;
+  for (PitUpIt_Init(&it, ctx->pitEntry); PitUpIt_Valid(&it); PitUpIt_Next(&it)) {
+    if (it.up->face != ctx->rxFace) {
+      continue;
+    }
+    // RTT sample is ambiguous if Interest has been retransmitted
+    if (likely(it.up->nTx == 1)) {
+      FibNexthopStats_AddRtt(stats, ctx->rxTime - it.up->lastTx);
+    }
+    break;
+  }
+}
+
 __attribute__((nonnull)) static void
 FwFwd_DataSatisfy(FwFwd* fwd, FwFwdCtx* ctx)
 {
@@ -73,6 +98,8 @@ FwFwd_DataSatisfy(FwFwd* fwd, FwFwdCtx* ctx)
 
   if (likely(ctx->fibEntry != NULL)) {
     ++ctx->fibEntryDyn->nRxData;
+    ctx->fibEntryDyn->nRxCongMarks += (uint32_t)(upCongMark != 0);
+    FwFwd_DataUpdateNexthopStats(fwd, ctx);
     uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
     N_LOGD("^ fib-entry-depth=%" PRIu8 " sg-id=%d sg-res=%" PRIu64, ctx->fibEntry->nComps,
            ctx->fibEntry->strategy->id, res);
@@ -2,6 +2,7 @@
 #include "strategy.h"
 
 #include "../core/logger.h"
+#include "../diskstore/diskstore.h"
 
 N_LOG_INIT(FwFwd);
 
@@ -81,16 +82,57 @@ FwFwd_InterestForward(FwFwd* fwd, FwFwdCtx* ctx)
   N_LOGD("^ pit-entry=%p(%s)", ctx->pitEntry, PitEntry_ToDebugString(ctx->pitEntry));
 
   uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
-  NULLize(ctx->pitEntry); // strategy may have deleted PIT entry via SgReturnNacks
-  N_LOGD("^ sg-res=%" PRIu64 " sg-forwarded=%d", res, ctx->nForwarded);
+  N_LOGD("^ sg-res=%" PRIu64 " sg-forwarded=%d sg-nack-returned=%d", res, ctx->nForwarded,
+         (int)ctx->nackReturned);
+  if (ctx->nackReturned) {
+    Pit_Erase(fwd->pit, ctx->pitEntry);
+  }
+  NULLize(ctx->pitEntry);
   if (unlikely(ctx->nForwarded == 0)) {
     ++fwd->nSgNoFwd;
   }
 }
 
+/** @brief Release Data packet retrieved from DiskStore, if it has not been moved into CS. */
+__attribute__((nonnull)) static __rte_always_inline void
+FwFwd_InterestReleaseDiskData(PInterest* interest)
+{
+  if (likely(interest->diskSlot == 0)) {
+    return;
+  }
+  if (interest->diskData != NULL) {
+    rte_pktmbuf_free(Packet_ToMbuf(interest->diskData));
+    interest->diskData = NULL;
+  }
+  interest->diskSlot = 0;
+}
+
+__attribute__((nonnull)) static void
+FwFwd_InterestHitCsDisk(FwFwd* fwd, FwFwdCtx* ctx, CsEntry* csEntry)
+{
+  struct rte_mbuf* dataBuf = rte_pktmbuf_alloc(fwd->mp.packet);
+  if (unlikely(dataBuf == NULL)) {
+    N_LOGD("^ cs-entry=%p drop=alloc-error", csEntry);
+    rte_pktmbuf_free(ctx->pkt);
+    NULLize(ctx->pkt);
+    return;
+  }
+
+  N_LOGD("^ cs-entry=%p helper=disk slot=%" PRIu64, csEntry, csEntry->diskSlot);
+  // Interest will be re-processed after DiskStore enqueues it to the Interest queue
+  DiskStore_GetData(fwd->cs->diskStore, csEntry->diskSlot, csEntry->dataLen, ctx->npkt, dataBuf,
+                    fwd->queueI.ring);
+  NULLize(ctx->npkt); // npkt is now owned by DiskStore
+}
+
 __attribute__((nonnull)) static void
 FwFwd_InterestHitCs(FwFwd* fwd, FwFwdCtx* ctx, CsEntry* csEntry)
 {
+  if (unlikely(csEntry->data == NULL)) {
+    FwFwd_InterestHitCsDisk(fwd, ctx, csEntry);
+    return;
+  }
+
   Packet* outNpkt = Packet_Clone(csEntry->data, &fwd->mp, Face_PacketTxAlign(ctx->rxFace));
   N_LOGD("^ cs-entry=%p data-to=%" PRI_FaceID " npkt=%p dn-token=" PRI_LpPitToken, csEntry,
          ctx->rxFace, outNpkt, LpPitToken_Fmt(&ctx->rxToken));
@@ -113,8 +155,10 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx)
   PInterest* interest = Packet_GetInterestHdr(ctx->npkt);
   NDNDPDK_ASSERT(interest->hopLimit > 0);
 
-  N_LOGD("RxInterest interest-from=%" PRI_FaceID " npkt=%p dn-token=" PRI_LpPitToken, ctx->rxFace,
-         ctx->npkt, LpPitToken_Fmt(&ctx->rxToken));
+  N_LOGD("RxInterest interest-from=%" PRI_FaceID " npkt=%p dn-token=" PRI_LpPitToken
+         " disk-slot=%" PRIu64 " disk-data=%p",
+         ctx->rxFace, ctx->npkt, LpPitToken_Fmt(&ctx->rxToken), interest->diskSlot,
+         interest->diskData);
 
   if (unlikely(fwd->crypto == NULL && interest->name.hasDigestComp)) {
     N_LOGD("^ drop=no-crypto-helper");
@@ -128,6 +172,7 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx)
   FwFwdCtx_SetFibEntry(ctx, FwFwd_InterestLookupFib(fwd, ctx->npkt, &ctx->nhFlt));
   if (unlikely(ctx->fibEntry == NULL)) {
     N_LOGD("^ drop=no-FIB-match nack-to=%" PRI_FaceID, ctx->rxFace);
+    FwFwd_InterestReleaseDiskData(interest);
     FwFwd_InterestRejectNack(fwd, ctx, NackNoRoute);
     ++fwd->nNoFibMatch;
     rcu_read_unlock();
@@ -139,6 +184,7 @@ FwFwd_RxInterest(FwFwd* fwd, FwFwdCtx* ctx)
 
   // lookup PIT-CS
   PitInsertResult pitIns = Pit_Insert(fwd->pit, ctx->npkt, ctx->fibEntry);
+  FwFwd_InterestReleaseDiskData(interest);
   switch (pitIns.kind) {
     case PIT_INSERT_PIT: {
       ctx->pitEntry = pitIns.pitEntry;
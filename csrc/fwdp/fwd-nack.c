@@ -2,12 +2,14 @@
 #include "strategy.h"
 
 #include "../core/logger.h"
+#include "../fib/nexthop-stats.h"
 #include "../pcct/pit-iterator.h"
 
 N_LOG_INIT(FwFwd);
 
 __attribute__((nonnull)) static void
-FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, uint8_t nackHopLimit)
+FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, uint8_t nackHopLimit,
+              uint8_t upCongMark)
 {
   PitDnIt it;
   for (PitDnIt_Init(&it, pitEntry); PitDnIt_Valid(&it); PitDnIt_Next(&it)) {
@@ -38,7 +40,9 @@ FwFwd_TxNacks(FwFwd* fwd, PitEntry* pitEntry, TscTime now, NackReason reason, ui
     NDNDPDK_ASSERT(output !=
                    NULL); // cannot fail because Interest_ModifyGuiders result is already aligned
 
-    Packet_GetLpL3Hdr(output)->pitToken = dn->token;
+    LpL3* lpl3 = Packet_GetLpL3Hdr(output);
+    lpl3->pitToken = dn->token;
+    lpl3->congMark = RTE_MAX(dn->congMark, upCongMark);
     N_LOGD("^ nack-to=%" PRI_FaceID " reason=%s npkt=%p nonce=%08" PRIx32
            " dn-token=" PRI_LpPitToken,
            dn->face, NackReason_ToString(reason), output, dn->nonce, LpPitToken_Fmt(&dn->token));
@@ -50,9 +54,54 @@ void
 SgReturnNacks(SgCtx* ctx0, SgNackReason reason)
 {
   FwFwdCtx* ctx = (FwFwdCtx*)ctx0;
-  NDNDPDK_ASSERT(ctx->eventKind == SGEVT_INTEREST);
+  NDNDPDK_ASSERT(ctx->eventKind != SGEVT_DATA);
+  if (unlikely(ctx->nackReturned)) {
+    N_LOGD("^ no-nack drop=nack-returned");
+    return;
+  }
+
+  uint8_t nackHopLimit = 1;
+  uint8_t upCongMark = 0;
+  if (ctx->eventKind == SGEVT_NACK) {
+    PNack* nack = Packet_GetNackHdr(ctx->npkt);
+    nackHopLimit = nack->interest.hopLimit;
+    upCongMark = nack->lpl3.congMark;
+  }
+
+  FwFwd_TxNacks(ctx->fwd, ctx->pitEntry, rte_get_tsc_cycles(), (NackReason)reason, nackHopLimit,
+                upCongMark);
+  ctx->nackReturned = true;
+}
+
+SgNackReason
+SgGetNackReason(SgCtx* ctx0, FaceID nh)
+{
+  FwFwdCtx* ctx = (FwFwdCtx*)ctx0;
+  NDNDPDK_ASSERT(ctx->eventKind != SGEVT_DATA);
+
+  PitUpIt it;
+  for (PitUpIt_Init(&it, ctx->pitEntry); PitUpIt_Valid(&it); PitUpIt_Next(&it)) {
+    if (it.up->face == nh) {
+      return (SgNackReason)it.up->nack;
+    }
+  }
+  return SgNackNone;
+}
 
-  FwFwd_TxNacks(ctx->fwd, ctx->pitEntry, rte_get_tsc_cycles(), (NackReason)reason, 1);
+/** @brief Prevent strategy from retrying on the Nack sender or downstream faces. */
+__attribute__((nonnull)) static void
+FwFwd_NackSetNexthopFilter(FwFwdCtx* ctx)
+{
+  ctx->nhFlt = 0;
+  FibNexthopFilter_Reject(&ctx->nhFlt, ctx->fibEntry, ctx->rxFace);
+
+  PitDnIt it;
+  for (PitDnIt_Init(&it, ctx->pitEntry); PitDnIt_Valid(&it); PitDnIt_Next(&it)) {
+    if (it.dn->face == 0) {
+      break;
+    }
+    FibNexthopFilter_Reject(&ctx->nhFlt, ctx->fibEntry, it.dn->face);
+  }
 }
 
 __attribute__((nonnull)) static bool
@@ -153,6 +202,12 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
   FwFwdCtx_SetFibEntry(ctx, PitEntry_FindFibEntry(ctx->pitEntry, fwd->fib));
   if (likely(ctx->fibEntry != NULL)) {
     ++ctx->fibEntryDyn->nRxNacks;
+    ctx->fibEntryDyn->nRxCongMarks += (uint32_t)(nack->lpl3.congMark != 0);
+    FibNexthopStats* stats =
+      FibEntryDyn_FindNexthopStats(ctx->fibEntryDyn, ctx->fibEntry, ctx->rxFace);
+    if (likely(stats != NULL)) {
+      ++stats->nNacks;
+    }
   }
 
   // Duplicate: record rejected nonce, resend with an alternate nonce if possible
@@ -164,7 +219,8 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
 
   // invoke strategy if FIB entry exists
   if (likely(ctx->fibEntry != NULL)) {
-    // TODO set ctx->nhFlt to prevent forwarding to downstream
+    FwFwd_NackSetNexthopFilter(ctx);
+    ctx->dnNonce = nack->interest.nonce;
     uint64_t res = SgInvoke(ctx->fibEntry->strategy, ctx);
     N_LOGD("^ fib-entry-depth=%" PRIu8 " sg-id=%d sg-res=%" PRIu64, ctx->fibEntry->nComps,
            ctx->fibEntry->strategy->id, res);
@@ -172,6 +228,14 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
   NULLize(ctx->fibEntry); // fibEntry is inaccessible upon RCU unlock
   rcu_read_unlock();
 
+  // if strategy has returned Nacks, erase PIT entry
+  if (ctx->nackReturned) {
+    N_LOGD("^ sg-nack-returned");
+    Pit_Erase(fwd->pit, ctx->pitEntry);
+    NULLize(ctx->pitEntry);
+    return;
+  }
+
   // if there are more pending upstream or strategy retries, wait for them
   if (nPending + ctx->nForwarded > 0) {
     N_LOGD("^ up-pendings=%d sg-forwarded=%d", nPending, ctx->nForwarded);
@@ -179,7 +243,8 @@ FwFwd_ProcessNack(FwFwd* fwd, FwFwdCtx* ctx)
   }
 
   // return Nacks to downstream and erase PIT entry
-  FwFwd_TxNacks(fwd, ctx->pitEntry, ctx->rxTime, leastSevere, nackHopLimit);
+  FwFwd_TxNacks(fwd, ctx->pitEntry, ctx->rxTime, leastSevere, nackHopLimit,
+                nack->lpl3.congMark);
   Pit_Erase(fwd->pit, ctx->pitEntry);
   NULLize(ctx->pitEntry);
 }
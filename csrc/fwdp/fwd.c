@@ -2,6 +2,8 @@
 #include "strategy.h"
 
 #include "../core/logger.h"
+#include "../fib/nexthop-stats.h"
+#include "../pcct/pit-iterator.h"
 
 N_LOG_INIT(FwFwd);
 
@@ -63,6 +65,33 @@ FwFwd_RxByType(FwFwd* fwd, PktType pktType)
   return pop.count;
 }
 
+/** @brief Count timeouts on upstream nexthops that have neither returned Data nor Nack. */
+static void
+FwFwd_PitExpiry(Pit* pit, PitEntry* pitEntry, void* fwd0)
+{
+  FwFwd* fwd = fwd0;
+  rcu_read_lock();
+  FibEntry* fibEntry = PitEntry_FindFibEntry(pitEntry, fwd->fib);
+  if (unlikely(fibEntry == NULL)) {
+    rcu_read_unlock();
+    return;
+  }
+
+  FibEntryDyn* dyn = FibEntry_PtrDyn(fibEntry, fwd->fibDynIndex);
+  PitUpIt it;
+  for (PitUpIt_Init(&it, pitEntry); PitUpIt_Valid(&it); PitUpIt_Next(&it)) {
+    if (it.up->face == 0 || it.up->nack != NackNone) {
+      continue;
+    }
+    FibNexthopStats* stats = FibEntryDyn_FindNexthopStats(dyn, fibEntry, it.up->face);
+    if (likely(stats != NULL)) {
+      ++stats->nTimeouts;
+      FibNexthopStats_Backoff(stats);
+    }
+  }
+  rcu_read_unlock();
+}
+
 int
 FwFwd_Run(FwFwd* fwd)
 {
@@ -72,6 +101,7 @@ FwFwd_Run(FwFwd* fwd)
 
   fwd->sgGlobal.tscHz = TscHz;
   Pit_SetSgTimerCb(fwd->pit, SgTriggerTimer, fwd);
+  Pit_SetExpiryCb(fwd->pit, FwFwd_PitExpiry, fwd);
 
   uint32_t nProcessed = 0;
   while (ThreadCtrl_Continue(fwd->ctrl, nProcessed)) {
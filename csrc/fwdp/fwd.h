@@ -15,6 +15,17 @@
 
 typedef struct FwFwdCtx FwFwdCtx;
 
+/** @brief Request from strategy to add, refresh, or remove a learned nexthop. */
+typedef struct FwLearnRecord
+{
+  uint32_t lifetime; ///< lifetime in milliseconds, zero to remove
+  FaceID nh;         ///< learned nexthop
+  uint16_t nameL;    ///< TLV-LENGTH of FIB entry name
+  uint8_t nameV[FibMaxNameLength];
+  char pad_[2];
+} FwLearnRecord;
+static_assert(sizeof(FwLearnRecord) % 4 == 0, "");
+
 /** @brief Forwarding thread. */
 typedef struct FwFwd
 {
@@ -37,10 +48,12 @@ typedef struct FwFwd
   uint64_t nDupNonce;     ///< Interests dropped due to duplicate nonce
   uint64_t nSgNoFwd;      ///< Interests not forwarded by strategy
   uint64_t nNackMismatch; ///< Nack dropped due to outdated nonce
+  uint64_t nLearnDrops;   ///< learned nexthop requests dropped due to full queue
 
   PacketMempools mp; ///< mempools for packet modification
 
-  struct rte_ring* crypto; ///< queue to crypto helper
+  struct rte_ring* crypto;     ///< queue to crypto helper
+  struct rte_ring* learnQueue; ///< queue of FwLearnRecord to control plane
 
   /** @brief Statistics of latency from packet arrival to start processing. */
   RunningStat latencyStat;
@@ -88,8 +101,9 @@ struct FwFwdCtx
 
   PitUp* pitUp;       // N
   LpPitToken rxToken; // F,I,D,N
-  uint32_t dnNonce;   // I
+  uint32_t dnNonce;   // T,I,N
   int nForwarded;     // T,I,N
+  bool nackReturned;  // T,I,N
   FaceID rxFace;      // F,I,D
 };
 
@@ -13,6 +13,7 @@ SgTriggerTimer(Pit* pit, PitEntry* pitEntry, void* fwd0)
     .fwd = fwd,
     .eventKind = SGEVT_TIMER,
     .pitEntry = pitEntry,
+    .dnNonce = pitEntry->dns[0].nonce,
   };
 
   // find FIB entry
@@ -24,14 +25,28 @@ SgTriggerTimer(Pit* pit, PitEntry* pitEntry, void* fwd0)
     return;
   }
 
+  // prevent strategy from forwarding to downstream faces
+  PitDnIt it;
+  for (PitDnIt_Init(&it, pitEntry); PitDnIt_Valid(&it); PitDnIt_Next(&it)) {
+    if (it.dn->face == 0) {
+      break;
+    }
+    FibNexthopFilter_Reject(&ctx.nhFlt, ctx.fibEntry, it.dn->face);
+  }
+
   // invoke strategy
   N_LOGD("Timer invoke sgtimer-at=%p fib-entry=%p sg-id=%d", pitEntry, ctx.fibEntry,
          ctx.fibEntry->strategy->id);
   uint64_t res = SgInvoke(ctx.fibEntry->strategy, &ctx);
-  N_LOGD("^ sg-res=%" PRIu64 " sg-forwarded=%d", res, ctx.nForwarded);
+  N_LOGD("^ sg-res=%" PRIu64 " sg-forwarded=%d sg-nack-returned=%d", res, ctx.nForwarded,
+         (int)ctx.nackReturned);
 
   NULLize(ctx.fibEntry); // fibEntry is inaccessible upon RCU unlock
   rcu_read_unlock();
+
+  if (ctx.nackReturned) {
+    Pit_Erase(pit, pitEntry);
+  }
 }
 
 bool
@@ -43,6 +58,26 @@ SgSetTimer(SgCtx* ctx0, TscDuration after)
   return ok;
 }
 
+bool
+SgLearnNexthop_(SgCtx* ctx0, uint64_t nhLifetime)
+{
+  FwFwdCtx* ctx = (FwFwdCtx*)ctx0;
+  FwLearnRecord rec = {
+    .lifetime = nhLifetime >> 32,
+    .nh = (FaceID)nhLifetime,
+    .nameL = ctx->fibEntry->nameL,
+  };
+  rte_memcpy(rec.nameV, ctx->fibEntry->nameV, rec.nameL);
+
+  bool ok = rte_ring_enqueue_elem(ctx->fwd->learnQueue, &rec, sizeof(rec)) == 0;
+  if (unlikely(!ok)) {
+    ++ctx->fwd->nLearnDrops;
+  }
+  N_LOGD("^ sglearn-nh=%" PRI_FaceID " lifetime=%" PRIu32 " %s", rec.nh, rec.lifetime,
+         ok ? "OK" : "FAIL");
+  return ok;
+}
+
 const struct rte_bpf_xsym*
 SgGetXsyms(int* nXsyms)
 {
@@ -106,6 +141,42 @@ SgGetXsyms(int* nXsyms)
                   .type = RTE_BPF_ARG_RAW,
                 },
             },
+        } },
+      { .name = "SgGetNackReason",
+        .type = RTE_BPF_XTYPE_FUNC,
+        .func = {
+          .val = (void*)SgGetNackReason,
+          .nb_args = 2,
+          .args =
+            {
+              [0] =
+                {
+                  .type = RTE_BPF_ARG_PTR,
+                  .size = sizeof(SgCtx),
+                },
+              [1] =
+                {
+                  .type = RTE_BPF_ARG_RAW,
+                },
+            },
+        } },
+      { .name = "SgLearnNexthop_",
+        .type = RTE_BPF_XTYPE_FUNC,
+        .func = {
+          .val = (void*)SgLearnNexthop_,
+          .nb_args = 2,
+          .args =
+            {
+              [0] =
+                {
+                  .type = RTE_BPF_ARG_PTR,
+                  .size = sizeof(SgCtx),
+                },
+              [1] =
+                {
+                  .type = RTE_BPF_ARG_RAW,
+                },
+            },
         } } };
   *nXsyms = RTE_DIM(xsyms);
   return xsyms;
@@ -13,11 +13,22 @@ SgGetXsyms(int* nXsyms);
 __attribute__((nonnull)) void
 SgTriggerTimer(Pit* pit, PitEntry* pitEntry, void* fwd0);
 
-/** @brief Invoke the strategy. */
+/**
+ * @brief Invoke the strategy.
+ *
+ * If the strategy program has been replaced with scratch reset, the FIB entry scratch area of
+ * this forwarding thread is cleared before the new program runs on it.
+ */
 __attribute__((nonnull)) static inline uint64_t
 SgInvoke(StrategyCode* strategy, FwFwdCtx* ctx)
 {
-  return StrategyCode_Run(strategy, ctx, sizeof(SgCtx));
+  const StrategyCodeProg* prog = StrategyCode_GetProg(strategy);
+  FibEntryDyn* dyn = ctx->fibEntryDyn;
+  if (unlikely(dyn->scratchGen != prog->scratchGen)) {
+    memset(dyn->scratch, 0, sizeof(dyn->scratch));
+    dyn->scratchGen = prog->scratchGen;
+  }
+  return StrategyCodeProg_Run(prog, ctx, sizeof(SgCtx));
 }
 
 #endif // NDNDPDK_FWDP_STRATEGY_H
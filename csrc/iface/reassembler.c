@@ -55,6 +55,16 @@ Reassembler_Drop_(Reassembler* reass, LpL2* pm, hash_sig_t hash)
   Reassembler_Delete_(reass, pm, hash);
 
   reass->nDropFragments += pm->fragCount - __builtin_popcount(pm->reassBitmap);
+  if (unlikely(reass->pdumpDrop != NULL)) {
+    struct rte_mbuf* frags[LpMaxFragments];
+    uint16_t nFrags = 0;
+    for (uint8_t i = 0; i < pm->fragCount; ++i) {
+      if (pm->reassFrags[i] != NULL) {
+        frags[nFrags++] = Packet_ToMbuf(pm->reassFrags[i]);
+      }
+    }
+    PdumpSourceRef_Process(reass->pdumpDrop, frags, nFrags);
+  }
   rte_pktmbuf_free_bulk((struct rte_mbuf**)pm->reassFrags, pm->fragCount);
 }
 
@@ -3,6 +3,7 @@
 
 /** @file */
 
+#include "../pdump/source.h"
 #include "common.h"
 
 /** @brief NDNLPv2 reassembler. */
@@ -12,6 +13,8 @@ typedef struct Reassembler
   uint64_t nDeliverFragments; ///< delivered fragments
   uint64_t nDropFragments;    ///< dropped fragments
 
+  PdumpSourceRef* pdumpDrop; ///< where to dump fragments of dropped partial messages, may be NULL
+
   struct rte_hash* table;
   TAILQ_HEAD(LpL2Queue, LpL2) list;
   uint32_t count;
@@ -0,0 +1,259 @@
+#include "reliability.h"
+
+#include "../core/logger.h"
+#include "../ndni/tlv-decoder.h"
+#include "../ndni/tlv-encoder.h"
+
+N_LOG_INIT(LpReliability);
+
+typedef struct LpRelField
+{
+  unaligned_uint32_t tl;
+  unaligned_uint64_t v;
+} __rte_packed LpRelField;
+
+__attribute__((nonnull)) static __rte_always_inline LpRelEntry*
+LpReliability_Slot(LpReliability* rel, uint64_t txSeq)
+{
+  return &rel->window[txSeq & rel->windowMask];
+}
+
+__attribute__((nonnull)) static __rte_always_inline void
+LpReliability_Abandon(LpReliability* rel, LpRelEntry* entry)
+{
+  N_LOGD("abandon txSeq=%016" PRIx64 " nRetx=%" PRIu8, entry->txSeq, entry->nRetx);
+  rte_pktmbuf_free(entry->frame);
+  entry->frame = NULL;
+  ++rel->nLost;
+}
+
+/**
+ * @brief Dequeue pending Acks.
+ * @param[out] acks Ack values, with room for @c LpMaxAcks values.
+ * @return number of Acks.
+ */
+__attribute__((nonnull)) static __rte_always_inline uint32_t
+LpReliability_DequeueAcks(LpReliability* rel, void* acks[LpMaxAcks])
+{
+  uint32_t nAcks = rte_ring_dequeue_burst(rel->ackQ, acks, LpMaxAcks, NULL);
+  rel->nAckTx += nAcks;
+  return nAcks;
+}
+
+/** @brief Write Ack fields. */
+__attribute__((nonnull)) static __rte_always_inline void
+LpReliability_WriteAcks(LpRelField* f, void* acks[LpMaxAcks], uint32_t nAcks)
+{
+  for (uint32_t i = 0; i < nAcks; ++i) {
+    f[i].tl = TlvEncoder_ConstTL3(TtLpAck, sizeof(f[i].v));
+    f[i].v = rte_cpu_to_be_64((uint64_t)(uintptr_t)acks[i]);
+  }
+}
+
+/** @brief Remove LpPacket TLV-TYPE and TLV-LENGTH from a frame. */
+__attribute__((nonnull)) static __rte_always_inline void
+LpReliability_StripTL(struct rte_mbuf* frame)
+{
+  const uint8_t* tl = rte_pktmbuf_mtod(frame, const uint8_t*);
+  NDNDPDK_ASSERT(tl[0] == TtLpPacket);
+  uint16_t sizeofTL = 1;
+  switch (tl[1]) {
+    case 0xFD:
+      sizeofTL += 3;
+      break;
+    case 0xFE:
+      sizeofTL += 5;
+      break;
+    default:
+      sizeofTL += 1;
+      break;
+  }
+  rte_pktmbuf_adj(frame, sizeofTL);
+}
+
+/**
+ * @brief Determine length of LpPacket header fields before LpPayload.
+ * @param frame LpPacket TLV-VALUE, as prepared by @c LpHeader_Prepend .
+ *
+ * Header fields are in the first segment, and all have TLV-TYPE less than TtLpAck.
+ */
+__attribute__((nonnull)) static __rte_always_inline uint16_t
+LpReliability_HeaderLength(struct rte_mbuf* frame)
+{
+  TlvDecoder d;
+  TlvDecoder_Init(&d, frame);
+  uint32_t hdrLen = 0;
+  TlvDecoder_EachTL (&d, type, length) {
+    if (type == TtLpPayload) {
+      break;
+    }
+    NDNDPDK_ASSERT(type < TtLpAck);
+    TlvDecoder_Skip(&d, length);
+    hdrLen = frame->pkt_len - d.length;
+  }
+  NDNDPDK_ASSERT(hdrLen <= frame->data_len);
+  return hdrLen;
+}
+
+void
+LpReliability_TxBurst(LpReliability* rel, PacketMempools* mp, struct rte_mbuf** frames,
+                      uint16_t count, TscTime now)
+{
+  for (uint16_t i = 0; i < count; ++i) {
+    struct rte_mbuf* frame = frames[i];
+    LpReliability_StripTL(frame);
+
+    // NDNLPv2 requires header fields in ascending TLV-TYPE order, so that Acks and TxSequence are
+    // inserted after existing header fields and before LpPayload
+    void* acks[LpMaxAcks];
+    uint32_t nAcks = LpReliability_DequeueAcks(rel, acks);
+    uint16_t hdrLen = LpReliability_HeaderLength(frame);
+    const uint8_t* hdr = rte_pktmbuf_mtod(frame, const uint8_t*);
+    uint8_t* room = (uint8_t*)rte_pktmbuf_prepend(frame, (nAcks + 1) * sizeof(LpRelField));
+    memmove(room, hdr, hdrLen);
+    LpRelField* f = RTE_PTR_ADD(room, hdrLen);
+    LpReliability_WriteAcks(f, acks, nAcks);
+
+    uint64_t txSeq = rel->nextTxSeq++;
+    f = &f[nAcks];
+    f->tl = TlvEncoder_ConstTL3(TtLpTxSequence, sizeof(f->v));
+    f->v = rte_cpu_to_be_64(txSeq);
+    TlvEncoder_PrependTL(frame, TtLpPacket, frame->pkt_len);
+    uint16_t txSeqOffset = RTE_PTR_DIFF(&f->v, rte_pktmbuf_mtod(frame, void*));
+
+    struct rte_mbuf* clone = rte_pktmbuf_clone(frame, mp->indirect);
+    if (unlikely(clone == NULL)) {
+      // transmit without retaining; this frame cannot be retransmitted
+      continue;
+    }
+
+    LpRelEntry* entry = LpReliability_Slot(rel, txSeq);
+    if (unlikely(entry->frame != NULL)) {
+      LpReliability_Abandon(rel, entry);
+    }
+    *entry = (LpRelEntry){
+      .frame = frame,
+      .txSeq = txSeq,
+      .sent = now,
+      .txSeqOffset = txSeqOffset,
+    };
+    frames[i] = clone;
+  }
+}
+
+__attribute__((nonnull)) static void
+LpReliability_ProcessAcks(LpReliability* rel)
+{
+  void* acks[MaxBurstSize];
+  uint32_t nAcks = rte_ring_dequeue_burst(rel->ackedQ, acks, RTE_DIM(acks), NULL);
+  for (uint32_t i = 0; i < nAcks; ++i) {
+    uint64_t ack = (uint64_t)(uintptr_t)acks[i];
+    LpRelEntry* entry = LpReliability_Slot(rel, ack);
+    if (entry->frame == NULL || entry->txSeq != ack) {
+      continue;
+    }
+    rte_pktmbuf_free(entry->frame);
+    entry->frame = NULL;
+    ++rel->nAcked;
+  }
+}
+
+__attribute__((nonnull)) static uint16_t
+LpReliability_Retx(LpReliability* rel, PacketMempools* mp, struct rte_mbuf** frames,
+                   uint16_t maxFrames, TscTime now)
+{
+  uint64_t capacity = (uint64_t)rel->windowMask + 1;
+  if (rel->nextTxSeq - rel->oldestTxSeq > capacity) {
+    rel->oldestTxSeq = rel->nextTxSeq - capacity;
+  }
+
+  uint16_t nFrames = 0;
+  for (; rel->oldestTxSeq != rel->nextTxSeq && nFrames < maxFrames; ++rel->oldestTxSeq) {
+    LpRelEntry* entry = LpReliability_Slot(rel, rel->oldestTxSeq);
+    if (entry->frame == NULL || entry->txSeq != rel->oldestTxSeq) {
+      continue;
+    }
+    if (now - entry->sent < rel->retxTimeout) {
+      break;
+    }
+    if (entry->nRetx >= rel->maxRetx) {
+      LpReliability_Abandon(rel, entry);
+      continue;
+    }
+    if (rte_mbuf_refcnt_read(entry->frame) > 1) {
+      // previous transmission is still in the driver, TxSequence cannot be rewritten yet
+      break;
+    }
+
+    LpRelEntry moved = *entry;
+    moved.txSeq = rel->nextTxSeq;
+    moved.sent = now;
+    ++moved.nRetx;
+    unaligned_uint64_t* txSeqV =
+      rte_pktmbuf_mtod_offset(moved.frame, unaligned_uint64_t*, moved.txSeqOffset);
+    *txSeqV = rte_cpu_to_be_64(moved.txSeq);
+
+    struct rte_mbuf* clone = rte_pktmbuf_clone(moved.frame, mp->indirect);
+    if (unlikely(clone == NULL)) {
+      *txSeqV = rte_cpu_to_be_64(entry->txSeq);
+      break;
+    }
+
+    ++rel->nextTxSeq;
+    entry->frame = NULL;
+    LpRelEntry* dst = LpReliability_Slot(rel, moved.txSeq);
+    if (unlikely(dst->frame != NULL)) {
+      LpReliability_Abandon(rel, dst);
+    }
+    *dst = moved;
+
+    N_LOGV("retx txSeq=%016" PRIx64 " nRetx=%" PRIu8, moved.txSeq, moved.nRetx);
+    frames[nFrames++] = clone;
+    ++rel->nRetx;
+  }
+  return nFrames;
+}
+
+__attribute__((nonnull)) static uint16_t
+LpReliability_Idle(LpReliability* rel, PacketMempools* mp, struct rte_mbuf** frames,
+                   uint16_t maxFrames)
+{
+  uint16_t nFrames = 0;
+  while (nFrames < maxFrames && !rte_ring_empty(rel->ackQ)) {
+    struct rte_mbuf* frame = rte_pktmbuf_alloc(mp->header);
+    if (unlikely(frame == NULL)) {
+      break;
+    }
+    frame->data_off = RTE_PKTMBUF_HEADROOM + LpHeaderHeadroom;
+    void* acks[LpMaxAcks];
+    uint32_t nAcks = LpReliability_DequeueAcks(rel, acks);
+    LpRelField* f = (LpRelField*)rte_pktmbuf_prepend(frame, nAcks * sizeof(LpRelField));
+    LpReliability_WriteAcks(f, acks, nAcks);
+    TlvEncoder_PrependTL(frame, TtLpPacket, frame->pkt_len);
+    Packet_SetType(Packet_FromMbuf(frame), PktFragment);
+    frames[nFrames++] = frame;
+    ++rel->nIdleTx;
+  }
+  return nFrames;
+}
+
+uint16_t
+LpReliability_Poll(LpReliability* rel, PacketMempools* mp, struct rte_mbuf** frames, TscTime now)
+{
+  LpReliability_ProcessAcks(rel);
+  uint16_t nFrames = LpReliability_Retx(rel, mp, frames, MaxBurstSize / 2, now);
+  nFrames += LpReliability_Idle(rel, mp, &frames[nFrames], MaxBurstSize - nFrames);
+  return nFrames;
+}
+
+void
+LpReliability_Close(LpReliability* rel)
+{
+  for (uint64_t i = 0, capacity = (uint64_t)rel->windowMask + 1; i < capacity; ++i) {
+    LpRelEntry* entry = &rel->window[i];
+    if (entry->frame != NULL) {
+      rte_pktmbuf_free(entry->frame);
+      entry->frame = NULL;
+    }
+  }
+}
@@ -0,0 +1,89 @@
+#ifndef NDNDPDK_IFACE_RELIABILITY_H
+#define NDNDPDK_IFACE_RELIABILITY_H
+
+/** @file */
+
+#include "../dpdk/tsc.h"
+#include "common.h"
+
+/** @brief Unacknowledged frame in LpReliability. */
+typedef struct LpRelEntry
+{
+  struct rte_mbuf* frame; ///< retained frame; NULL if slot is empty
+  uint64_t txSeq;         ///< TxSequence of last transmission
+  TscTime sent;           ///< time of last transmission
+  uint16_t txSeqOffset;   ///< offset of TxSequence TLV-VALUE within frame
+  uint8_t nRetx;          ///< number of retransmissions
+} LpRelEntry;
+
+/**
+ * @brief NDNLPv2 link reliability.
+ *
+ * RX threads pass received TxSequence and Ack values to the TX thread via rings.
+ * TX thread assigns TxSequence to outgoing frames, piggybacks Acks, retains a copy of each frame
+ * until it is acknowledged, and retransmits unacknowledged frames after a timeout.
+ */
+typedef struct LpReliability
+{
+  struct rte_ring* ackQ;   ///< received TxSequence to be acknowledged
+  struct rte_ring* ackedQ; ///< received Ack values
+  TscDuration retxTimeout; ///< retransmission timeout
+  uint64_t nextTxSeq;      ///< next TxSequence
+  uint64_t oldestTxSeq;    ///< TxSequence below this are either acknowledged or abandoned
+  uint32_t windowMask;     ///< window capacity minus one
+  uint8_t maxRetx;         ///< maximum retransmissions per frame
+
+  uint64_t nRetx;     ///< retransmitted frames
+  uint64_t nAcked;    ///< frames acknowledged by peer
+  uint64_t nLost;     ///< frames abandoned after exceeding maxRetx or window capacity
+  uint64_t nAckTx;    ///< Ack fields transmitted
+  uint64_t nIdleTx;   ///< IDLE packets transmitted to carry Acks
+
+  LpRelEntry window[0];
+} LpReliability;
+
+/**
+ * @brief Pass link reliability fields of an incoming frame to the TX thread.
+ * @return whether success; false indicates a ring is full.
+ *
+ * This function is thread-safe.
+ */
+__attribute__((nonnull)) static inline bool
+LpReliability_Rx(LpReliability* rel, const LpRel* lpr)
+{
+  bool ok = true;
+  if (lpr->hasTxSeq) {
+    ok = rte_ring_enqueue(rel->ackQ, (void*)(uintptr_t)lpr->txSeq) == 0;
+  }
+  if (lpr->nAcks > 0) {
+    void* acks[LpMaxAcks];
+    for (uint8_t i = 0; i < lpr->nAcks; ++i) {
+      acks[i] = (void*)(uintptr_t)lpr->acks[i];
+    }
+    ok = rte_ring_enqueue_bulk(rel->ackedQ, acks, lpr->nAcks, NULL) == lpr->nAcks && ok;
+  }
+  return ok;
+}
+
+/**
+ * @brief Assign TxSequence and piggyback Acks on outgoing frames.
+ * @param[inout] frames L2 frames, each is an LpPacket from @c TxProc_Output .
+ *                      Each frame is retained for retransmission, and replaced with a clone.
+ */
+__attribute__((nonnull)) void
+LpReliability_TxBurst(LpReliability* rel, PacketMempools* mp, struct rte_mbuf** frames,
+                      uint16_t count, TscTime now);
+
+/**
+ * @brief Process Acks, retransmit unacknowledged frames, and send pending Acks.
+ * @param[out] frames L2 frames to be transmitted, with room for @c MaxBurstSize frames.
+ * @return number of L2 frames to be transmitted.
+ */
+__attribute__((nonnull)) uint16_t
+LpReliability_Poll(LpReliability* rel, PacketMempools* mp, struct rte_mbuf** frames, TscTime now);
+
+/** @brief Release retained frames. */
+__attribute__((nonnull)) void
+LpReliability_Close(LpReliability* rel);
+
+#endif // NDNDPDK_IFACE_RELIABILITY_H
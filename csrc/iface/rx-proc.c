@@ -13,33 +13,62 @@ RxProc_Input(RxProc* rx, int thread, struct rte_mbuf* frame)
   rxt->nFrames[0] += frame->pkt_len;
 
   Packet* npkt = Packet_FromMbuf(frame);
-  if (unlikely(!Packet_Parse(npkt))) {
-    ++rxt->nDecodeErr;
-    N_LOGD("l2-decode-error face=%" PRI_FaceID " thread=%d", faceID, thread);
-    rte_pktmbuf_free(frame);
-    return NULL;
+  if (rx->rel == NULL) {
+    if (unlikely(!Packet_Parse(npkt))) {
+      goto L2_DECODE_ERROR;
+    }
+  } else {
+    LpRel lpr;
+    if (unlikely(!Packet_ParseWithRel(npkt, &lpr))) {
+      goto L2_DECODE_ERROR;
+    }
+    if (unlikely(!LpReliability_Rx(rx->rel, &lpr))) {
+      ++rxt->nRelDrops;
+    }
+    if (frame->pkt_len == 0) { // IDLE packet
+      rte_pktmbuf_free(frame);
+      return NULL;
+    }
   }
 
   PktType pktType = Packet_GetType(npkt);
   if (likely(pktType != PktFragment)) {
     ++rxt->nFrames[pktType];
+    rxt->nCongMarks += (uint64_t)(Packet_GetLpL3Hdr(npkt)->congMark != 0);
     return npkt;
   }
 
+  ++rxt->nFragments;
+  if (thread != 0) {
+    ++rxt->nFragShared;
+  }
   NULLize(frame); // frame aliases npkt, but npkt will be owned by reassembler
-  npkt = Reassembler_Accept(&rxt->reass, npkt);
+  rte_spinlock_lock(&rx->reassLock);
+  npkt = Reassembler_Accept(&rx->reass, npkt);
+  rte_spinlock_unlock(&rx->reassLock);
   if (npkt == NULL) {
     return NULL;
   }
+  ++rxt->nReassembled;
 
   if (unlikely(!Packet_ParseL3(npkt))) {
     ++rxt->nDecodeErr;
     N_LOGD("l3-decode-error face=%" PRI_FaceID " thread=%d", faceID, thread);
-    rte_pktmbuf_free(Packet_ToMbuf(npkt));
+    frame = Packet_ToMbuf(npkt);
+    PdumpSourceRef_Process(&rx->pdumpDrop, &frame, 1);
+    rte_pktmbuf_free(frame);
     return NULL;
   }
 
   pktType = Packet_GetType(npkt);
   ++rxt->nFrames[pktType];
+  rxt->nCongMarks += (uint64_t)(Packet_GetLpL3Hdr(npkt)->congMark != 0);
   return npkt;
+
+L2_DECODE_ERROR:
+  ++rxt->nDecodeErr;
+  N_LOGD("l2-decode-error face=%" PRI_FaceID " thread=%d", faceID, thread);
+  PdumpSourceRef_Process(&rx->pdumpDrop, &frame, 1);
+  rte_pktmbuf_free(frame);
+  return NULL;
 }
@@ -5,20 +5,37 @@
 
 #include "../pdump/source.h"
 #include "reassembler.h"
+#include "reliability.h"
+
+#include <rte_spinlock.h>
 
 /** @brief RxProc per-thread information. */
 typedef struct RxProcThread
 {
   uint64_t nFrames[PktMax]; ///< accepted L3 packets; nFrames[0] is nOctets
   uint64_t nDecodeErr;      ///< decode errors
-  Reassembler reass;
+  uint64_t nRelDrops;       ///< link reliability fields dropped due to full ring
+  uint64_t nFragments;      ///< fragments passed to reassembler
+  uint64_t nFragShared;     ///< fragments passed to reassembler from a non-zero thread
+  uint64_t nReassembled;    ///< L3 packets returned by reassembler
+  uint64_t nCongMarks;      ///< L3 packets carrying congestion mark
 } __rte_cache_aligned RxProcThread;
 
-/** @brief Incoming frame processing procedure. */
+/**
+ * @brief Incoming frame processing procedure.
+ *
+ * All RX threads share one reassembler, so that fragments of the same L3 packet can be
+ * reassembled even if they arrive on different threads.
+ * Fragments arriving on non-zero threads are passed to the shared reassembler under a lock.
+ */
 typedef struct RxProc
 {
   RxProcThread threads[MaxRxProcThreads];
+  Reassembler reass;
+  rte_spinlock_t reassLock; ///< protects reass
   PdumpSourceRef pdump;
+  PdumpSourceRef pdumpDrop; ///< frames dropped due to decoding errors or reassembler eviction
+  LpReliability* rel;       ///< link reliability, NULL if disabled
 } RxProc;
 
 /**
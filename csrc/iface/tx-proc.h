@@ -5,6 +5,7 @@
 
 #include "../pdump/source.h"
 #include "common.h"
+#include "reliability.h"
 
 /**
  * @brief Transmit a burst of L2 frames.
@@ -29,6 +30,11 @@ typedef struct TxProc
   PacketMempools mp; ///< mempools for fragmentation
   TxProc_OutputFunc_ outputFunc[2];
   uint64_t nextSeqNum; ///< next fragmentation sequence number
+  LpReliability* rel;  ///< link reliability, NULL if disabled
+
+  uint32_t congMarkThreshold; ///< output queue occupancy to place congestion mark, 0 if disabled
+  uint64_t nCongMarks;        ///< L3 packets carrying congestion mark
+  uint64_t nCongMarksAdded;   ///< congestion marks placed by TxLoop
 
   uint64_t nL3Fragmented; ///< L3 packets that required fragmentation
   uint64_t nL3OverLength; ///< dropped L3 packets due to over length
@@ -26,12 +26,60 @@ TxLoop_TxFrames(Face* face, struct rte_mbuf** frames, uint16_t count)
   }
 }
 
+/** @brief Transmit frames from TxProc_Output, after applying link reliability if enabled. */
+__attribute__((nonnull)) static void
+TxLoop_TxNewFrames(Face* face, struct rte_mbuf** frames, uint16_t count, TscTime now)
+{
+  TxProc* tx = &face->impl->tx;
+  if (tx->rel != NULL) {
+    LpReliability_TxBurst(tx->rel, &tx->mp, frames, count, now);
+  }
+  TxLoop_TxFrames(face, frames, count);
+}
+
+__attribute__((nonnull)) static void
+TxLoop_Reliability(Face* face, TscTime now)
+{
+  TxProc* tx = &face->impl->tx;
+  struct rte_mbuf* frames[MaxBurstSize];
+  uint16_t nFrames = LpReliability_Poll(tx->rel, &tx->mp, frames, now);
+  if (nFrames > 0) {
+    TxLoop_TxFrames(face, frames, nFrames);
+  }
+}
+
+/**
+ * @brief Place a congestion mark on the first packet of a burst if the output queue is congested.
+ * @param nRemaining number of packets remaining in the output queue.
+ *
+ * This signals egress congestion, which occurs when the output queue grows faster than what the
+ * link can transmit. At most one packet is marked per burst.
+ */
+__attribute__((nonnull)) static __rte_always_inline void
+TxLoop_CongMark(TxProc* tx, Packet* npkt, uint32_t nRemaining)
+{
+  if (likely(tx->congMarkThreshold == 0 || nRemaining < tx->congMarkThreshold)) {
+    return;
+  }
+
+  LpL3* lpl3 = Packet_GetLpL3Hdr(npkt);
+  if (lpl3->congMark == 0) {
+    lpl3->congMark = 1;
+    ++tx->nCongMarksAdded;
+  }
+}
+
 __attribute__((nonnull)) static uint16_t
 TxLoop_Transfer(Face* face)
 {
   TxProc* tx = &face->impl->tx;
   Packet* npkts[MaxBurstSize];
-  uint16_t count = rte_ring_dequeue_burst(face->outputQueue, (void**)npkts, MaxBurstSize, NULL);
+  uint32_t nRemaining = 0;
+  uint16_t count =
+    rte_ring_dequeue_burst(face->outputQueue, (void**)npkts, MaxBurstSize, &nRemaining);
+  if (count > 0) {
+    TxLoop_CongMark(tx, npkts[0], nRemaining);
+  }
 
   struct rte_mbuf* frames[MaxBurstSize + LpMaxFragments];
   uint16_t nFrames = 0;
@@ -43,6 +91,7 @@ TxLoop_Transfer(Face* face)
     Packet* npkt = npkts[i];
     PktType framePktType = PktType_ToFull(Packet_GetType(npkt));
     ++tx->nFrames[framePktType];
+    tx->nCongMarks += (uint64_t)(Packet_GetLpL3Hdr(npkt)->congMark != 0);
 
     if (Hrlog_Enabled()) {
       struct rte_mbuf* m = Packet_ToMbuf(npkt);
@@ -64,16 +113,20 @@ TxLoop_Transfer(Face* face)
 
     nFrames += TxProc_Output(tx, npkt, &frames[nFrames], face->txAlign);
     if (unlikely(nFrames >= MaxBurstSize)) {
-      TxLoop_TxFrames(face, frames, nFrames);
+      TxLoop_TxNewFrames(face, frames, nFrames, now);
       nFrames = 0;
     }
   }
 
   if (likely(nFrames > 0)) {
-    TxLoop_TxFrames(face, frames, nFrames);
+    TxLoop_TxNewFrames(face, frames, nFrames, now);
   }
   Hrlog_Post(hrl, nHrls);
 
+  if (tx->rel != NULL) {
+    TxLoop_Reliability(face, now);
+  }
+
   return count;
 }
 
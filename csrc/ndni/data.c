@@ -240,8 +240,9 @@ Encode_Finish(struct rte_mbuf* m)
   return output;
 }
 
-Packet*
-DataEnc_EncodePayload(LName prefix, LName suffix, const void* metaBuf, struct rte_mbuf* m)
+__attribute__((nonnull)) static __rte_always_inline bool
+Encode_Payload(LName prefix, LName suffix, const void* metaBuf, const uint8_t* sigV, uint16_t sigL,
+               struct rte_mbuf* m)
 {
   NDNDPDK_ASSERT(RTE_MBUF_DIRECT(m) && rte_pktmbuf_is_contiguous(m) &&
                  rte_mbuf_refcnt_read(m) == 1);
@@ -253,11 +254,11 @@ DataEnc_EncodePayload(LName prefix, LName suffix, const void* metaBuf, struct rt
   uint16_t sizeofContentL = TlvEncoder_SizeofVarNum(contentL);
   uint16_t sizeofHeadroom = 1 + sizeofNameL + nameL + meta->size + 1 + sizeofContentL;
 
-  uint8_t* sig = (uint8_t*)rte_pktmbuf_append(m, sizeof(NullSig));
+  uint8_t* sig = (uint8_t*)rte_pktmbuf_append(m, sigL);
   if (unlikely(sig == NULL || rte_pktmbuf_headroom(m) < 4 + sizeofHeadroom)) {
-    return NULL;
+    return false;
   }
-  rte_memcpy(sig, &NullSig, sizeof(NullSig));
+  rte_memcpy(sig, sigV, sigL);
 
   uint8_t* head = (uint8_t*)rte_pktmbuf_prepend(m, sizeofHeadroom);
   *head++ = TtName;
@@ -270,7 +271,37 @@ DataEnc_EncodePayload(LName prefix, LName suffix, const void* metaBuf, struct rt
   head += meta->size;
   *head++ = TtContent;
   head += TlvEncoder_WriteVarNum(head, contentL);
+  return true;
+}
+
+Packet*
+DataEnc_EncodePayload(LName prefix, LName suffix, const void* metaBuf, struct rte_mbuf* m)
+{
+  if (unlikely(
+        !Encode_Payload(prefix, suffix, metaBuf, (const uint8_t*)&NullSig, sizeof(NullSig), m))) {
+    return NULL;
+  }
+  return Encode_Finish(m);
+}
 
+bool
+DataEnc_EncodeSignedPortion(LName prefix, LName suffix, const void* metaBuf, LName sigInfo,
+                            struct rte_mbuf* m)
+{
+  return Encode_Payload(prefix, suffix, metaBuf, sigInfo.value, sigInfo.length, m);
+}
+
+Packet*
+DataEnc_AppendSigValue(struct rte_mbuf* m, uint8_t sigValueL, uint8_t** sigValue)
+{
+  NDNDPDK_ASSERT(sigValueL < 0xFD);
+  uint8_t* tail = (uint8_t*)rte_pktmbuf_append(m, 2 + sigValueL);
+  if (unlikely(tail == NULL)) {
+    return NULL;
+  }
+  tail[0] = TtDSigValue;
+  tail[1] = sigValueL;
+  *sigValue = &tail[2];
   return Encode_Finish(m);
 }
 
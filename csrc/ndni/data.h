@@ -105,6 +105,32 @@ DataEnc_PrepareMetaInfo_(void* metaBuf, size_t capacity, ContentType ct, uint32_
 __attribute__((nonnull)) Packet*
 DataEnc_EncodePayload(LName prefix, LName suffix, const void* metaBuf, struct rte_mbuf* m);
 
+/**
+ * @brief Encode signed portion of Data with payload.
+ * @param prefix Data name prefix.
+ * @param suffix Data name suffix.
+ * @param metaBuf prepared DataEnc_MetaInfoBuffer.
+ * @param sigInfo DSigInfo TLV, including TLV-TYPE and TLV-LENGTH.
+ * @param m a uniquely owned, unsegmented, direct mbuf of Content payload.
+ * @return whether success.
+ * @post @p m contains Name, MetaInfo, Content, and DSigInfo TLVs, without Data TLV-TYPE and
+ *       TLV-LENGTH. Headroom for Data TLV-TYPE and TLV-LENGTH is ensured.
+ */
+__attribute__((nonnull)) bool
+DataEnc_EncodeSignedPortion(LName prefix, LName suffix, const void* metaBuf, LName sigInfo,
+                            struct rte_mbuf* m);
+
+/**
+ * @brief Append DSigValue TLV and finish Data encoding.
+ * @param m mbuf prepared by @c DataEnc_EncodeSignedPortion .
+ * @param sigValueL DSigValue TLV-LENGTH, must be less than 0xFD.
+ * @param[out] sigValue DSigValue TLV-VALUE buffer, to be filled by the caller.
+ * @return encoded packet, same as @p m .
+ * @retval NULL insufficient tailroom.
+ */
+__attribute__((nonnull)) Packet*
+DataEnc_AppendSigValue(struct rte_mbuf* m, uint8_t sigValueL, uint8_t** sigValue);
+
 /** @brief Data encoder optimized for traffic generator. */
 typedef struct DataGen
 {
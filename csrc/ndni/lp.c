@@ -101,6 +101,23 @@ LpHeader_Parse(LpHeader* lph, struct rte_mbuf* pkt)
         }
         break;
       }
+      case TtLpTxSequence: {
+        if (unlikely(length != 8 || !TlvDecoder_ReadNniTo(&d, length, &lph->rel.txSeq))) {
+          return false;
+        }
+        lph->rel.hasTxSeq = true;
+        break;
+      }
+      case TtLpAck: {
+        uint64_t ack = 0;
+        if (unlikely(length != 8 || !TlvDecoder_ReadNniTo(&d, length, &ack))) {
+          return false;
+        }
+        if (likely(lph->rel.nAcks < LpMaxAcks)) {
+          lph->rel.acks[lph->rel.nAcks++] = ack;
+        }
+        break;
+      }
       default:
         if (LpHeader_IsCriticalType(type)) {
           return false;
@@ -79,11 +79,21 @@ typedef struct LpL3
   LpPitToken pitToken;
 } LpL3;
 
+/** @brief NDNLPv2 link reliability fields. */
+typedef struct LpRel
+{
+  uint64_t txSeq;           ///< TxSequence, valid if hasTxSeq is true
+  uint64_t acks[LpMaxAcks]; ///< Ack values
+  uint8_t nAcks;            ///< number of valid entries in acks
+  bool hasTxSeq;
+} LpRel;
+
 /** @brief Parsed NDNLPv2 header. */
 typedef struct LpHeader
 {
   LpL3 l3;
   LpL2 l2;
+  LpRel rel;
 } LpHeader;
 
 /**
@@ -97,6 +107,7 @@ typedef struct LpHeader
  * @li PIT token
  * @li network nack
  * @li congestion mark
+ * @li link reliability TxSequence and Ack; Acks in excess of @c LpMaxAcks are ignored
  *
  * This function does not check whether header fields are applicable to network layer packet type,
  * because network layer type is unknown before reassembly. For example, it would accept Nack
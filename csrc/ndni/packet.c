@@ -21,8 +21,8 @@ PktType_ToString(PktType t)
   }
 }
 
-bool
-Packet_Parse(Packet* npkt)
+__attribute__((nonnull(1))) static __rte_always_inline bool
+Packet_Parse_(Packet* npkt, LpRel* rel)
 {
   PacketPriv* priv = Packet_GetPriv_(npkt);
   struct rte_mbuf* pkt = Packet_ToMbuf(npkt);
@@ -32,10 +32,17 @@ Packet_Parse(Packet* npkt)
   if (unlikely(!LpHeader_Parse(lph, pkt))) {
     return false;
   }
+  if (rel != NULL) {
+    *rel = lph->rel; // copy before Packet_ParseL3 overwrites PacketPriv
+  }
 
   if (unlikely(pkt->pkt_len == 0)) {
-    // there isn't any feature that depends on IDLE packets yet
-    return false;
+    // IDLE packet is only useful for link reliability
+    if (rel == NULL) {
+      return false;
+    }
+    Packet_SetType(npkt, PktFragment);
+    return true;
   }
 
   if (lph->l2.fragCount > 1) {
@@ -46,6 +53,18 @@ Packet_Parse(Packet* npkt)
   return Packet_ParseL3(npkt);
 }
 
+bool
+Packet_Parse(Packet* npkt)
+{
+  return Packet_Parse_(npkt, NULL);
+}
+
+bool
+Packet_ParseWithRel(Packet* npkt, LpRel* rel)
+{
+  return Packet_Parse_(npkt, rel);
+}
+
 bool
 Packet_ParseL3(Packet* npkt)
 {
@@ -143,6 +143,17 @@ Packet_GetNackHdr(Packet* npkt)
 __attribute__((nonnull, warn_unused_result)) bool
 Packet_Parse(Packet* npkt);
 
+/**
+ * @brief Parse packet in mbuf, and extract NDNLPv2 link reliability fields.
+ * @param npkt a uniquely owned, unsegmented, direct mbuf.
+ * @param[out] rel link reliability fields.
+ * @return whether success.
+ * @post Same as @c Packet_Parse , except that an IDLE packet (LpPacket without payload) is
+ *       accepted, and has zero length and @c PktFragment type.
+ */
+__attribute__((nonnull, warn_unused_result)) bool
+Packet_ParseWithRel(Packet* npkt, LpRel* rel);
+
 /**
  * @brief Parse layer 3 in mbuf.
  * @param npkt a uniquely owned, possibly segmented, direct mbuf.
@@ -36,6 +36,7 @@ CsArc_GetList(CsArc* arc, CsListID l)
     (entry)->arcList = CslMd##dst;                                                                 \
     CsList_Append(&(arc)->dst, (entry));                                                           \
     N_LOGV("^ move=%p from=" #src " to=" #dst, (entry));                                           \
+    (arc)->moveCb((arc)->moveCbArg, (entry), CslMd##src, CslMd##dst);                              \
   } while (false)
 
 static inline void
@@ -47,7 +48,7 @@ CsArc_SetP(CsArc* arc, double p)
 }
 
 void
-CsArc_Init(CsArc* arc, uint32_t capacity)
+CsArc_Init(CsArc* arc, uint32_t capacity, CsArc_MoveCb moveCb, void* moveCbArg)
 {
   CsList_Init(&arc->T1);
   CsList_Init(&arc->B1);
@@ -55,6 +56,9 @@ CsArc_Init(CsArc* arc, uint32_t capacity)
   CsList_Init(&arc->B2);
   CsList_Init(&arc->Del);
 
+  arc->moveCb = moveCb;
+  arc->moveCbArg = moveCbArg;
+
   arc->c = (double)capacity;
   CsArc_c(arc) = capacity;
   CsArc_2c(arc) = 2 * capacity;
@@ -72,7 +76,6 @@ CsArc_Replace(CsArc* arc, bool isB2)
     moving = CsList_GetFront(&arc->T2);
     CsArc_Move(arc, moving, T2, B2);
   }
-  CsEntry_ClearData(moving);
 }
 
 static void
@@ -116,7 +119,6 @@ CsArc_AddNew(CsArc* arc, CsEntry* entry)
       NDNDPDK_ASSERT(arc->B1.count == 0);
       N_LOGV("^ evict-from=T1");
       CsEntry* deleting = CsList_GetFront(&arc->T1);
-      CsEntry_ClearData(deleting);
       CsArc_Move(arc, deleting, T1, Del);
     }
   } else {
@@ -5,8 +5,13 @@
 
 #include "cs-list.h"
 
-__attribute__((nonnull)) void
-CsArc_Init(CsArc* arc, uint32_t capacity);
+/**
+ * @brief Initialize ARC lists.
+ * @param moveCb callback when an entry moves between lists. When an entry moves into B1, B2, or
+ *               Del list, the callback is responsible for releasing the Data packet in memory.
+ */
+__attribute__((nonnull(1, 3))) void
+CsArc_Init(CsArc* arc, uint32_t capacity, CsArc_MoveCb moveCb, void* moveCbArg);
 
 __attribute__((nonnull)) CsList*
 CsArc_GetList(CsArc* arc, CsListID l);
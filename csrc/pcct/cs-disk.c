@@ -0,0 +1,119 @@
+#include "cs-disk.h"
+#include "pcct.h"
+
+#include "../core/logger.h"
+#include "../diskstore/diskalloc.h"
+#include "../diskstore/diskstore.h"
+
+N_LOG_INIT(CsDisk);
+
+/** @brief Move Data packet of a direct entry from memory to disk. */
+__attribute__((nonnull)) static void
+CsDisk_Insert(Cs* cs, CsEntry* entry)
+{
+  NDNDPDK_ASSERT(entry->diskSlot == 0);
+  Packet* npkt = entry->data;
+  if (unlikely(npkt == NULL)) {
+    return;
+  }
+  entry->data = NULL;
+
+  if (cs->diskStore == NULL) {
+    rte_pktmbuf_free(Packet_ToMbuf(npkt));
+    return;
+  }
+
+  uint64_t slot = 0;
+  if (unlikely(DiskStore_ComputeBlockCount_(cs->diskStore, npkt) >
+               cs->diskStore->nBlocksPerSlot) ||
+      unlikely((slot = DiskAlloc_Alloc(cs->diskAlloc)) == 0)) {
+    N_LOGD("Insert cs=%p cs-entry=%p npkt=%p drop=disk-full", cs, entry, npkt);
+    ++cs->nDiskFull;
+    rte_pktmbuf_free(Packet_ToMbuf(npkt));
+    return;
+  }
+
+  N_LOGD("Insert cs=%p cs-entry=%p npkt=%p slot=%" PRIu64, cs, entry, npkt, slot);
+  entry->diskSlot = slot;
+  entry->dataLen = Packet_ToMbuf(npkt)->pkt_len;
+  ++cs->nDiskInsert;
+  DiskStore_PutData(cs->diskStore, slot, npkt);
+}
+
+void
+CsDisk_Delete(Cs* cs, CsEntry* entry)
+{
+  if (likely(entry->diskSlot == 0)) {
+    return;
+  }
+
+  N_LOGD("Delete cs=%p cs-entry=%p slot=%" PRIu64, cs, entry, entry->diskSlot);
+  DiskAlloc_Free(cs->diskAlloc, entry->diskSlot);
+  entry->diskSlot = 0;
+  ++cs->nDiskDelete;
+}
+
+void
+CsDisk_ArcMove(void* cs0, CsEntry* entry, CsListID src, CsListID dst)
+{
+  Cs* cs = (Cs*)cs0;
+  switch (dst) {
+    case CslMdB1:
+    case CslMdB2:
+      CsDisk_Insert(cs, entry);
+      break;
+    case CslMdDel:
+      CsEntry_ClearData(entry);
+      CsDisk_Delete(cs, entry);
+      break;
+    case CslMdT2:
+      // entry revived from B1/B2 will have its Data packet in memory
+      CsDisk_Delete(cs, entry);
+      break;
+    default:
+      break;
+  }
+}
+
+bool
+CsDisk_RestoreData(Cs* cs, CsEntry* entry, PInterest* interest)
+{
+  NDNDPDK_ASSERT(CsEntry_IsDirect(entry));
+  if (entry->data != NULL) {
+    // Data was restored by another Interest, or refreshed from network
+    return true;
+  }
+
+  if (unlikely(entry->diskSlot != interest->diskSlot)) {
+    N_LOGD("RestoreData cs=%p cs-entry=%p slot=%" PRIu64 " interest-slot=%" PRIu64
+           " miss=slot-changed",
+           cs, entry, entry->diskSlot, interest->diskSlot);
+    ++cs->nDiskMiss;
+    return false;
+  }
+
+  Packet* npkt = interest->diskData;
+  if (unlikely(npkt == NULL)) {
+    N_LOGD("RestoreData cs=%p cs-entry=%p slot=%" PRIu64 " miss=read-error", cs, entry,
+           entry->diskSlot);
+    ++cs->nDiskMiss;
+    CsDisk_Delete(cs, entry);
+    return false;
+  }
+
+  PccEntry* pccEntry = PccEntry_FromCsEntry(entry);
+  if (unlikely(!PccKey_MatchName(&pccEntry->key, PName_ToLName(&Packet_GetDataHdr(npkt)->name)))) {
+    N_LOGD("RestoreData cs=%p cs-entry=%p slot=%" PRIu64 " miss=name-mismatch", cs, entry,
+           entry->diskSlot);
+    ++cs->nDiskMiss;
+    CsDisk_Delete(cs, entry);
+    return false;
+  }
+
+  N_LOGD("RestoreData cs=%p cs-entry=%p slot=%" PRIu64 " npkt=%p", cs, entry, entry->diskSlot,
+         npkt);
+  interest->diskData = NULL;
+  entry->data = npkt;
+  ++cs->nDiskHit;
+  return true;
+}
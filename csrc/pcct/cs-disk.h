@@ -0,0 +1,34 @@
+#ifndef NDNDPDK_PCCT_CS_DISK_H
+#define NDNDPDK_PCCT_CS_DISK_H
+
+/** @file */
+
+#include "cs-entry.h"
+
+/**
+ * @brief Callback when a direct entry moves between ARC lists.
+ *
+ * When an entry moves from T1/T2 into B1/B2, its Data packet is written to DiskStore, if enabled.
+ * When an entry moves out of B1/B2, its disk slot is released.
+ */
+__attribute__((nonnull)) void
+CsDisk_ArcMove(void* cs0, CsEntry* entry, CsListID src, CsListID dst);
+
+/**
+ * @brief Release the disk slot of a direct entry, if any.
+ * @post @c entry->diskSlot is zero.
+ */
+__attribute__((nonnull)) void
+CsDisk_Delete(Cs* cs, CsEntry* entry);
+
+/**
+ * @brief Determine whether a Data packet read from disk can be used on an Interest.
+ * @param entry direct entry that matches the Interest.
+ * @param interest Interest that has been processed by DiskStore_GetData.
+ * @return whether @p entry has its Data packet in memory.
+ * @post If the Data packet is usable, it is moved from @c interest->diskData into @p entry .
+ */
+__attribute__((nonnull)) bool
+CsDisk_RestoreData(Cs* cs, CsEntry* entry, PInterest* interest);
+
+#endif // NDNDPDK_PCCT_CS_DISK_H
@@ -10,8 +10,6 @@ enum
   CsMaxIndirects = 4,
 };
 
-typedef struct CsEntry CsEntry;
-
 /**
  * @brief A CS entry.
  *
@@ -43,6 +41,18 @@ struct CsEntry
    */
   TscTime freshUntil;
 
+  /**
+   * @brief DiskStore slot number where the Data packet is stored, or 0 if not on disk.
+   * @pre Valid if entry is direct.
+   */
+  uint64_t diskSlot;
+
+  /**
+   * @brief Data packet length in DiskStore.
+   * @pre Valid if entry is direct and @c diskSlot is non-zero.
+   */
+  uint16_t dataLen;
+
   /**
    * @brief Count of indirect entries depending on this direct entry,
    *        or -1 to indicate this entry is indirect.
@@ -89,6 +99,17 @@ CsEntry_GetData(CsEntry* entry)
   return CsEntry_GetDirect(entry)->data;
 }
 
+/**
+ * @brief Determine if @p entry has its Data packet in DiskStore instead of memory.
+ * @warning undefined behavior if @p entry does not have a direct entry.
+ */
+__attribute__((nonnull)) static __rte_always_inline bool
+CsEntry_IsOnDisk(CsEntry* entry)
+{
+  CsEntry* direct = CsEntry_GetDirect(entry);
+  return direct->data == NULL && direct->diskSlot != 0;
+}
+
 /**
  * @brief Determine if @p entry is fresh.
  * @warning undefined behavior if @p entry does not have a direct entry.
@@ -9,6 +9,10 @@
 /** @brief The prev-next pointers common in CsEntry and CsList. */
 typedef struct CsNode CsNode;
 
+typedef struct CsEntry CsEntry;
+typedef struct DiskStore DiskStore;
+typedef struct DiskAlloc DiskAlloc;
+
 /** @brief A doubly linked list within CS. */
 typedef struct CsList
 {
@@ -18,6 +22,9 @@ typedef struct CsList
   uint32_t capacity; // unused by CsList
 } CsList;
 
+/** @brief Callback when an entry moves between ARC lists. */
+typedef void (*CsArc_MoveCb)(void* arg, CsEntry* entry, CsListID src, CsListID dst);
+
 /** @brief Lists for Adaptive Replacement Cache (ARC). */
 typedef struct CsArc
 {
@@ -28,6 +35,8 @@ typedef struct CsArc
   CsList T2;  // stored entries that appeared more than once
   CsList B2;  // tracked entries that appeared more than once
   CsList Del; // deleted entries
+  CsArc_MoveCb moveCb;
+  void* moveCbArg;
   // B1.capacity is c, the total capacity
   // B2.capacity is 2c, twice the total capacity
   // T1.capacity is (uint32_t)p
@@ -44,6 +53,14 @@ typedef struct Cs
 {
   CsArc direct;    ///< ARC lists of direct entries
   CsList indirect; ///< LRU list of indirect entries
+
+  DiskStore* diskStore; ///< disk-backed second-tier storage, NULL if disabled
+  DiskAlloc* diskAlloc; ///< disk slot allocator
+  uint64_t nDiskInsert; ///< Data written to disk
+  uint64_t nDiskDelete; ///< Data deleted from disk
+  uint64_t nDiskFull;   ///< Data not written to disk due to unavailable slot
+  uint64_t nDiskHit;    ///< Interests satisfied by Data read from disk
+  uint64_t nDiskMiss;   ///< Interests not satisfied due to disk read failure or changed entry
 } Cs;
 
 #endif // NDNDPDK_PCCT_CS_STRUCT_H
@@ -1,4 +1,5 @@
 #include "cs.h"
+#include "cs-disk.h"
 #include "pit.h"
 
 #include "../core/logger.h"
@@ -43,6 +44,7 @@ CsEraseBatch_AddDirect(PcctEraseBatch* peb, CsEntry* entry)
     CsEraseBatch_Append_(peb, indirect, "indirect-dep");
   }
   entry->nIndirects = 0;
+  CsDisk_Delete(cs, entry);
   CsEntry_Finalize(entry);
   CsEraseBatch_Append_(peb, entry, "direct");
 }
@@ -108,7 +110,7 @@ Cs_Init(Cs* cs, uint32_t capMd, uint32_t capMi)
   capMd = RTE_MAX(capMd, CS_EVICT_BULK);
   capMi = RTE_MAX(capMi, CS_EVICT_BULK);
 
-  CsArc_Init(&cs->direct, capMd);
+  CsArc_Init(&cs->direct, capMd, CsDisk_ArcMove, cs);
   CsList_Init(&cs->indirect);
   cs->indirect.capacity = capMi;
 
@@ -157,6 +159,9 @@ Cs_PutDirect(Cs* cs, Packet* npkt, PccEntry* pccEntry)
           break;
         }
       }
+      CsDisk_Delete(cs, entry);
+    } else {
+      entry->diskSlot = 0;
     }
     CsEntry_Clear(entry);
     CsArc_Add(&cs->direct, entry);
@@ -170,6 +175,7 @@ Cs_PutDirect(Cs* cs, Packet* npkt, PccEntry* pccEntry)
     N_LOGD("PutDirect insert cs=%p npkt=%p pcc-entry=%p cs-entry=%p", cs, npkt, pccEntry, entry);
     entry->arcList = 0;
     entry->nIndirects = 0;
+    entry->diskSlot = 0;
     CsArc_Add(&cs->direct, entry);
   }
   entry->data = npkt;
@@ -217,6 +223,9 @@ Cs_PutIndirect(Cs* cs, CsEntry* direct, PccEntry* pccEntry)
     }
     // refresh indirect entry
     // old entry can be either direct without dependency or indirect
+    if (CsEntry_IsDirect(entry)) {
+      CsDisk_Delete(cs, entry);
+    }
     CsEntry_Clear(entry);
     CsList_MoveToLast(&cs->indirect, entry);
     N_LOGD("PutIndirect refresh cs=%p npkt=%p pcc-entry-%p cs-entry=%p count=%" PRIu32, cs, direct,
@@ -291,7 +300,6 @@ bool
 Cs_MatchInterest(Cs* cs, CsEntry* entry, Packet* interestNpkt)
 {
   CsEntry* direct = CsEntry_GetDirect(entry);
-  bool hasData = CsEntry_GetData(direct) != NULL;
   PccEntry* pccDirect = PccEntry_FromCsEntry(direct);
 
   PInterest* interest = Packet_GetInterestHdr(interestNpkt);
@@ -299,9 +307,14 @@ Cs_MatchInterest(Cs* cs, CsEntry* entry, Packet* interestNpkt)
   bool violateMustBeFresh =
     interest->mustBeFresh &&
     !CsEntry_IsFresh(direct, Mbuf_GetTimestamp(Packet_ToMbuf(interestNpkt)));
+  bool hasData = CsEntry_GetData(direct) != NULL;
+  if (unlikely(interest->diskSlot != 0) && !violateCanBePrefix && !violateMustBeFresh) {
+    hasData = CsDisk_RestoreData(cs, direct, interest);
+  }
+  bool onDisk = !hasData && interest->diskSlot == 0 && CsEntry_IsOnDisk(direct);
   N_LOGD("MatchInterest cs=%p cs-entry=%p~%s cbp=%s mbf=%s has-data=%s", cs, entry,
          CsEntry_IsDirect(entry) ? "direct" : "indirect", violateCanBePrefix ? "N" : "Y",
-         violateMustBeFresh ? "N" : "Y", hasData ? "Y" : "N");
+         violateMustBeFresh ? "N" : "Y", hasData ? "Y" : onDisk ? "disk" : "N");
 
   if (likely(!violateCanBePrefix && !violateMustBeFresh)) {
     if (!CsEntry_IsDirect(entry)) {
@@ -311,6 +324,10 @@ Cs_MatchInterest(Cs* cs, CsEntry* entry, Packet* interestNpkt)
       CsArc_Add(&cs->direct, direct);
       return true;
     }
+    if (onDisk) {
+      // caller should retrieve Data from disk; CsArc_Add is deferred until Data arrives
+      return true;
+    }
   }
   return false;
 }
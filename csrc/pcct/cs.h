@@ -32,7 +32,13 @@ Cs_Insert(Cs* cs, Packet* npkt, PitFindResult pitFound);
 /**
  * @brief Determine whether the CS entry matches an Interest during PIT insertion.
  * @param entry the CS entry, possibly indirect.
+ * @retval true the CS entry matches; either its Data packet is in memory, or the caller should
+ *              retrieve the Data packet from DiskStore when @c CsEntry_IsOnDisk is true.
  * @post the CS entry is erased if it would conflict with a PIT entry for the Interest.
+ *
+ * If the Interest carries a non-zero @c diskSlot, it is being re-processed after DiskStore
+ * retrieval. In this case, the Data packet in @c diskData may be moved into the CS entry, and
+ * this function would not request another DiskStore retrieval.
  */
 __attribute__((nonnull)) bool
 Cs_MatchInterest(Cs* cs, CsEntry* entry, Packet* interestNpkt);
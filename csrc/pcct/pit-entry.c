@@ -124,6 +124,7 @@ PitEntry_Timeout_(MinTmr* tmr, uintptr_t pitPtr)
     Pit_InvokeSgTimerCb_(pit, entry);
   } else {
     N_LOGD("Timeout(expiry) pit=%p pit-entry=%p", pit, entry);
+    Pit_InvokeExpiryCb_(pit, entry);
     Pit_Erase(pit, entry);
   }
 }
@@ -12,6 +12,9 @@ typedef struct PitEntry PitEntry;
 /** @brief Callback to handle strategy timer triggers. */
 typedef void (*Pit_SgTimerCb)(Pit* pit, PitEntry* entry, void* arg);
 
+/** @brief Callback to handle PIT entry expiry, invoked before the entry is erased. */
+typedef void (*Pit_ExpiryCb)(Pit* pit, PitEntry* entry, void* arg);
+
 /**
  * @brief The Pending Interest Table (PIT).
  *
@@ -34,6 +37,8 @@ struct Pit
   MinSched* timeoutSched;
   Pit_SgTimerCb sgTimerCb;
   void* sgTimerCbArg;
+  Pit_ExpiryCb expiryCb;
+  void* expiryCbArg;
 };
 
 #endif // NDNDPDK_PCCT_PIT_STRUCT_H
@@ -11,6 +11,11 @@ Pit_SgTimerCb_Empty(Pit* pit, PitEntry* entry, void* arg)
   N_LOGD("SgTimerCb pit=%p pit-entry=%p no-callback", pit, entry);
 }
 
+static void
+Pit_ExpiryCb_Empty(Pit* pit, PitEntry* entry, void* arg)
+{
+}
+
 void
 Pit_Init(Pit* pit)
 {
@@ -22,6 +27,7 @@ Pit_Init(Pit* pit)
                  (TscDuration)(PIT_MAX_LIFETIME * TscHz / 1000));
 
   pit->sgTimerCb = Pit_SgTimerCb_Empty;
+  pit->expiryCb = Pit_ExpiryCb_Empty;
 }
 
 void
@@ -31,6 +37,13 @@ Pit_SetSgTimerCb(Pit* pit, Pit_SgTimerCb cb, void* arg)
   pit->sgTimerCbArg = arg;
 }
 
+void
+Pit_SetExpiryCb(Pit* pit, Pit_ExpiryCb cb, void* arg)
+{
+  pit->expiryCb = cb;
+  pit->expiryCbArg = arg;
+}
+
 PitInsertResult
 Pit_Insert(Pit* pit, Packet* npkt, const FibEntry* fibEntry)
 {
@@ -56,7 +69,10 @@ Pit_Insert(Pit* pit, Packet* npkt, const FibEntry* fibEntry)
       // CS entry satisfies Interest
       N_LOGD("Insert has-CS pit=%p search=%s pcc=%p", pit, PccSearch_ToDebugString(&search),
              pccEntry);
-      ++pit->nCsMatch;
+      if (likely(!CsEntry_IsOnDisk(csEntry))) {
+        // disk entry is counted after Data is retrieved
+        ++pit->nCsMatch;
+      }
       return (PitInsertResult){ .kind = PIT_INSERT_CS, .csEntry = CsEntry_GetDirect(csEntry) };
     }
   }
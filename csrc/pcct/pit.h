@@ -30,6 +30,16 @@ Pit_InvokeSgTimerCb_(Pit* pit, PitEntry* entry)
   (*pit->sgTimerCb)(pit, entry, pit->sgTimerCbArg);
 }
 
+/** @brief Set callback when PIT entry expires. */
+__attribute__((nonnull(1))) void
+Pit_SetExpiryCb(Pit* pit, Pit_ExpiryCb cb, void* arg);
+
+__attribute__((nonnull)) static inline void
+Pit_InvokeExpiryCb_(Pit* pit, PitEntry* entry)
+{
+  (*pit->expiryCb)(pit, entry, pit->expiryCbArg);
+}
+
 /**
  * @brief Insert or find a PIT entry for the given Interest.
  * @param npkt Interest packet.
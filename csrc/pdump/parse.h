@@ -3,8 +3,23 @@
 
 /** @file */
 
+#include "../ndni/lp.h"
 #include "../ndni/name.h"
 #include "../ndni/tlv-decoder.h"
+#include "enum.h"
+
+/** @brief Packet attributes extracted by @c Pdump_Parse . */
+typedef struct PdumpParsed
+{
+  LName name;          ///< Interest/Data name, possibly truncated
+  LpPitToken pitToken; ///< PIT token
+  uint64_t seqNum;     ///< LpSeqNum
+  uint8_t fragIndex;   ///< FragIndex
+  uint8_t fragCount;   ///< FragCount
+  uint8_t pktType;     ///< one of PdumpPktBit*, or zero if unrecognized
+  uint8_t nackReason;  ///< NackReason, or NackNone if not a Nack
+  uint8_t congMark;    ///< CongestionMark
+} PdumpParsed;
 
 __attribute__((nonnull)) static inline LName
 Pdump_ExtractNameL3_(TlvDecoder* d)
@@ -19,48 +34,117 @@ Pdump_ExtractNameL3_(TlvDecoder* d)
   };
 }
 
+__attribute__((nonnull)) static inline void
+Pdump_ParseNack_(PdumpParsed* p, TlvDecoder* d, uint32_t length)
+{
+  p->nackReason = NackUnspecified;
+  TlvDecoder vd;
+  TlvDecoder_MakeValueDecoder(d, length, &vd);
+  TlvDecoder_EachTL (&vd, type, length1) {
+    if (type == TtNackReason) {
+      if (unlikely(!TlvDecoder_ReadNniTo(&vd, length1, &p->nackReason))) {
+        p->nackReason = NackUnspecified;
+      }
+      return;
+    }
+    TlvDecoder_Skip(&vd, length1);
+  }
+}
+
 /**
- * @brief Extract Interest/Data name from mbuf.
+ * @brief Extract packet attributes from mbuf.
  *
- * If @p pkt is an Interest/Data packet, extract its name.
- * If @p pkt is the first fragment of an Interest/Data packet, extract the portion of name
- * contained in this fragment; it may be truncated and contain incomplete name component.
- * Otherwise, return empty LName.
+ * If @p pkt is an Interest/Data packet, with or without NDNLPv2 headers, extract its type and name.
+ * If @p pkt is a fragment, its type is @c PdumpPktBitFragment , and its LpSeqNum, FragIndex, and
+ * FragCount are extracted; if it is also the first fragment of an Interest/Data packet, extract the
+ * portion of name contained in this fragment, which may be truncated and contain incomplete name
+ * component.
+ * NDNLPv2 PIT token, Nack, and CongestionMark fields are extracted from any LpPacket.
  */
-__attribute__((nonnull)) static inline LName
-Pdump_ExtractName(struct rte_mbuf* pkt)
+__attribute__((nonnull)) static inline void
+Pdump_Parse(struct rte_mbuf* pkt, PdumpParsed* p)
 {
+  *p = (const PdumpParsed){ .fragCount = 1 };
   TlvDecoder d;
   TlvDecoder_Init(&d, pkt);
   uint32_t length0, type0 = TlvDecoder_ReadTL(&d, &length0);
   switch (type0) {
     case TtInterest:
+      p->pktType = PdumpPktBitInterest;
+      p->name = Pdump_ExtractNameL3_(&d);
+      return;
     case TtData:
-      return Pdump_ExtractNameL3_(&d);
+      p->pktType = PdumpPktBitData;
+      p->name = Pdump_ExtractNameL3_(&d);
+      return;
     case TtLpPacket:
       break;
     default:
-      return (LName){ 0 };
+      return;
   }
 
   TlvDecoder_EachTL (&d, type1, length1) {
     switch (type1) {
+      case TtLpSeqNum: {
+        if (unlikely(length1 != 8 || !TlvDecoder_ReadNniTo(&d, length1, &p->seqNum))) {
+          return;
+        }
+        break;
+      }
       case TtFragIndex: {
-        uint8_t fragIndex = 0;
-        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, &fragIndex)) || fragIndex > 0) {
-          return (LName){ 0 };
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, &p->fragIndex))) {
+          return;
+        }
+        break;
+      }
+      case TtFragCount: {
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, &p->fragCount))) {
+          return;
+        }
+        break;
+      }
+      case TtPitToken: {
+        if (unlikely(length1 > sizeof(p->pitToken.value))) {
+          return;
+        }
+        p->pitToken.length = length1;
+        TlvDecoder_Copy(&d, p->pitToken.value, length1);
+        break;
+      }
+      case TtNack: {
+        Pdump_ParseNack_(p, &d, length1);
+        break;
+      }
+      case TtCongestionMark: {
+        if (unlikely(!TlvDecoder_ReadNniTo(&d, length1, &p->congMark))) {
+          return;
         }
         break;
       }
       case TtLpPayload: {
+        if (p->fragCount > 1) {
+          p->pktType = PdumpPktBitFragment;
+        }
+        if (p->fragIndex > 0) {
+          return;
+        }
+
         uint32_t length2, type2 = TlvDecoder_ReadTL_MaybeTruncated(&d, &length2);
         switch (type2) {
           case TtInterest:
-          case TtData: {
-            return Pdump_ExtractNameL3_(&d);
-          }
+            if (p->pktType == 0) {
+              p->pktType = p->nackReason == NackNone ? PdumpPktBitInterest : PdumpPktBitNack;
+            }
+            p->name = Pdump_ExtractNameL3_(&d);
+            return;
+          case TtData:
+            if (p->pktType == 0) {
+              p->pktType = PdumpPktBitData;
+            }
+            p->name = Pdump_ExtractNameL3_(&d);
+            return;
           default:
-            return (LName){ 0 };
+            return;
         }
       }
       default:
@@ -68,8 +152,18 @@ Pdump_ExtractName(struct rte_mbuf* pkt)
         break;
     }
   }
+}
 
-  return (LName){ 0 };
+/**
+ * @brief Extract Interest/Data name from mbuf.
+ * @sa Pdump_Parse
+ */
+__attribute__((nonnull)) static inline LName
+Pdump_ExtractName(struct rte_mbuf* pkt)
+{
+  PdumpParsed p;
+  Pdump_Parse(pkt, &p);
+  return p.name;
 }
 
 #endif // NDNDPDK_PDUMP_PARSE_H
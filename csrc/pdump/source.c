@@ -35,13 +35,12 @@ PdumpSourceRef_Set(PdumpSourceRef* ref, PdumpSource* s)
 }
 
 __attribute__((nonnull)) static __rte_always_inline uint32_t
-PdumpFaceSource_NameProb(const PdumpFaceSource* source, struct rte_mbuf* pkt)
+PdumpFaceSource_NameProb(const PdumpFaceSource* source, LName name)
 {
   if (source->nameL[0] == 0) {
     return source->sample[0];
   }
 
-  LName name = Pdump_ExtractName(pkt);
   if (unlikely(name.length == 0)) {
     return 0;
   }
@@ -50,11 +49,92 @@ PdumpFaceSource_NameProb(const PdumpFaceSource* source, struct rte_mbuf* pkt)
   return index >= 0 ? source->sample[index] : 0;
 }
 
+__attribute__((nonnull)) static __rte_always_inline bool
+PdumpFaceSource_MatchAttrs(const PdumpFaceSource* source, const PdumpParsed* p)
+{
+  if (source->pktTypes != 0 && (source->pktTypes & p->pktType) == 0) {
+    return false;
+  }
+
+  if (source->congMarked && p->congMark == 0) {
+    return false;
+  }
+
+  if (source->pitToken.length > 0 &&
+      (p->pitToken.length < source->pitToken.length ||
+       memcmp(p->pitToken.value, source->pitToken.value, source->pitToken.length) != 0)) {
+    return false;
+  }
+
+  if ((source->nackReasons[0] | source->nackReasons[1] | source->nackReasons[2] |
+       source->nackReasons[3]) != 0 &&
+      (p->nackReason == NackNone ||
+       (source->nackReasons[p->nackReason >> 6] & RTE_BIT64(p->nackReason & 0x3F)) == 0)) {
+    return false;
+  }
+
+  return true;
+}
+
+__attribute__((nonnull)) static __rte_always_inline bool
+PdumpFaceSource_Sample(PdumpFaceSource* source, uint32_t prob)
+{
+  return prob > 0 && // separate `prob>0` to skip pcg32 computation when there's no name match
+         prob > pcg32_random_r(&source->rng);
+}
+
+/** @brief Determine whether a non-first fragment belongs to a captured first fragment. */
+__attribute__((nonnull)) static bool
+PdumpFaceSource_FindFragBase(const PdumpFaceSource* source, const PdumpParsed* p)
+{
+  uint64_t seqNumBase = p->seqNum - p->fragIndex;
+  for (int i = 0; i < PdumpMaxFragBases; ++i) {
+    const PdumpFragBase* fb = &source->fragBases[i];
+    if (fb->seqNumBase == seqNumBase && fb->fragCount == p->fragCount) {
+      return true;
+    }
+  }
+  return false;
+}
+
 bool
 PdumpFaceSource_Filter(PdumpSource* s0, struct rte_mbuf* pkt)
 {
   PdumpFaceSource* s = container_of(s0, PdumpFaceSource, base);
-  uint32_t prob = PdumpFaceSource_NameProb(s, pkt);
-  return prob > 0 && // separate `prob>0` to skip pcg32 computation when there's no name match
-         prob > pcg32_random_r(&s->rng);
+  if (likely(!s->filterAttrs) && s->nameL[0] == 0) {
+    // skip parsing when every packet is accepted, which includes all fragments
+    return PdumpFaceSource_Sample(s, s->sample[0]);
+  }
+
+  PdumpParsed p;
+  Pdump_Parse(pkt, &p);
+  if (p.fragIndex > 0) {
+    return PdumpFaceSource_FindFragBase(s, &p);
+  }
+
+  if (!PdumpFaceSource_MatchAttrs(s, &p) ||
+      !PdumpFaceSource_Sample(s, PdumpFaceSource_NameProb(s, p.name))) {
+    return false;
+  }
+
+  if (p.fragCount > 1) {
+    s->fragBases[s->fragBasesNext] = (PdumpFragBase){
+      .seqNumBase = p.seqNum,
+      .fragCount = p.fragCount,
+    };
+    s->fragBasesNext = (s->fragBasesNext + 1) % PdumpMaxFragBases;
+  }
+  return true;
+}
+
+bool
+PdumpEthPortSource_Filter(PdumpSource* s0, struct rte_mbuf* pkt)
+{
+  PdumpEthPortSource* s = container_of(s0, PdumpEthPortSource, base);
+  for (uint8_t i = 0; i < s->nFaces; ++i) {
+    if (s->faces[i] == pkt->port) {
+      return true;
+    }
+  }
+  return false;
 }
@@ -5,6 +5,7 @@
 
 #include "../core/urcu.h"
 #include "../iface/faceid.h"
+#include "../ndni/lp.h"
 #include "../vendor/pcg_basic.h"
 #include "enum.h"
 #include <urcu-pointer.h>
@@ -53,6 +54,13 @@ PdumpSourceRef_Process(PdumpSourceRef* ref, struct rte_mbuf** pkts, uint16_t cou
   return true;
 }
 
+/** @brief LpSeqNum base of a captured first fragment. */
+typedef struct PdumpFragBase
+{
+  uint64_t seqNumBase;
+  uint8_t fragCount; ///< zero indicates empty slot
+} PdumpFragBase;
+
 /** @brief Packet dump from a face RxProc or TxProc. */
 typedef struct PdumpFaceSource
 {
@@ -61,9 +69,39 @@ typedef struct PdumpFaceSource
   uint32_t sample[PdumpMaxNames];
   uint16_t nameL[PdumpMaxNames];
   uint8_t nameV[PdumpMaxNames * NameMaxLength];
+
+  bool filterAttrs;        ///< whether any packet attribute filter is enabled
+  uint8_t pktTypes;        ///< accepted PdumpPktBit* bitmask, zero accepts any
+  bool congMarked;         ///< accept only packets carrying CongestionMark
+  LpPitToken pitToken;     ///< PIT token prefix, zero length accepts any
+  uint64_t nackReasons[4]; ///< accepted NackReason bitmap, all zeros accepts any
+
+  /**
+   * @brief LpSeqNum bases of recently captured first fragments.
+   *
+   * Non-first fragments are captured if their LpSeqNum base appears in this list.
+   * Slots are overwritten in round-robin order.
+   */
+  PdumpFragBase fragBases[PdumpMaxFragBases];
+  uint32_t fragBasesNext;
 } PdumpFaceSource;
 
 __attribute__((nonnull)) bool
 PdumpFaceSource_Filter(PdumpSource* s, struct rte_mbuf* pkt);
 
+/** @brief Packet dump from an Ethernet port. */
+typedef struct PdumpEthPortSource
+{
+  PdumpSource base;
+  FaceID faces[PdumpMaxFaces]; ///< accepted FaceIDs
+  uint8_t nFaces;
+} PdumpEthPortSource;
+
+/**
+ * @brief Filter packets by FaceID.
+ * @pre pkt->port is FaceID.
+ */
+__attribute__((nonnull)) bool
+PdumpEthPortSource_Filter(PdumpSource* s, struct rte_mbuf* pkt);
+
 #endif // NDNDPDK_PDUMP_SOURCE_H
@@ -1,18 +1,143 @@
 #include "writer.h"
+#include "../core/logger.h"
 #include "../iface/faceid.h"
 #include "format.h"
 
+#include <limits.h>
+#include <signal.h>
+#include <time.h>
+#include <unistd.h>
+
+N_LOG_INIT(PdumpWriter);
+
+enum
+{
+  StreamBufferSize = 1 << 16,
+};
+
+__attribute__((nonnull)) static void
+StreamFlush(PdumpWriter* w)
+{
+  size_t offset = 0;
+  while (offset < w->pos) {
+    ssize_t res = write(w->streamFd, RTE_PTR_ADD(w->streamBuf, offset), w->pos - offset);
+    if (unlikely(res < 0)) {
+      if (errno == EINTR) {
+        continue;
+      }
+      N_LOGW("write(fd=%d) stream closed" N_LOG_ERROR_ERRNO, w->streamFd, errno);
+      w->full = true;
+      break;
+    }
+    offset += (size_t)res;
+  }
+  w->pos = 0;
+}
+
+/**
+ * @brief Open next output file.
+ * @pre Rotation is enabled, or this is the first file.
+ */
+__attribute__((nonnull)) static bool
+OpenFile(PdumpWriter* w)
+{
+  const char* filename = w->filename;
+  if (w->maxFiles > 0) {
+    char* name = &w->rotated[(w->nFiles % w->maxFiles) * PATH_MAX];
+    if (w->nFiles >= w->maxFiles && unlink(name) != 0) {
+      N_LOGW("unlink(%s)" N_LOG_ERROR_ERRNO, name, errno);
+    }
+
+    time_t now = time(NULL);
+    struct tm tm;
+    char timestamp[16];
+    strftime(timestamp, sizeof(timestamp), "%Y%m%d%H%M%S", localtime_r(&now, &tm));
+    snprintf(name, PATH_MAX, "%s_%05" PRIu32 "_%s%s", w->filename, w->nFiles, timestamp,
+             w->suffix);
+    ++w->nFiles;
+    filename = name;
+  }
+
+  if (!MmapFd_Open(&w->m, filename, w->maxSize)) {
+    return false;
+  }
+  if (w->hdrLen > 0) {
+    rte_memcpy(MmapFd_At(&w->m, 0), w->hdr, w->hdrLen);
+  }
+  w->pos = w->hdrLen;
+  N_LOGI("open %s", filename);
+  return true;
+}
+
+__attribute__((nonnull)) static bool
+RotateFile(PdumpWriter* w)
+{
+  if (!MmapFd_Close(&w->m, w->pos)) {
+    N_LOGW("close error, continuing with next file");
+  }
+  return OpenFile(w);
+}
+
+/**
+ * @brief Reserve room for a block in the output.
+ * @return pointer to the room, or NULL if the block cannot be written.
+ *
+ * After writing into the room, caller should advance w->pos by @p len .
+ */
+__attribute__((nonnull)) static uint8_t*
+Reserve(PdumpWriter* w, size_t len)
+{
+  if (w->streamFd >= 0) {
+    if (unlikely(w->pos + len > StreamBufferSize)) {
+      StreamFlush(w);
+      if (unlikely(len > StreamBufferSize)) {
+        return NULL;
+      }
+    }
+    return RTE_PTR_ADD(w->streamBuf, w->pos);
+  }
+
+  if (likely(w->pos + len <= w->m.size)) {
+    return MmapFd_At(&w->m, w->pos);
+  }
+
+  if (w->maxFiles == 0) {
+    w->full = true;
+    return NULL;
+  }
+  if (unlikely(w->hdrLen + len > w->maxSize)) { // block cannot fit in any file
+    return NULL;
+  }
+  if (unlikely(!RotateFile(w))) {
+    w->full = true;
+    return NULL;
+  }
+  return MmapFd_At(&w->m, w->pos);
+}
+
 __attribute__((nonnull)) static __rte_noinline void
 WriteBlock(PdumpWriter* w, struct rte_mbuf* pkt)
 {
   NDNDPDK_ASSERT(pkt->pkt_len % 4 == 0);
   NDNDPDK_ASSERT(pkt->pkt_len == pkt->data_len);
-  if (unlikely(w->pos + pkt->pkt_len > w->m.size)) {
-    w->full = true;
-    return;
+  const uint8_t* block = rte_pktmbuf_mtod(pkt, const uint8_t*);
+
+  uint8_t* room = Reserve(w, pkt->pkt_len);
+  if (likely(room != NULL)) {
+    rte_memcpy(room, block, pkt->pkt_len);
+    w->pos += pkt->pkt_len;
+  }
+
+  if (w->maxFiles > 0) { // save for the next rotated file, after Reserve might have rotated
+    uint8_t* hdr = rte_realloc(w->hdr, w->hdrLen + pkt->pkt_len, 0);
+    if (unlikely(hdr == NULL)) {
+      N_LOGW("rte_realloc error, next rotated file would lack this block");
+      return;
+    }
+    rte_memcpy(RTE_PTR_ADD(hdr, w->hdrLen), block, pkt->pkt_len);
+    w->hdr = hdr;
+    w->hdrLen += pkt->pkt_len;
   }
-  rte_memcpy(MmapFd_At(&w->m, w->pos), rte_pktmbuf_mtod(pkt, const uint8_t*), pkt->pkt_len);
-  w->pos += pkt->pkt_len;
 }
 
 /**
@@ -30,8 +155,8 @@ WriteEPB(PdumpWriter* w, struct rte_mbuf* pkt, PcapngEPB* epb, size_t hdrLen)
 
   uint32_t totalLength = hdrLen + pkt->pkt_len + sizeof(PcapngTrailer);
   totalLength = (totalLength + 0x03) & (~0x03);
-  if (unlikely(w->pos + totalLength > w->m.size)) {
-    w->full = true;
+  uint8_t* room = Reserve(w, totalLength);
+  if (unlikely(room == NULL)) {
     return;
   }
 
@@ -46,9 +171,9 @@ WriteEPB(PdumpWriter* w, struct rte_mbuf* pkt, PcapngEPB* epb, size_t hdrLen)
     .capLen = rte_cpu_to_le_32(pktLen),
     .origLen = rte_cpu_to_le_32(pktLen),
   };
-  rte_memcpy(MmapFd_At(&w->m, w->pos), epb, hdrLen);
+  rte_memcpy(room, epb, hdrLen);
 
-  uint8_t* dst = MmapFd_At(&w->m, w->pos + hdrLen);
+  uint8_t* dst = RTE_PTR_ADD(room, hdrLen);
   const uint8_t* readTo = rte_pktmbuf_read(pkt, 0, pkt->pkt_len, dst);
   if (readTo != dst) {
     rte_memcpy(dst, readTo, pkt->pkt_len);
@@ -57,7 +182,7 @@ WriteEPB(PdumpWriter* w, struct rte_mbuf* pkt, PcapngEPB* epb, size_t hdrLen)
   PcapngTrailer trailer = {
     .totalLength = epb->totalLength,
   };
-  rte_memcpy(MmapFd_At(&w->m, w->pos + totalLength - sizeof(trailer)), &trailer, sizeof(trailer));
+  rte_memcpy(RTE_PTR_ADD(room, totalLength - sizeof(trailer)), &trailer, sizeof(trailer));
 
   w->pos += totalLength;
 }
@@ -105,10 +230,55 @@ ProcessMbuf(PdumpWriter* w, struct rte_mbuf* pkt)
   }
 }
 
+__attribute__((nonnull)) static bool
+OpenOutput(PdumpWriter* w)
+{
+  if (w->maxFiles > 0) {
+    w->rotated = rte_zmalloc("PdumpWriter.rotated", (size_t)w->maxFiles * PATH_MAX, 0);
+    if (w->rotated == NULL) {
+      return false;
+    }
+  }
+
+  if (w->streamFd < 0) {
+    return OpenFile(w);
+  }
+
+  // write errors are reported via EPIPE instead of terminating the process
+  sigset_t sigpipe;
+  sigemptyset(&sigpipe);
+  sigaddset(&sigpipe, SIGPIPE);
+  pthread_sigmask(SIG_BLOCK, &sigpipe, NULL);
+
+  w->streamBuf = rte_malloc("PdumpWriter.streamBuf", StreamBufferSize, 0);
+  return w->streamBuf != NULL;
+}
+
+__attribute__((nonnull)) static bool
+CloseOutput(PdumpWriter* w)
+{
+  bool ok = true;
+  if (w->streamFd >= 0) {
+    StreamFlush(w);
+  } else if (w->m.size > 0) {
+    ok = MmapFd_Close(&w->m, w->pos);
+  }
+
+  rte_free(w->streamBuf);
+  w->streamBuf = NULL;
+  rte_free(w->rotated);
+  w->rotated = NULL;
+  rte_free(w->hdr);
+  w->hdr = NULL;
+  w->hdrLen = 0;
+  return ok;
+}
+
 int
 PdumpWriter_Run(PdumpWriter* w)
 {
-  if (!MmapFd_Open(&w->m, w->filename, w->maxSize)) {
+  if (!OpenOutput(w)) {
+    CloseOutput(w);
     return 1;
   }
 
@@ -121,9 +291,13 @@ PdumpWriter_Run(PdumpWriter* w)
       ProcessMbuf(w, pkts[i]);
     }
     rte_pktmbuf_free_bulk(pkts, count);
+
+    if (w->streamFd >= 0 && count == 0 && w->pos > 0) {
+      StreamFlush(w); // deliver buffered blocks to live viewer when idle
+    }
   }
 
-  if (!MmapFd_Close(&w->m, w->pos)) {
+  if (!CloseOutput(w)) {
     return 2;
   }
   return 0;
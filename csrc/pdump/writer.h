@@ -11,10 +11,20 @@ typedef struct PdumpWriter
 {
   ThreadCtrl ctrl;
   struct rte_ring* queue;
-  const char* filename;
+  const char* filename; ///< output filename, or filename prefix if rotation is enabled
+  const char* suffix;   ///< filename suffix if rotation is enabled
   MmapFd m;
   size_t maxSize;
   size_t pos;
+
+  uint8_t* hdr;       ///< SHB and IDBs, written at the start of every rotated file
+  size_t hdrLen;      ///< length of hdr
+  char* rotated;      ///< maxFiles*PATH_MAX buffer of rotated filenames
+  uint32_t maxFiles;  ///< number of rotated files to keep, 0 disables rotation
+  uint32_t nFiles;    ///< number of rotated files created
+  int streamFd;       ///< stream output file descriptor, -1 disables streaming
+  uint8_t* streamBuf; ///< stream output buffer
+
   uint32_t nextIntf;
   bool full;
   uint32_t intf[UINT16_MAX + 1];
@@ -73,6 +73,27 @@ SgFibNexthopIt_Init2(SgFibNexthopIt* it, const SgCtx* ctx)
     (T*)(ctx)->fibEntryDyn->scratch;                                                               \
   })
 
+/**
+ * @brief Access a strategy parameter.
+ * @param index parameter slot index, i.e. position of the property in the schema.
+ */
+#define SgCtx_Param(ctx, index)                                                                    \
+  __extension__({                                                                                  \
+    static_assert((index) >= 0 && (index) < FibMaxParams, "");                                     \
+    (ctx)->fibEntry->params[(index)];                                                              \
+  })
+
+/**
+ * @brief Declare the strategy parameters schema.
+ * @param json JSON schema of an object, as a string literal.
+ *
+ * Each property must have "integer" or "boolean" type, and may have a "default" value.
+ * Parameters are validated against this schema when they are assigned to a FIB entry, and then
+ * become available via @c SgCtx_Param , in the order of properties in the schema.
+ */
+#define SGPARAMS_SCHEMA(json)                                                                      \
+  __attribute__((section(".sgschema"), used)) static const char SgParamsSchema_[] = json
+
 /** @brief Access PIT entry scratch area as T* type. */
 #define SgCtx_PitScratchT(ctx, T)                                                                  \
   __extension__({                                                                                  \
@@ -105,17 +126,71 @@ typedef enum SgForwardInterestResult
 /**
  * @brief Forward an Interest to a nexthop.
  * @pre Not available in @c SGEVT_DATA.
+ *
+ * In @c SGEVT_NACK and @c SGEVT_TIMER, this retries the pending Interest stored in the PIT entry,
+ * such as on an alternative nexthop after the previous nexthop has returned a Nack.
+ * In @c SGEVT_NACK, ctx->nhFlt excludes the nexthop that has returned the Nack and the
+ * downstream faces. In @c SGEVT_TIMER, ctx->nhFlt excludes the downstream faces.
  */
 __attribute__((nonnull)) SgForwardInterestResult
 SgForwardInterest(SgCtx* ctx, FaceID nh);
 
 /**
  * @brief Return Nacks downstream and erase PIT entry.
- * @pre Only available in @c SGEVT_INTEREST.
+ * @pre Not available in @c SGEVT_DATA.
+ *
+ * The PIT entry is erased after the strategy program returns.
+ * The strategy should not forward the Interest after calling this function.
  */
 __attribute__((nonnull)) void
 SgReturnNacks(SgCtx* ctx, SgNackReason reason);
 
+/**
+ * @brief Retrieve the Nack reason returned by an upstream nexthop.
+ * @pre Not available in @c SGEVT_DATA.
+ * @return Nack reason of the last Nack from @p nh , or @c SgNackNone if the Interest has not been
+ *         forwarded to @p nh or @p nh has not returned a Nack since the last transmission.
+ */
+__attribute__((nonnull)) SgNackReason
+SgGetNackReason(SgCtx* ctx, FaceID nh);
+
+/**
+ * @brief Request the control plane to add, refresh, or remove a learned nexthop.
+ * @param nhLifetime FaceID in bits 0-15, lifetime in milliseconds in bits 32-63.
+ * @return whether the request has been queued.
+ * @sa SgLearnNexthop, SgForgetNexthop
+ */
+__attribute__((nonnull)) bool
+SgLearnNexthop_(SgCtx* ctx, uint64_t nhLifetime);
+
+/**
+ * @brief Request the control plane to add a learned nexthop to the FIB entry, or extend its
+ *        lifetime.
+ * @param lifetime learned nexthop lifetime in milliseconds, must be positive.
+ * @return whether the request has been queued.
+ *
+ * The request is processed asynchronously. The learned nexthop appears in ctx->fibEntry in a
+ * subsequent invocation, and is removed when its lifetime expires.
+ * This function is available in all events.
+ */
+inline bool
+SgLearnNexthop(SgCtx* ctx, FaceID nh, uint32_t lifetime)
+{
+  return SgLearnNexthop_(ctx, ((uint64_t)lifetime << 32) | nh);
+}
+
+/**
+ * @brief Request the control plane to remove a learned nexthop from the FIB entry.
+ * @return whether the request has been queued.
+ *
+ * Nexthops that are not added by @c SgLearnNexthop are unaffected.
+ */
+inline bool
+SgForgetNexthop(SgCtx* ctx, FaceID nh)
+{
+  return SgLearnNexthop_(ctx, nh);
+}
+
 /**
  * @brief The strategy program.
  * @return status code, ignored by forwarding but appears in logs.
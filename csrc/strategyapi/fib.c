@@ -3,9 +3,20 @@
 
 static_assert(offsetof(SgFibEntry, nNexthops) == offsetof(FibEntry, nNexthops), "");
 static_assert(offsetof(SgFibEntry, nexthops) == offsetof(FibEntry, nexthops), "");
+static_assert(offsetof(SgFibEntry, params) == offsetof(FibEntry, params), "");
 static_assert(sizeof(SgFibEntry) <= sizeof(FibEntry), "");
 
 static_assert(offsetof(SgFibEntryDyn, scratch) == offsetof(FibEntryDyn, scratch), "");
+static_assert(offsetof(SgFibEntryDyn, nhStats) == offsetof(FibEntryDyn, nhStats), "");
 static_assert(sizeof(SgFibEntryDyn) <= sizeof(FibEntryDyn), "");
 
+static_assert(sizeof(SgFibNexthopStats) == sizeof(FibNexthopStats), "");
+static_assert(offsetof(SgFibNexthopStats, sRtt) == offsetof(FibNexthopStats, sRtt), "");
+static_assert(offsetof(SgFibNexthopStats, rttVar) == offsetof(FibNexthopStats, rttVar), "");
+static_assert(offsetof(SgFibNexthopStats, rto) == offsetof(FibNexthopStats, rto), "");
+static_assert(offsetof(SgFibNexthopStats, nSatisfied) == offsetof(FibNexthopStats, nSatisfied),
+              "");
+static_assert(offsetof(SgFibNexthopStats, nTimeouts) == offsetof(FibNexthopStats, nTimeouts), "");
+static_assert(offsetof(SgFibNexthopStats, nNacks) == offsetof(FibNexthopStats, nNacks), "");
+
 static_assert(sizeof(SgFibNexthopFilter) == sizeof(FibNexthopFilter), "");
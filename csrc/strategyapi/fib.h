@@ -6,10 +6,30 @@
 #include "../fib/enum.h"
 #include "common.h"
 
+/** @brief Per-nexthop statistics maintained by forwarding. */
+typedef struct SgFibNexthopStats
+{
+  TscDuration sRtt;    ///< smoothed RTT, zero if there is no RTT sample
+  TscDuration rttVar;  ///< RTT variation
+  TscDuration rto;     ///< retransmission timeout, zero if there is no RTT sample
+  uint32_t nSatisfied; ///< Interests satisfied by Data
+  uint32_t nTimeouts;  ///< Interests expired without Data or Nack
+  uint32_t nNacks;     ///< Interests answered by Nack
+  char a_[4];
+} SgFibNexthopStats;
+
 typedef struct SgFibEntryDyn
 {
   char a_[32];
   char scratch[FibScratchSize];
+
+  /**
+   * @brief Per-nexthop statistics, in the same order as nexthops in SgFibEntry.
+   *
+   * These are updated by forwarding upon Data, Nack, and PIT entry expiry.
+   * Strategies should not modify them.
+   */
+  const SgFibNexthopStats nhStats[FibMaxNexthops];
 } SgFibEntryDyn;
 
 typedef struct SgFibEntry
@@ -18,6 +38,14 @@ typedef struct SgFibEntry
   uint8_t nNexthops;
   char b_[2];
   FaceID nexthops[FibMaxNexthops];
+
+  /**
+   * @brief Strategy parameters.
+   *
+   * Each parameter occupies a slot, in the order of properties in the schema declared with
+   * @c SGPARAMS_SCHEMA . Absent parameters have their default values, or zero.
+   */
+  int64_t params[FibMaxParams];
 } SgFibEntry;
 
 typedef uint32_t SgFibNexthopFilter;
@@ -12,6 +12,7 @@ static_assert(offsetof(SgPacket, congMark) - offsetof(SgPacket, mbuf_end_) ==
                 offsetof(PacketPriv, lpl3) + offsetof(LpL3, congMark),
               "");
 
+static_assert((int)SgNackNone == (int)NackNone, "");
 static_assert((int)SgNackCongestion == (int)NackCongestion, "");
 static_assert((int)SgNackDuplicate == (int)NackDuplicate, "");
 static_assert((int)SgNackNoRoute == (int)NackNoRoute, "");
@@ -5,6 +5,11 @@
 
 #include "common.h"
 
+/**
+ * @brief Incoming Data or Nack packet.
+ *
+ * This is a partial view of Packet that exposes fields accessible to strategy programs.
+ */
 typedef struct SgPacket
 {
   char a_[22];
@@ -22,12 +27,13 @@ typedef struct SgPacket
   };
   char d_[92];
   char mbuf_end_[0];
-  uint8_t nackReason;
-  uint8_t congMark;
+  uint8_t nackReason; ///< Nack reason, see SgNackReason
+  uint8_t congMark;   ///< congestion mark, nonzero if the packet is marked
 } SgPacket;
 
 typedef enum SgNackReason
 {
+  SgNackNone = 0,
   SgNackCongestion = 50,
   SgNackDuplicate = 100,
   SgNackNoRoute = 150,
@@ -0,0 +1,149 @@
+#include "sgtest.h"
+
+static int
+SgTestCtx_FindNexthop(const SgTestCtx* ctx, FaceID nh)
+{
+  for (int i = 0; i < ctx->fibEntry.nNexthops; ++i) {
+    if (ctx->fibEntry.nexthops[i] == nh) {
+      return i;
+    }
+  }
+  return -1;
+}
+
+static SgTestCall*
+SgTestCtx_Record(SgTestCtx* ctx, SgTestCallKind kind)
+{
+  uint32_t i = ctx->nCalls++;
+  if (unlikely(i >= SgTestMaxCalls)) {
+    return NULL;
+  }
+  SgTestCall* call = &ctx->calls[i];
+  *call = (const SgTestCall){ .kind = kind };
+  return call;
+}
+
+int
+SgTestCtx_RejectNexthop(SgTestCtx* ctx, FaceID nh)
+{
+  int i = SgTestCtx_FindNexthop(ctx, nh);
+  if (i >= 0) {
+    ctx->sg.nhFlt |= (1 << i);
+  }
+  return i;
+}
+
+uint64_t
+SgTestCtx_Invoke(SgTestCtx* ctx, StrategyCode* sc)
+{
+  ctx->sg.global = &ctx->global;
+  ctx->sg.pkt = (ctx->sg.eventKind == SGEVT_DATA || ctx->sg.eventKind == SGEVT_NACK) ? &ctx->pkt
+                                                                                     : NULL;
+  ctx->sg.fibEntry = &ctx->fibEntry;
+  ctx->sg.fibEntryDyn = &ctx->fibEntryDyn;
+  ctx->sg.pitEntry = &ctx->pitEntry;
+  ctx->nackReturned = false;
+  ctx->nCalls = 0;
+  return StrategyCode_Run(sc, &ctx->sg, sizeof(SgCtx));
+}
+
+static bool
+SgTest_SetTimer(SgCtx* ctx0, TscDuration after)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, sg);
+  SgTestCall* call = SgTestCtx_Record(ctx, SgTestCallSetTimer);
+  if (call != NULL) {
+    call->after = after;
+  }
+  return ctx->timerResult;
+}
+
+static SgForwardInterestResult
+SgTest_ForwardInterest(SgCtx* ctx0, FaceID nh)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, sg);
+  SgTestCall* call = SgTestCtx_Record(ctx, SgTestCallForwardInterest);
+  if (call != NULL) {
+    call->nh = nh;
+  }
+
+  int i = SgTestCtx_FindNexthop(ctx, nh);
+  if (i < 0) {
+    return SGFWDI_BADFACE;
+  }
+  SgForwardInterestResult res = ctx->fwdResult[i];
+  if (res == SGFWDI_OK) {
+    ctx->nackReason[i] = SgNackNone;
+  }
+  return res;
+}
+
+static void
+SgTest_ReturnNacks(SgCtx* ctx0, SgNackReason reason)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, sg);
+  SgTestCall* call = SgTestCtx_Record(ctx, SgTestCallReturnNacks);
+  if (call != NULL) {
+    call->reason = reason;
+  }
+  ctx->nackReturned = true;
+}
+
+static SgNackReason
+SgTest_GetNackReason(SgCtx* ctx0, FaceID nh)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, sg);
+  int i = SgTestCtx_FindNexthop(ctx, nh);
+  if (i < 0) {
+    return SgNackNone;
+  }
+  return ctx->nackReason[i];
+}
+
+static bool
+SgTest_LearnNexthop(SgCtx* ctx0, uint64_t nhLifetime)
+{
+  SgTestCtx* ctx = container_of(ctx0, SgTestCtx, sg);
+  SgTestCall* call = SgTestCtx_Record(ctx, SgTestCallLearnNexthop);
+  if (call != NULL) {
+    call->nh = (FaceID)nhLifetime;
+    call->lifetime = nhLifetime >> 32;
+  }
+  return true;
+}
+
+#define SGTEST_XSYM(fn, impl)                                                                      \
+  {                                                                                                \
+    .name = #fn,                                                                                   \
+    .type = RTE_BPF_XTYPE_FUNC,                                                                    \
+    .func = {                                                                                      \
+      .val = (void*)(impl),                                                                        \
+      .nb_args = 2,                                                                                \
+      .args =                                                                                      \
+        {                                                                                          \
+          [0] =                                                                                    \
+            {                                                                                      \
+              .type = RTE_BPF_ARG_PTR,                                                             \
+              .size = sizeof(SgCtx),                                                               \
+            },                                                                                     \
+          [1] =                                                                                    \
+            {                                                                                      \
+              .type = RTE_BPF_ARG_RAW,                                                             \
+            },                                                                                     \
+        },                                                                                         \
+    },                                                                                             \
+  }
+
+const struct rte_bpf_xsym*
+SgTest_GetXsyms(int* nXsyms)
+{
+  static const struct rte_bpf_xsym xsyms[] = {
+    SGTEST_XSYM(SgSetTimer, SgTest_SetTimer),
+    SGTEST_XSYM(SgForwardInterest, SgTest_ForwardInterest),
+    SGTEST_XSYM(SgReturnNacks, SgTest_ReturnNacks),
+    SGTEST_XSYM(SgGetNackReason, SgTest_GetNackReason),
+    SGTEST_XSYM(SgLearnNexthop_, SgTest_LearnNexthop),
+  };
+  *nXsyms = RTE_DIM(xsyms);
+  return xsyms;
+}
@@ -0,0 +1,79 @@
+#ifndef NDNDPDK_STRATEGYCODE_SGTEST_H
+#define NDNDPDK_STRATEGYCODE_SGTEST_H
+
+/** @file */
+
+#include "../strategyapi/api.h"
+#include "strategy-code.h"
+
+/** @brief Strategy API function recorded by the test harness. */
+typedef enum SgTestCallKind
+{
+  SgTestCallNone,
+  SgTestCallSetTimer,
+  SgTestCallForwardInterest,
+  SgTestCallReturnNacks,
+  SgTestCallLearnNexthop,
+} SgTestCallKind;
+
+/** @brief Record of a strategy API function call. */
+typedef struct SgTestCall
+{
+  TscDuration after; ///< SgSetTimer duration
+  uint32_t lifetime; ///< SgLearnNexthop lifetime in milliseconds, zero for SgForgetNexthop
+  uint8_t kind;      ///< SgTestCallKind
+  uint8_t reason;    ///< SgReturnNacks reason
+  FaceID nh;         ///< SgForwardInterest or SgLearnNexthop nexthop
+} SgTestCall;
+
+enum
+{
+  SgTestMaxCalls = 64,
+};
+
+/**
+ * @brief Strategy invocation context of the test harness.
+ *
+ * This contains fake FIB entry, PIT entry, and packet that are visible to the strategy program
+ * through the strategy API. The strategy API functions record their calls, and return results
+ * configured in this struct.
+ */
+typedef struct SgTestCtx
+{
+  SgCtx sg;
+  SgGlobal global;
+  SgPacket pkt;
+  SgFibEntry fibEntry;
+  SgFibEntryDyn fibEntryDyn;
+  SgPitEntry pitEntry;
+
+  uint8_t fwdResult[FibMaxNexthops];  ///< SgForwardInterest result of each nexthop
+  uint8_t nackReason[FibMaxNexthops]; ///< SgGetNackReason result of each nexthop
+  bool timerResult;                   ///< SgSetTimer result
+  bool nackReturned;                  ///< whether SgReturnNacks has been called
+
+  uint32_t nCalls; ///< number of calls, may exceed SgTestMaxCalls
+  SgTestCall calls[SgTestMaxCalls];
+} SgTestCtx;
+
+/**
+ * @brief Exclude a nexthop from ctx->sg.nhFlt.
+ * @return nexthop index, or -1 if @p nh is not a nexthop.
+ */
+__attribute__((nonnull)) int
+SgTestCtx_RejectNexthop(SgTestCtx* ctx, FaceID nh);
+
+/**
+ * @brief Invoke the strategy.
+ * @pre ctx->sg.eventKind, ctx->sg.now, ctx->sg.nhFlt, and the fake entries are prepared.
+ *
+ * Calls recorded in the previous invocation are cleared.
+ */
+__attribute__((nonnull)) uint64_t
+SgTestCtx_Invoke(SgTestCtx* ctx, StrategyCode* sc);
+
+/** @brief Obtain external symbols that record strategy API function calls. */
+__attribute__((nonnull, returns_nonnull)) const struct rte_bpf_xsym*
+SgTest_GetXsyms(int* nXsyms);
+
+#endif // NDNDPDK_STRATEGYCODE_SGTEST_H
@@ -3,8 +3,7 @@
 void
 StrategyCode_Ref(StrategyCode* sc)
 {
-  NDNDPDK_ASSERT(sc->bpf != NULL);
-  NDNDPDK_ASSERT(sc->jit != NULL);
+  NDNDPDK_ASSERT(sc->prog != NULL);
   atomic_fetch_add_explicit(&sc->nRefs, 1, memory_order_acq_rel);
 }
 
@@ -17,11 +16,34 @@ StrategyCode_Unref(StrategyCode* sc)
     return;
   }
 
-  rte_bpf_destroy(sc->bpf);
+  if (sc->prog != NULL) {
+    StrategyCodeProg_Free(sc->prog);
+  }
   free(sc->name);
   rte_free(sc);
 }
 
+StrategyCodeProg*
+StrategyCode_Replace(StrategyCode* sc, StrategyCode* src, bool resetScratch)
+{
+  StrategyCodeProg* prog = src->prog;
+  src->prog = NULL;
+  prog->scratchGen = sc->prog->scratchGen + (uint32_t)resetScratch;
+
+  char* name = sc->name;
+  sc->name = src->name;
+  src->name = name;
+
+  return rcu_xchg_pointer(&sc->prog, prog);
+}
+
+void
+StrategyCodeProg_Free(StrategyCodeProg* prog)
+{
+  rte_bpf_destroy(prog->bpf);
+  rte_free(prog);
+}
+
 const struct ebpf_insn*
 StrategyCode_GetEmptyProgram_(uint32_t* nInsn)
 {
@@ -4,20 +4,49 @@
 /** @file */
 
 #include "../core/common.h"
+#include "../core/urcu.h"
 #include <rte_bpf.h>
 
 typedef uint64_t (*StrategyCodeFunc)(void*, size_t);
 
-/** @brief BPF program of a forwarding strategy. */
-typedef struct StrategyCode
+/** @brief Loaded BPF program of a forwarding strategy. */
+typedef struct StrategyCodeProg
 {
-  char* name;           ///< descriptive name
   struct rte_bpf* bpf;  ///< BPF execution context
   StrategyCodeFunc jit; ///< JIT-compiled strategy function
-  int id;               ///< identifier
-  atomic_int nRefs;     ///< how many FibEntry* reference this
+  uint32_t scratchGen;  ///< FIB entry scratch areas from another generation should be cleared
+} StrategyCodeProg;
+
+/** @brief Forwarding strategy. */
+typedef struct StrategyCode
+{
+  char* name;             ///< descriptive name
+  StrategyCodeProg* prog; ///< BPF program, replaceable via RCU
+  int id;                 ///< identifier
+  atomic_int nRefs;       ///< how many FibEntry* reference this
 } StrategyCode;
 
+/**
+ * @brief Retrieve the current BPF program.
+ * @pre Calling thread holds rcu_read_lock, which must be retained until it stops using the program.
+ */
+__attribute__((nonnull, returns_nonnull)) static inline const StrategyCodeProg*
+StrategyCode_GetProg(StrategyCode* sc)
+{
+  return rcu_dereference(sc->prog);
+}
+
+/**
+ * @brief Run a BPF program.
+ * @param arg argument to BPF program.
+ * @param sizeofArg sizeof(*arg)
+ */
+__attribute__((nonnull)) static inline uint64_t
+StrategyCodeProg_Run(const StrategyCodeProg* prog, void* arg, size_t sizeofArg)
+{
+  return (*prog->jit)(arg, sizeofArg);
+}
+
 /**
  * @brief Run the strategy's BPF program.
  * @param arg argument to BPF program.
@@ -26,7 +55,7 @@ typedef struct StrategyCode
 __attribute__((nonnull)) static inline uint64_t
 StrategyCode_Run(StrategyCode* sc, void* arg, size_t sizeofArg)
 {
-  return (*sc->jit)(arg, sizeofArg);
+  return StrategyCodeProg_Run(StrategyCode_GetProg(sc), arg, sizeofArg);
 }
 
 __attribute__((nonnull)) void
@@ -35,6 +64,21 @@ StrategyCode_Ref(StrategyCode* sc);
 __attribute__((nonnull)) void
 StrategyCode_Unref(StrategyCode* sc);
 
+/**
+ * @brief Move the BPF program and name of @p src into @p sc .
+ * @param resetScratch whether FIB entry scratch areas should be cleared before the new program
+ *                     runs on them.
+ * @return the old BPF program of @p sc , which should be released with @c StrategyCodeProg_Free
+ *         after an RCU grace period.
+ * @post @p src has the old name of @p sc and no BPF program; it should be unreferenced.
+ */
+__attribute__((nonnull, returns_nonnull)) StrategyCodeProg*
+StrategyCode_Replace(StrategyCode* sc, StrategyCode* src, bool resetScratch);
+
+/** @brief Release a BPF program. */
+__attribute__((nonnull)) void
+StrategyCodeProg_Free(StrategyCodeProg* prog);
+
 __attribute__((nonnull, returns_nonnull)) const struct ebpf_insn*
 StrategyCode_GetEmptyProgram_(uint32_t* nInsn);
 
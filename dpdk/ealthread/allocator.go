@@ -3,12 +3,23 @@ package ealthread
 import (
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"go.uber.org/zap"
 )
 
-var allocated [eal.MaxLCoreID]string
+var (
+	allocMutex sync.RWMutex
+	allocated  [eal.MaxLCoreID]string
+)
+
+// allocatedRole returns the role assigned to an lcore, or empty string if unallocated.
+func allocatedRole(lc eal.LCore) string {
+	allocMutex.RLock()
+	defer allocMutex.RUnlock()
+	return allocated[lc.ID()]
+}
 
 // AllocReq represents a request to the allocator.
 type AllocReq struct {
@@ -16,6 +27,8 @@ type AllocReq struct {
 	Socket eal.NumaSocket // preferred NUMA socket
 }
 
+// lcAllocatedTo returns a predicate that matches lcores assigned to role.
+// Caller must hold allocMutex while evaluating the predicate.
 func lcAllocatedTo(role string) eal.LCorePredicate {
 	return func(lc eal.LCore) bool {
 		return allocated[lc.ID()] == role
@@ -28,6 +41,9 @@ func lcUnallocated() eal.LCorePredicate {
 
 // AllocConfig allocates lcores according to configuration.
 func AllocConfig(c Config) (m map[string]eal.LCores, e error) {
+	allocMutex.Lock()
+	defer allocMutex.Unlock()
+
 	m, e = c.assignWorkers(lcUnallocated())
 	if e == nil {
 		for role, lcores := range m {
@@ -43,6 +59,9 @@ func AllocConfig(c Config) (m map[string]eal.LCores, e error) {
 // AllocRequest allocates lcores to requests.
 // Any request with Role=="" is skipped.
 func AllocRequest(requests ...AllocReq) (list []eal.LCore, e error) {
+	allocMutex.Lock()
+	defer allocMutex.Unlock()
+
 	reqBySocket, reqAny, nReq := map[eal.NumaSocket][]int{}, []int{}, 0
 	for i, req := range requests {
 		if req.Role == "" {
@@ -112,6 +131,9 @@ func AllocClear() {
 }
 
 func allocFree(lcores []eal.LCore, maybeFree bool) {
+	allocMutex.Lock()
+	defer allocMutex.Unlock()
+
 	var freed eal.LCores
 	for _, lc := range lcores {
 		role := allocated[lc.ID()]
@@ -59,7 +59,7 @@ func init() {
 				Type:        graphql.String,
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					lc := p.Source.(eal.LCore)
-					return gqlserver.Optional(allocated[lc.ID()]), nil
+					return gqlserver.Optional(allocatedRole(lc)), nil
 				},
 			},
 			"numaSocket": eal.GqlWithNumaSocket,
@@ -89,6 +89,8 @@ func init() {
 			if numaSocket, ok := p.Args["numaSocket"].(int); ok {
 				pred = append(pred, eal.LCoreOnNumaSocket(eal.NumaSocketFromID(numaSocket)))
 			}
+			allocMutex.RLock()
+			defer allocMutex.RUnlock()
 			return eal.Workers.Filter(pred...), nil
 		},
 	})
@@ -13,7 +13,7 @@ type LoadStat struct {
 
 	// ItemsPerPoll is average count of processed items per valid poll.
 	// This is only available from Sub() return value.
-	ItemsPerPoll float64 `json:"itemsPerPoll,omitempty" gqldesc:"Average count of processed items per valid poll."`
+	ItemsPerPoll float64 `json:"itemsPerPoll,omitempty" gqldesc:"Average count of processed items per valid poll." openmetrics:"-"`
 }
 
 // Sub computes the difference.
@@ -0,0 +1,28 @@
+package ealthread
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+)
+
+func init() {
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		for _, lc := range eal.Workers {
+			thObj, ok := activeThread.Load(lc)
+			if !ok {
+				continue
+			}
+			th, ok := thObj.(ThreadWithLoadStat)
+			if !ok {
+				continue
+			}
+			labels := openmetrics.Labels{
+				"lcore": strconv.Itoa(lc.ID()),
+				"role":  allocatedRole(lc),
+			}
+			s.AddStruct("ndndpdk_thread_", labels, th.ThreadLoadStat())
+		}
+	})
+}
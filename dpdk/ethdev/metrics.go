@@ -0,0 +1,43 @@
+package ethdev
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+)
+
+func init() {
+	type statField struct {
+		openmetrics.Family
+		get func(stats Stats) uint64
+	}
+	newStatField := func(name, help string, get func(stats Stats) uint64) statField {
+		return statField{
+			Family: openmetrics.Family{Name: "ndndpdk_ethdev_" + name, Help: help, Type: openmetrics.Counter},
+			get:    get,
+		}
+	}
+	fields := []statField{
+		newStatField("rx_packets", "RX successfully received packets.", func(stats Stats) uint64 { return stats.Ipackets }),
+		newStatField("rx_bytes", "RX successfully received bytes.", func(stats Stats) uint64 { return stats.Ibytes }),
+		newStatField("rx_missed", "RX packets dropped by hardware because there were no available buffers.", func(stats Stats) uint64 { return stats.Imissed }),
+		newStatField("rx_errors", "RX erroneous packets.", func(stats Stats) uint64 { return stats.Ierrors }),
+		newStatField("rx_nombuf", "RX mbuf allocation failures.", func(stats Stats) uint64 { return stats.Rx_nombuf }),
+		newStatField("tx_packets", "TX successfully transmitted packets.", func(stats Stats) uint64 { return stats.Opackets }),
+		newStatField("tx_bytes", "TX successfully transmitted bytes.", func(stats Stats) uint64 { return stats.Obytes }),
+		newStatField("tx_errors", "TX failed packets.", func(stats Stats) uint64 { return stats.Oerrors }),
+	}
+
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		for _, port := range List() {
+			labels := openmetrics.Labels{
+				"port": strconv.Itoa(port.ID()),
+				"name": port.Name(),
+			}
+			stats := port.Stats()
+			for _, field := range fields {
+				s.Add(field.Family, labels, field.get(stats))
+			}
+		}
+	})
+}
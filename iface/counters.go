@@ -23,11 +23,14 @@ type RxCounters struct {
 	RxDecodeErrs   uint64 `json:"rxDecodeErrs" gqldesc:"RX decode errors."`
 	RxReassPackets uint64 `json:"rxReassPackets" gqldesc:"RX packets that were reassembled."`
 	RxReassDrops   uint64 `json:"rxReassDrops" gqldesc:"RX frames that were dropped by reassembler."`
+	RxReassShared  uint64 `json:"rxReassShared" gqldesc:"RX fragments passed from non-zero RX threads to shared reassembler."`
+	RxRelDrops     uint64 `json:"rxRelDrops" gqldesc:"RX TxSequence/Ack fields that were dropped by link reliability."`
+	RxCongMarks    uint64 `json:"rxCongMarks" gqldesc:"RX packets carrying congestion mark."`
 }
 
 func (cnt RxCounters) String() string {
-	return fmt.Sprintf("%dfrm %db %dI %dD %dN %derr reass=(%dpkt %ddrop)",
-		cnt.RxFrames, cnt.RxOctets, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.RxDecodeErrs, cnt.RxReassPackets, cnt.RxReassDrops)
+	return fmt.Sprintf("%dfrm %db %dI %dD %dN %derr reass=(%dpkt %ddrop %dshared)",
+		cnt.RxFrames, cnt.RxOctets, cnt.RxInterests, cnt.RxData, cnt.RxNacks, cnt.RxDecodeErrs, cnt.RxReassPackets, cnt.RxReassDrops, cnt.RxReassShared)
 }
 
 // Since computes the difference between cnt and prev.
@@ -46,10 +49,12 @@ func (cnt *RxCounters) readFrom(c *C.RxProcThread) {
 	cnt.RxNacks = uint64(c.nFrames[ndni.PktNack])
 
 	cnt.RxDecodeErrs = uint64(c.nDecodeErr)
-	cnt.RxReassPackets = uint64(c.reass.nDeliverPackets)
-	cnt.RxReassDrops = uint64(c.reass.nDropFragments)
+	cnt.RxReassPackets = uint64(c.nReassembled)
+	cnt.RxReassShared = uint64(c.nFragShared)
+	cnt.RxRelDrops = uint64(c.nRelDrops)
+	cnt.RxCongMarks = uint64(c.nCongMarks)
 
-	cnt.RxFrames = cnt.RxInterests + cnt.RxData + cnt.RxNacks - cnt.RxReassPackets + uint64(c.reass.nDeliverFragments) + cnt.RxReassDrops
+	cnt.RxFrames = cnt.RxInterests + cnt.RxData + cnt.RxNacks - cnt.RxReassPackets + uint64(c.nFragments)
 }
 
 // RxCounters contains face/queue TX counters.
@@ -64,6 +69,15 @@ type TxCounters struct {
 	TxFragBad   uint64 `json:"txFragBad" gqldesc:"TX fragmentation failures."`
 	TxAllocErrs uint64 `json:"txAllocErrs" gqldesc:"TX allocation errors."`
 	TxDropped   uint64 `json:"txDropped" gqldesc:"TX dropped L2 frames due to full queue."`
+
+	TxCongMarks      uint64 `json:"txCongMarks" gqldesc:"TX packets carrying congestion mark."`
+	TxCongMarksAdded uint64 `json:"txCongMarksAdded" gqldesc:"TX congestion marks placed due to output queue congestion."`
+
+	TxRetxFrames  uint64 `json:"txRetxFrames" gqldesc:"TX frames retransmitted by link reliability."`
+	TxAckedFrames uint64 `json:"txAckedFrames" gqldesc:"TX frames acknowledged by peer."`
+	TxLostFrames  uint64 `json:"txLostFrames" gqldesc:"TX frames abandoned by link reliability."`
+	TxAckFields   uint64 `json:"txAckFields" gqldesc:"TX Ack fields."`
+	TxIdleFrames  uint64 `json:"txIdleFrames" gqldesc:"TX IDLE packets carrying Acks."`
 }
 
 func (cnt TxCounters) String() string {
@@ -91,6 +105,16 @@ func (cnt *TxCounters) readFrom(c *C.TxProc) {
 	cnt.TxFragBad = uint64(c.nL3OverLength + c.nAllocFails)
 	cnt.TxAllocErrs = uint64(c.nAllocFails)
 	cnt.TxDropped = uint64(c.nDroppedFrames)
+	cnt.TxCongMarks = uint64(c.nCongMarks)
+	cnt.TxCongMarksAdded = uint64(c.nCongMarksAdded)
+
+	if rel := c.rel; rel != nil {
+		cnt.TxRetxFrames = uint64(rel.nRetx)
+		cnt.TxAckedFrames = uint64(rel.nAcked)
+		cnt.TxLostFrames = uint64(rel.nLost)
+		cnt.TxAckFields = uint64(rel.nAckTx)
+		cnt.TxIdleFrames = uint64(rel.nIdleTx)
+	}
 }
 
 // Counters contains face counters.
@@ -147,6 +171,7 @@ func (f *face) Counters() (cnt Counters) {
 		cnt.RxThreads = append(cnt.RxThreads, rxCnt)
 	}
 	cnt.sumRx()
+	cnt.RxReassDrops = uint64(rxC.reass.nDropFragments) // shared reassembler is not attributed to threads
 
 	txC := &c.impl.tx
 	cnt.TxCounters.readFrom(txC)
@@ -16,6 +16,11 @@ func (loc *CLocator) ptr() *C.EthLocator {
 	return (*C.EthLocator)(unsafe.Pointer(loc))
 }
 
+// IsMulticast determines whether the remote address is an Ethernet multicast group.
+func (loc CLocator) IsMulticast() bool {
+	return bool(C.rte_is_multicast_ether_addr(&loc.ptr().remote))
+}
+
 // Locator is an Ethernet-based face locator.
 type Locator interface {
 	iface.Locator
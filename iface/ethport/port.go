@@ -9,6 +9,7 @@ import (
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
+	"github.com/usnistgov/ndn-dpdk/core/events"
 	"github.com/usnistgov/ndn-dpdk/core/logging"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ethdev"
 	"github.com/usnistgov/ndn-dpdk/dpdk/ethdev/ethnetif"
@@ -21,8 +22,18 @@ var logger = logging.New("ethport")
 var (
 	ports      = map[ethdev.EthDev]*Port{}
 	portsMutex sync.RWMutex
+	emitter    = events.NewEmitter()
 )
 
+const evtPortClosing = "PortClosing"
+
+// OnPortClosing registers a callback when a port is closing.
+// Callbacks should release resources that refer to the port, such as packet dump sources.
+// Return a function that cancels the callback registration.
+func OnPortClosing(cb func(port *Port)) (cancel func()) {
+	return emitter.On(evtPortClosing, cb)
+}
+
 // Limits and defaults.
 const (
 	DefaultRxQueueSize = 4096
@@ -116,6 +127,7 @@ func (port *Port) closeWithPortsMutex() error {
 	if nFaces := len(port.faces); nFaces > 0 {
 		return fmt.Errorf("cannot close Port with %d active faces", nFaces)
 	}
+	emitter.Emit(evtPortClosing, port)
 
 	errs := []error{}
 
@@ -193,7 +205,9 @@ func New(cfg Config) (port *Port, e error) {
 	}
 
 	cfg.applyDefaults()
-	if ndni.PacketMempool.Config().Dataroom < pktmbuf.DefaultHeadroom+cfg.MTU {
+	// linearized TX fragments are placed after LpHeaderHeadroom, which includes LpReliabilityHeadroom
+	// that is used as fragment payload when link reliability is disabled
+	if ndni.PacketMempool.Config().Dataroom < pktmbuf.DefaultHeadroom+ndni.LpReliabilityHeadroom+cfg.MTU {
 		return nil, errors.New("PacketMempool dataroom is too small for requested MTU")
 	}
 
@@ -5,10 +5,12 @@ package ethport
 */
 import "C"
 import (
+	"errors"
 	"fmt"
 	"unsafe"
 
 	"github.com/pkg/math"
+	"github.com/usnistgov/ndn-dpdk/core/urcu"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"go.uber.org/zap"
@@ -31,6 +33,7 @@ type rxFlow struct {
 	isolated        bool
 	availQueues     []uint16
 	hasDestroyError bool
+	pdump           *C.PdumpSourceRef
 }
 
 func (rxFlow) String() string {
@@ -71,6 +74,8 @@ func (impl *rxFlow) Init(port *Port) error {
 	for _, q := range port.dev.RxQueues() {
 		impl.availQueues = append(impl.availQueues, q.Queue)
 	}
+
+	impl.pdump = (*C.PdumpSourceRef)(eal.Zmalloc("PdumpSourceRef", C.sizeof_PdumpSourceRef, port.dev.NumaSocket()))
 	return nil
 }
 
@@ -125,6 +130,7 @@ func (impl *rxFlow) startFlow(face *Face, queues []uint16) {
 			index: i,
 			queue: q,
 		}
+		face.priv.rxf[i].pdump = impl.pdump
 		face.rxf[i] = rxf
 		iface.ActivateRxGroup(rxf)
 	}
@@ -171,6 +177,20 @@ func (impl *rxFlow) destroyFlow(face *Face) error {
 }
 
 func (impl *rxFlow) Close(port *Port) error {
+	if impl.pdump == nil {
+		return nil
+	}
+
+	// dump source should have been detached in OnPortClosing callback; otherwise, PdumpSourceRef
+	// is leaked because the source owner would write to it when detaching
+	if impl.pdump.s != nil {
+		impl.pdump = nil
+		return errors.New("cannot free PdumpSourceRef with attached source")
+	}
+
+	urcu.Synchronize()
+	eal.Free(impl.pdump)
+	impl.pdump = nil
 	return nil
 }
 
@@ -189,3 +209,12 @@ func (rxf *rxgFlow) NumaSocket() eal.NumaSocket {
 func (rxf *rxgFlow) Ptr() unsafe.Pointer {
 	return unsafe.Pointer(&rxf.face.priv.rxf[rxf.index].base)
 }
+
+// RxFlowPdumpPtrFromPort extracts *C.PdumpSourceRef pointer shared by RxFlow queues of Port.
+func RxFlowPdumpPtrFromPort(port *Port) unsafe.Pointer {
+	impl, ok := port.rxImpl.(*rxFlow)
+	if !ok {
+		return nil
+	}
+	return unsafe.Pointer(impl.pdump)
+}
@@ -64,6 +64,14 @@ type Config struct {
 	// Otherwise, it is adjusted up to the next power of 2.
 	OutputQueueSize int `json:"outputQueueSize,omitempty"`
 
+	// CongMarkThreshold is the output queue occupancy that triggers congestion marking.
+	// When the output queue still contains at least this many packets after the output thread
+	// dequeues a burst, the first packet in the burst receives a congestion mark.
+	//
+	// Default is zero, which disables congestion marking on the output queue.
+	// A suggested value is half of OutputQueueSize.
+	CongMarkThreshold int `json:"congMarkThreshold,omitempty"`
+
 	// MTU is the maximum size of outgoing NDNLP packets.
 	// This excludes lower layer headers, such as Ethernet/VXLAN headers.
 	//
@@ -71,6 +79,10 @@ type Config struct {
 	// If this is less than MinMTU or greater than the maximum, the face will fail to initialize.
 	MTU int `json:"mtu,omitempty"`
 
+	// Reliability enables NDNLPv2 link reliability.
+	// Default is disabled.
+	Reliability *ReliabilityConfig `json:"reliability,omitempty"`
+
 	maxMTU int
 }
 
@@ -82,6 +94,7 @@ func (c *Config) ApplyDefaults() {
 	c.ReassemblerCapacity = math.MinInt(math.MaxInt(MinReassemblerCapacity, c.ReassemblerCapacity), MaxReassemblerCapacity)
 
 	c.OutputQueueSize = ringbuffer.AlignCapacity(c.OutputQueueSize, MinOutputQueueSize, DefaultOutputQueueSize)
+	c.CongMarkThreshold = math.MaxInt(0, c.CongMarkThreshold)
 }
 
 // WithMaxMTU returns a copy of Config with consideration of device MTU.
@@ -201,9 +214,13 @@ func newFace(p NewParams) (Face, error) {
 	}
 	logEntry = logEntry.With(LocatorZapField("locator", f.Locator()))
 
+	fragmentPayloadSize := p.MTU - ndni.LpHeaderHeadroom
+	if p.Reliability == nil {
+		fragmentPayloadSize += ndni.LpReliabilityHeadroom
+	}
 	c.txAlign = C.PacketTxAlign{
 		linearize:           C.bool(initResult.TxLinearize),
-		fragmentPayloadSize: C.uint16_t(p.MTU - ndni.LpHeaderHeadroom),
+		fragmentPayloadSize: C.uint16_t(fragmentPayloadSize),
 	}
 	c.impl.tx.l2Burst = (C.Face_L2TxBurst)(initResult.L2TxBurst)
 	(*ndni.Mempools)(unsafe.Pointer(&c.impl.tx.mp)).Assign(p.Socket)
@@ -215,21 +232,30 @@ func newFace(p NewParams) (Face, error) {
 	}
 	c.outputQueue = (*C.struct_rte_ring)(outputQueue.Ptr())
 
-	for i := 0; i < MaxRxProcThreads; i++ {
-		ok := func() bool {
-			reassID := C.CString(eal.AllocObjectID("iface.Reassembler"))
-			defer C.free(unsafe.Pointer(reassID))
-			return bool(C.Reassembler_Init(&c.impl.rx.threads[i].reass, reassID,
-				C.uint32_t(p.ReassemblerCapacity), C.unsigned(p.Socket.ID())))
-		}()
-		if !ok {
-			e := eal.GetErrno()
-			logEntry.Warn("Reassembler_Init error", zap.Int("rx-thread", i), zap.Error(e))
-			return f.clear(), e
-		}
+	ok := func() bool {
+		reassID := C.CString(eal.AllocObjectID("iface.Reassembler"))
+		defer C.free(unsafe.Pointer(reassID))
+		return bool(C.Reassembler_Init(&c.impl.rx.reass, reassID,
+			C.uint32_t(p.ReassemblerCapacity), C.unsigned(p.Socket.ID())))
+	}()
+	if !ok {
+		e := eal.GetErrno()
+		logEntry.Warn("Reassembler_Init error", zap.Error(e))
+		return f.clear(), e
 	}
+	c.impl.rx.reass.pdumpDrop = &c.impl.rx.pdumpDrop
 
 	C.TxProc_Init(&c.impl.tx, c.txAlign)
+	c.impl.tx.congMarkThreshold = C.uint32_t(p.CongMarkThreshold)
+
+	if p.Reliability != nil {
+		rel, e := newReliability(*p.Reliability, p.Socket)
+		if e != nil {
+			logEntry.Warn("newReliability error", zap.Error(e))
+			return f.clear(), e
+		}
+		c.impl.rx.rel, c.impl.tx.rel = rel, rel
+	}
 
 	if e := p.Start(); e != nil {
 		logEntry.Warn("start error", zap.Error(e))
@@ -297,8 +323,11 @@ func (f *face) clear() Face {
 	id, c := f.id, f.ptr()
 	c.state = StateRemoved
 	if c.impl != nil {
-		for i := 0; i < MaxRxProcThreads; i++ {
-			C.Reassembler_Close(&c.impl.rx.threads[i].reass)
+		C.Reassembler_Close(&c.impl.rx.reass)
+		if c.impl.tx.rel != nil {
+			if e := closeReliability(c.impl.tx.rel); e != nil {
+				logger.Warn("closeReliability error", id.ZapField("id"), zap.Error(e))
+			}
 		}
 		eal.Free(c.impl)
 	}
@@ -6,6 +6,10 @@ import (
 
 	"github.com/usnistgov/ndn-dpdk/iface"
 	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/sockettransport"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
 	"github.com/usnistgov/ndn-dpdk/ndni"
 	"github.com/usnistgov/ndn-dpdk/ndni/ndnitestenv"
 	"go4.org/must"
@@ -94,3 +98,61 @@ func TestEvents(t *testing.T) {
 	}
 	assert.True(iface.IsDown(id1))
 }
+
+func TestTxCongMark(t *testing.T) {
+	assert, require := makeAR(t)
+
+	makeFace := func(congMarkThreshold int) (face iface.Face, peer sockettransport.Transport) {
+		trFace, trPeer, e := sockettransport.Pipe(sockettransport.Config{})
+		require.NoError(e)
+		var cfg socketface.Config
+		cfg.OutputQueueSize = 256
+		cfg.CongMarkThreshold = congMarkThreshold
+		face, e = socketface.Wrap(trFace, cfg)
+		require.NoError(e)
+		return face, trPeer
+	}
+
+	// fill the output queue while TxLoop is not serving the face, then count marks on the wire
+	const nPackets = 200
+	run := func(face iface.Face, peer sockettransport.Transport) (nCongMarks int) {
+		iface.DeactivateTxFace(face)
+		for i := 0; i < nPackets; i++ {
+			iface.TxBurst(face.ID(), []*ndni.Packet{ndnitestenv.MakeInterest("/I")})
+		}
+		iface.ActivateTxFace(face)
+
+		timeout := time.After(time.Second)
+		for i := 0; i < nPackets; i++ {
+			select {
+			case wire := <-peer.Rx():
+				var pkt ndn.Packet
+				if assert.NoError(tlv.Decode(wire, &pkt)) && pkt.Lp.CongMark != 0 {
+					nCongMarks++
+				}
+			case <-timeout:
+				assert.Fail("timeout", "received %d of %d packets", i, nPackets)
+				return
+			}
+		}
+		return
+	}
+
+	faceA, peerA := makeFace(iface.MaxBurstSize)
+	defer faceA.Close()
+	nCongMarksA := run(faceA, peerA)
+	cntA := faceA.Counters()
+	assert.EqualValues(nPackets, cntA.TxInterests)
+	assert.Greater(cntA.TxCongMarksAdded, uint64(0))
+	assert.Less(cntA.TxCongMarksAdded, uint64(nPackets/iface.MaxBurstSize+1))
+	assert.EqualValues(cntA.TxCongMarksAdded, cntA.TxCongMarks)
+	assert.EqualValues(cntA.TxCongMarksAdded, nCongMarksA)
+
+	faceB, peerB := makeFace(0)
+	defer faceB.Close()
+	nCongMarksB := run(faceB, peerB)
+	cntB := faceB.Counters()
+	assert.EqualValues(nPackets, cntB.TxInterests)
+	assert.Zero(cntB.TxCongMarksAdded)
+	assert.Zero(nCongMarksB)
+}
@@ -1,12 +1,17 @@
 package iface
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"strconv"
+	"sync"
+	"time"
 
+	"github.com/functionalfoundry/graphqlws"
 	"github.com/graphql-go/graphql"
 	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver/gqlsub"
 	"github.com/usnistgov/ndn-dpdk/core/jsonhelper"
 	"github.com/usnistgov/ndn-dpdk/core/nnduration"
 	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
@@ -125,6 +130,88 @@ func init() {
 		},
 	})
 
+	gqlserver.AddSubscription(&graphql.Field{
+		Name:        "faceCounters",
+		Description: "Obtain face counters, as differences since the previous update.",
+		Args: graphql.FieldConfigArgument{
+			"id": &graphql.ArgumentConfig{
+				Description: "Face ID.",
+				Type:        gqlserver.NonNullID,
+			},
+			"interval": &graphql.ArgumentConfig{
+				Description: "Interval between updates.",
+				Type:        nnduration.GqlNanoseconds,
+			},
+		},
+		Type: GqlCountersType,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Info.RootValue.(Counters), nil
+		},
+	}, func(ctx context.Context, sub *graphqlws.Subscription, updates chan<- interface{}) {
+		defer close(updates)
+
+		id, ok := gqlsub.GetArg(sub, "id", graphql.ID).(string)
+		if !ok {
+			return
+		}
+		var face Face
+		if e := gqlserver.RetrieveNodeOfType(GqlFaceNodeType, id, &face); e != nil {
+			return
+		}
+
+		interval, ok := gqlsub.GetArg(sub, "interval", nnduration.GqlNanoseconds).(nnduration.Nanoseconds)
+		if !ok {
+			return
+		}
+
+		// FaceClosing callback blocks until an ongoing counters read completes, and then prevents
+		// further reads, so that counters are never read from a freed face
+		var closingLock sync.Mutex
+		closing := make(chan struct{})
+		faceID := face.ID()
+		cancelOnClosing := OnFaceClosing(func(id ID) {
+			if id == faceID {
+				closingLock.Lock()
+				defer closingLock.Unlock()
+				close(closing)
+			}
+		})
+		defer cancelOnClosing()
+
+		readCounters := func() (cnt Counters, ok bool) {
+			closingLock.Lock()
+			defer closingLock.Unlock()
+			select {
+			case <-closing:
+				return cnt, false
+			default:
+				return face.Counters(), true
+			}
+		}
+
+		prev, ok := readCounters()
+		if !ok {
+			return
+		}
+		ticker := time.NewTicker(interval.Duration())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-closing:
+				return
+			case <-ticker.C:
+				cnt, ok := readCounters()
+				if !ok {
+					return
+				}
+				updates <- cnt.Since(prev)
+				prev = cnt
+			}
+		}
+	})
+
 	gqlserver.AddMutation(&graphql.Field{
 		Name:        "createFace",
 		Description: "Create a face.",
@@ -0,0 +1,87 @@
+package iface_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/gqlclient"
+	"github.com/usnistgov/ndn-dpdk/core/gqlserver"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"github.com/usnistgov/ndn-dpdk/ndni/ndnitestenv"
+	"go4.org/must"
+)
+
+func TestGqlFaceCounters(t *testing.T) {
+	assert, require := makeAR(t)
+
+	gqlserver.Prepare()
+	server := httptest.NewServer(http.DefaultServeMux)
+	defer server.Close()
+	c, e := gqlclient.New(gqlclient.Config{HTTPUri: server.URL})
+	require.NoError(e)
+	defer c.Close()
+
+	face := intface.MustNew()
+	intface.Collect(face)
+
+	var faces []struct {
+		ID  string `json:"id"`
+		Nid int    `json:"nid"`
+	}
+	require.NoError(c.Do(context.Background(), `
+		query {
+			faces { id nid }
+		}
+	`, nil, "faces", &faces))
+	faceGqlID := ""
+	for _, f := range faces {
+		if f.Nid == int(face.ID) {
+			faceGqlID = f.ID
+		}
+	}
+	require.NotEmpty(faceGqlID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	updates := make(chan iface.Counters, 100)
+	go c.Subscribe(ctx, `
+		subscription faceCounters($id: ID!, $interval: NNNanoseconds!) {
+			faceCounters(id: $id, interval: $interval) { txInterests }
+		}
+	`, map[string]interface{}{
+		"id":       faceGqlID,
+		"interval": "100ms",
+	}, "faceCounters", updates)
+	time.Sleep(200 * time.Millisecond)
+
+	const nInterests = 20
+	for i := 0; i < nInterests; i++ {
+		iface.TxBurst(face.ID, []*ndni.Packet{ndnitestenv.MakeInterest("/A")})
+		time.Sleep(20 * time.Millisecond)
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	// each update contains the difference since the previous update
+	nUpdates, sumTxInterests := 0, uint64(0)
+	for len(updates) > 0 {
+		cnt := <-updates
+		nUpdates++
+		sumTxInterests += cnt.TxInterests
+	}
+	assert.Greater(nUpdates, 3)
+	assert.EqualValues(nInterests, sumTxInterests)
+
+	// subscription ends when the face is closed
+	must.Close(face.D)
+	time.Sleep(200 * time.Millisecond)
+	for len(updates) > 0 {
+		<-updates
+	}
+	time.Sleep(300 * time.Millisecond)
+	assert.Zero(len(updates))
+}
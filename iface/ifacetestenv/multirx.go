@@ -0,0 +1,133 @@
+package ifacetestenv
+
+/*
+#include "../../csrc/iface/rxloop.h"
+
+typedef struct RingRxGroup
+{
+	RxGroup base;
+	struct rte_ring* ring;
+} RingRxGroup;
+
+static uint16_t RingRxGroup_RxBurst(RxGroup* rxg, struct rte_mbuf** pkts, uint16_t nPkts)
+{
+	RingRxGroup* rrxg = container_of(rxg, RingRxGroup, base);
+	return rte_ring_dequeue_burst(rrxg->ring, (void**)pkts, nPkts, NULL);
+}
+*/
+import "C"
+import (
+	"math/rand"
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ealthread"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf"
+	"github.com/usnistgov/ndn-dpdk/dpdk/pktmbuf/mbuftestenv"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"go4.org/must"
+)
+
+// ringRxGroup is an RxGroup that receives frames enqueued into a ring, on a specific RX thread.
+type ringRxGroup struct {
+	ring *ringbuffer.Ring
+	c    *C.RingRxGroup
+}
+
+func (*ringRxGroup) IsRxGroup() {}
+
+func (rxg *ringRxGroup) NumaSocket() eal.NumaSocket {
+	return eal.NumaSocket{}
+}
+
+func (rxg *ringRxGroup) Ptr() unsafe.Pointer {
+	return unsafe.Pointer(rxg.c)
+}
+
+func newRingRxGroup(rxThread int) (rxg *ringRxGroup, e error) {
+	rxg = &ringRxGroup{}
+	if rxg.ring, e = ringbuffer.New(4096, eal.NumaSocket{}, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle); e != nil {
+		return nil, e
+	}
+	rxg.c = (*C.RingRxGroup)(eal.Zmalloc("RingRxGroup", C.sizeof_RingRxGroup, eal.NumaSocket{}))
+	rxg.c.base.rxBurstOp = C.RxGroup_RxBurst(C.RingRxGroup_RxBurst)
+	rxg.c.base.rxThread = C.int(rxThread)
+	rxg.c.ring = (*C.struct_rte_ring)(rxg.ring.Ptr())
+	return rxg, nil
+}
+
+func (rxg *ringRxGroup) Close() error {
+	eal.Free(rxg.c)
+	return rxg.ring.Close()
+}
+
+// RunMultiRxTest sends fragmented Data packets into rxFace, spreading fragments of each packet
+// over nThreads RX threads, and expects them to be reassembled.
+// This simulates a face whose frames are received on multiple hardware queues.
+func (fixture *Fixture) RunMultiRxTest(rxFace iface.Face, nThreads int) {
+	_, require := makeAR(fixture.t)
+	fixture.rxFace = rxFace
+
+	rxgs := make([]*ringRxGroup, nThreads)
+	for i := range rxgs {
+		rxg, e := newRingRxGroup(i)
+		require.NoError(e)
+		fixture.rxl.Add(rxg)
+		rxgs[i] = rxg
+	}
+	defer func() {
+		for _, rxg := range rxgs {
+			fixture.rxl.Remove(rxg)
+			must.Close(rxg)
+		}
+	}()
+
+	content := make([]byte, fixture.PayloadLen)
+	rand.Read(content)
+	fragmenter := ndn.NewLpFragmenter(fixture.PayloadLen/fixture.DataFrames + ndni.LpHeaderHeadroom)
+
+	recvStop := ealthread.NewStopChan()
+	go fixture.recvProc(recvStop)
+
+	for i := 0; i < fixture.TxIterations; i++ {
+		data := ndn.MakeData("/A", content)
+		frags, e := fragmenter.Fragment(data.ToPacket())
+		require.NoError(e)
+		require.Len(frags, fixture.DataFrames)
+
+		for j, frag := range frags {
+			wire, e := tlv.EncodeFrom(frag)
+			require.NoError(e)
+			m := mbuftestenv.MakePacket(wire)
+			m.SetPort(uint16(rxFace.ID()))
+			m.SetTimestamp(eal.TscNow())
+			rxg := rxgs[(i+j)%nThreads]
+			if rxg.ring.Enqueue(pktmbuf.Vector{m}) != 1 {
+				must.Close(m)
+			}
+		}
+		time.Sleep(100 * time.Microsecond)
+	}
+
+	time.Sleep(800 * time.Millisecond)
+	recvStop.RequestStop()
+	time.Sleep(100 * time.Millisecond)
+}
+
+// CheckMultiRxCounters checks the counters after RunMultiRxTest.
+func (fixture *Fixture) CheckMultiRxCounters() {
+	assert, _ := makeAR(fixture.t)
+
+	rxCnt := fixture.rxFace.Counters()
+	assert.EqualValues(fixture.NRxData, rxCnt.RxData)
+	assert.EqualValues(fixture.NRxData, rxCnt.RxReassPackets)
+	assert.InEpsilon(uint64(fixture.DataFrames)*rxCnt.RxData, rxCnt.RxFrames, 0.01)
+	assert.Greater(rxCnt.RxReassShared, uint64(0))
+	assert.Zero(rxCnt.RxReassDrops)
+	assert.InEpsilon(fixture.TxIterations, fixture.NRxData, fixture.RxLossTolerance)
+}
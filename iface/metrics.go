@@ -0,0 +1,21 @@
+package iface
+
+import (
+	"strconv"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+)
+
+func init() {
+	openmetrics.AddCollector(func(s *openmetrics.Set) {
+		for _, face := range List() {
+			labels := openmetrics.Labels{
+				"face":   strconv.Itoa(int(face.ID())),
+				"scheme": face.Locator().Scheme(),
+			}
+			cnt := face.Counters()
+			s.AddStruct("ndndpdk_face_", labels, cnt.RxCounters)
+			s.AddStruct("ndndpdk_face_", labels, cnt.TxCounters)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package iface_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/openmetrics"
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/intface"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"github.com/usnistgov/ndn-dpdk/ndni/ndnitestenv"
+	"go4.org/must"
+)
+
+func TestMetrics(t *testing.T) {
+	assert, require := makeAR(t)
+
+	face := intface.MustNew()
+	defer must.Close(face.D)
+	intface.Collect(face)
+
+	const nInterests = 20
+	for i := 0; i < nInterests; i++ {
+		iface.TxBurst(face.ID, []*ndni.Packet{ndnitestenv.MakeInterest(fmt.Sprintf("/A/%d", i))})
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	server := httptest.NewServer(openmetrics.Handler())
+	defer server.Close()
+	res, e := http.Get(server.URL)
+	require.NoError(e)
+	defer res.Body.Close()
+	body, e := io.ReadAll(res.Body)
+	require.NoError(e)
+
+	labels := fmt.Sprintf(`{face="%d",scheme="%s"}`, face.ID, face.D.Locator().Scheme())
+	assert.Contains(string(body), "# TYPE ndndpdk_face_tx_interests counter\n")
+	assert.Contains(string(body), fmt.Sprintf("\nndndpdk_face_tx_interests_total%s %d\n", labels, nInterests))
+	assert.Contains(string(body), fmt.Sprintf("\nndndpdk_face_rx_frames_total%s 0\n", labels))
+}
@@ -130,6 +130,12 @@ type PktQueueCounters struct {
 	NDrops uint64 `json:"nDrops"`
 }
 
+// Since computes the difference between cnt and prev.
+func (cnt PktQueueCounters) Since(prev PktQueueCounters) (diff PktQueueCounters) {
+	diff.NDrops = cnt.NDrops - prev.NDrops
+	return diff
+}
+
 // Counters reads counters.
 func (q *PktQueue) Counters() (cnt PktQueueCounters) {
 	cnt.NDrops = uint64(q.nDrops)
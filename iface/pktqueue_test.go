@@ -175,3 +175,12 @@ func TestPktQueueCoDel(t *testing.T) {
 	assert.Equal(nEnq, nDeq)
 	assert.Equal(nDrop, 0)
 }
+
+func TestPktQueueCountersSince(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	prev := iface.PktQueueCounters{NDrops: 7}
+	cnt := iface.PktQueueCounters{NDrops: 12}
+	assert.Equal(iface.PktQueueCounters{NDrops: 5}, cnt.Since(prev))
+	assert.Equal(iface.PktQueueCounters{}, cnt.Since(cnt))
+}
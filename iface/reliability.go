@@ -0,0 +1,106 @@
+package iface
+
+/*
+#include "../csrc/iface/reliability.h"
+*/
+import "C"
+import (
+	"math/rand"
+	"time"
+	"unsafe"
+
+	"github.com/usnistgov/ndn-dpdk/core/nnduration"
+	"github.com/usnistgov/ndn-dpdk/dpdk/eal"
+	"github.com/usnistgov/ndn-dpdk/dpdk/ringbuffer"
+	"go.uber.org/multierr"
+)
+
+// Limits and defaults of link reliability.
+const (
+	MinReliabilityWindow     = 64
+	DefaultReliabilityWindow = 1024
+	MaxReliabilityWindow     = 65536
+
+	DefaultReliabilityRetxTimeout = 200 * time.Millisecond
+	DefaultReliabilityMaxRetx     = 3
+	MaxReliabilityMaxRetx         = 255
+)
+
+// ReliabilityConfig contains NDNLPv2 link reliability configuration.
+//
+// When enabled, each outgoing frame carries a TxSequence field, and is retained until the peer
+// acknowledges it.
+// Acknowledgements of incoming frames are piggybacked on outgoing frames, or sent in IDLE packets
+// when there is no outgoing traffic.
+// Both ends of the link should enable this feature; otherwise, every frame is retransmitted up to
+// MaxRetx times.
+type ReliabilityConfig struct {
+	// RetxTimeout is the duration before an unacknowledged frame is retransmitted.
+	// Default is DefaultReliabilityRetxTimeout.
+	RetxTimeout nnduration.Milliseconds `json:"retxTimeout,omitempty"`
+
+	// MaxRetx is the maximum number of retransmissions per frame.
+	// Default is DefaultReliabilityMaxRetx.
+	// Maximum is MaxReliabilityMaxRetx.
+	MaxRetx int `json:"maxRetx,omitempty"`
+
+	// Window is the maximum number of unacknowledged frames.
+	// When this limit is exceeded, the oldest unacknowledged frame is abandoned.
+	//
+	// The minimum is MinReliabilityWindow.
+	// If this value is less than the minimum, it defaults to DefaultReliabilityWindow.
+	// Otherwise, it is adjusted up to the next power of 2, and clamped to MaxReliabilityWindow.
+	Window int `json:"window,omitempty"`
+}
+
+func (cfg *ReliabilityConfig) applyDefaults() {
+	if cfg.RetxTimeout <= 0 {
+		cfg.RetxTimeout = nnduration.Milliseconds(DefaultReliabilityRetxTimeout / time.Millisecond)
+	}
+	if cfg.MaxRetx <= 0 {
+		cfg.MaxRetx = DefaultReliabilityMaxRetx
+	} else if cfg.MaxRetx > MaxReliabilityMaxRetx {
+		cfg.MaxRetx = MaxReliabilityMaxRetx
+	}
+	cfg.Window = ringbuffer.AlignCapacity(cfg.Window, MinReliabilityWindow, DefaultReliabilityWindow, MaxReliabilityWindow)
+}
+
+func newReliability(cfg ReliabilityConfig, socket eal.NumaSocket) (rel *C.LpReliability, e error) {
+	cfg.applyDefaults()
+	rel = (*C.LpReliability)(eal.ZmallocAligned("LpReliability",
+		C.sizeof_LpReliability+C.sizeof_LpRelEntry*uintptr(cfg.Window), 1, socket))
+
+	ackQ, e := ringbuffer.New(cfg.Window, socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
+	if e != nil {
+		closeReliability(rel)
+		return nil, e
+	}
+	rel.ackQ = (*C.struct_rte_ring)(ackQ.Ptr())
+
+	ackedQ, e := ringbuffer.New(cfg.Window, socket, ringbuffer.ProducerMulti, ringbuffer.ConsumerSingle)
+	if e != nil {
+		closeReliability(rel)
+		return nil, e
+	}
+	rel.ackedQ = (*C.struct_rte_ring)(ackedQ.Ptr())
+
+	rel.retxTimeout = C.TscDuration(eal.ToTscDuration(cfg.RetxTimeout.Duration()))
+	rel.maxRetx = C.uint8_t(cfg.MaxRetx)
+	rel.windowMask = C.uint32_t(cfg.Window - 1)
+	rel.nextTxSeq = C.uint64_t(rand.Uint64())
+	rel.oldestTxSeq = rel.nextTxSeq
+	return rel, nil
+}
+
+func closeReliability(rel *C.LpReliability) error {
+	C.LpReliability_Close(rel)
+	var errs []error
+	if rel.ackQ != nil {
+		errs = append(errs, ringbuffer.FromPtr(unsafe.Pointer(rel.ackQ)).Close())
+	}
+	if rel.ackedQ != nil {
+		errs = append(errs, ringbuffer.FromPtr(unsafe.Pointer(rel.ackedQ)).Close())
+	}
+	eal.Free(rel)
+	return multierr.Combine(errs...)
+}
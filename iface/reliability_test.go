@@ -0,0 +1,160 @@
+package iface_test
+
+import (
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/iface"
+	"github.com/usnistgov/ndn-dpdk/iface/socketface"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/sockettransport"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+	"github.com/usnistgov/ndn-dpdk/ndni"
+	"github.com/usnistgov/ndn-dpdk/ndni/ndnitestenv"
+)
+
+// lossyLink relays frames between two socket faces, dropping some of them.
+type lossyLink struct {
+	t        *testing.T
+	lossPct  int32 // atomic
+	nTxSeqs  int32 // atomic
+	nAcks    int32 // atomic
+	nPayload int32 // atomic
+}
+
+// relay forwards frames from src to dst, checking NDNLPv2 header field order.
+func (link *lossyLink) relay(src, dst sockettransport.Transport) {
+	defer close(dst.Tx())
+	for wire := range src.Rx() {
+		link.check(wire)
+		if rand.Int31n(100) < atomic.LoadInt32(&link.lossPct) {
+			continue
+		}
+		dst.Tx() <- wire
+	}
+}
+
+func (link *lossyLink) check(wire []byte) {
+	assert, _ := makeAR(link.t)
+
+	var pkt ndn.Packet
+	assert.NoError(tlv.Decode(wire, &pkt))
+
+	d := tlv.DecodingBuffer(wire)
+	outer, e := d.Element()
+	if !assert.NoError(e) || !assert.EqualValues(an.TtLpPacket, outer.Type) {
+		return
+	}
+
+	var lastType uint32
+	hasPayload := false
+	for _, de := range tlv.DecodingBuffer(outer.Value).Elements() {
+		assert.False(hasPayload, "LpPayload must be the last field")
+		switch de.Type {
+		case an.TtLpPayload:
+			hasPayload = true
+			atomic.AddInt32(&link.nPayload, 1)
+			continue
+		case an.TtLpTxSequence:
+			atomic.AddInt32(&link.nTxSeqs, 1)
+		case an.TtLpAck:
+			atomic.AddInt32(&link.nAcks, 1)
+		}
+		assert.GreaterOrEqual(de.Type, lastType, "header fields must be in ascending TLV-TYPE order")
+		lastType = de.Type
+	}
+}
+
+func TestReliability(t *testing.T) {
+	assert, require := makeAR(t)
+	link := &lossyLink{t: t}
+
+	makeFace := func(window int) (face iface.Face, peer sockettransport.Transport) {
+		trFace, trPeer, e := sockettransport.Pipe(sockettransport.Config{})
+		require.NoError(e)
+		var cfg socketface.Config
+		cfg.MTU = 1200
+		cfg.Reliability = &iface.ReliabilityConfig{
+			RetxTimeout: 50,
+			MaxRetx:     2,
+			Window:      window,
+		}
+		face, e = socketface.Wrap(trFace, cfg)
+		require.NoError(e)
+		return face, trPeer
+	}
+	faceA, peerA := makeFace(1024)
+	faceB, peerB := makeFace(64)
+	defer faceB.Close()
+	defer faceA.Close()
+	go link.relay(peerA, peerB)
+	go link.relay(peerB, peerA)
+
+	const nInterests, nData, nNacks = 300, 100, 100
+	payload := make([]byte, 1500)
+	rand.Read(payload)
+	send := func(nInterests, nData, nNacks int) {
+		for i := 0; i < nInterests; i++ {
+			iface.TxBurst(faceA.ID(), []*ndni.Packet{
+				ndnitestenv.MakeInterest("/I", ndnitestenv.SetPitToken([]byte{0xA0, 0xA1, 0xA2, 0xA3})),
+			})
+			time.Sleep(time.Millisecond)
+		}
+		for i := 0; i < nData; i++ {
+			iface.TxBurst(faceA.ID(), []*ndni.Packet{
+				ndnitestenv.MakeData("/D", payload, ndnitestenv.SetPitToken([]byte{0xD0, 0xD1})),
+			})
+			time.Sleep(time.Millisecond)
+		}
+		for i := 0; i < nNacks; i++ {
+			iface.TxBurst(faceA.ID(), []*ndni.Packet{
+				ndnitestenv.MakeNack(ndn.MakeInterest("/N"), an.NackCongestion, ndnitestenv.SetPitToken([]byte{0xE0})),
+			})
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// lossy link: frames are retransmitted, and most are eventually acknowledged
+	atomic.StoreInt32(&link.lossPct, 10)
+	send(nInterests, nData, nNacks)
+	time.Sleep(800 * time.Millisecond)
+
+	cntA, cntB := faceA.Counters(), faceB.Counters()
+	assert.EqualValues(nData, cntA.TxFragGood)
+	nFrames := nInterests + 2*nData + nNacks
+	assert.Greater(cntA.TxRetxFrames, uint64(0))
+	assert.EqualValues(nFrames, cntA.TxAckedFrames+cntA.TxLostFrames)
+	assert.Greater(cntA.TxAckedFrames, uint64(nFrames*9/10))
+	assert.Zero(cntA.TxAckFields)
+	assert.Zero(cntA.TxIdleFrames)
+	assert.Greater(cntB.TxAckFields, uint64(0))
+	assert.Greater(cntB.TxIdleFrames, uint64(0))
+	assert.Zero(cntB.TxRetxFrames)
+	// a frame is received more than once if its Ack is lost
+	assert.GreaterOrEqual(cntB.RxInterests, uint64(nInterests*95/100))
+	assert.GreaterOrEqual(cntB.RxData, uint64(nData*95/100))
+	assert.GreaterOrEqual(cntB.RxNacks, uint64(nNacks*95/100))
+	assert.Zero(cntB.RxRelDrops)
+	assert.Zero(cntB.RxDecodeErrs)
+
+	// peer does not transmit Acks: its ackQ overflows, and frames are abandoned after MaxRetx
+	atomic.StoreInt32(&link.lossPct, 0)
+	iface.DeactivateTxFace(faceB)
+	send(nInterests, 0, 0)
+	time.Sleep(500 * time.Millisecond)
+	iface.ActivateTxFace(faceB)
+	time.Sleep(200 * time.Millisecond)
+
+	cntA2, cntB2 := faceA.Counters(), faceB.Counters()
+	assert.Greater(cntB2.RxRelDrops, uint64(0))
+	assert.Greater(cntA2.TxRetxFrames, cntA.TxRetxFrames)
+	assert.Greater(cntA2.TxLostFrames, cntA.TxLostFrames)
+	assert.EqualValues(nFrames+nInterests, cntA2.TxAckedFrames+cntA2.TxLostFrames)
+
+	assert.Greater(atomic.LoadInt32(&link.nTxSeqs), int32(nFrames))
+	assert.Greater(atomic.LoadInt32(&link.nAcks), int32(0))
+	assert.Equal(atomic.LoadInt32(&link.nTxSeqs), atomic.LoadInt32(&link.nPayload))
+}
@@ -58,6 +58,28 @@ func TestUDP(t *testing.T) {
 	fixture.CheckCounters()
 }
 
+func TestMultiRxReassembly(t *testing.T) {
+	_, require := makeAR(t)
+	fixture := ifacetestenv.NewFixture(t)
+	fixture.PayloadLen = 6000
+	fixture.DataFrames = 3
+	fixture.TxIterations = 1000
+
+	portA, portB := 0, 0
+	for portA == portB {
+		portA, _ = freeport.UDP()
+		portB, _ = freeport.UDP()
+	}
+	loc := mustParseLocator(`{ "scheme": "udp", "local": "127.0.0.1:` + strconv.Itoa(portA) +
+		`", "remote": "127.0.0.1:` + strconv.Itoa(portB) + `" }`)
+	face, e := socketface.New(loc)
+	require.NoError(e)
+	defer face.Close()
+
+	fixture.RunMultiRxTest(face, 3)
+	fixture.CheckMultiRxCounters()
+}
+
 func checkStreamRedialing(t *testing.T, listener net.Listener, makeFaceA func() iface.Face) {
 	assert, require := makeAR(t)
 	fixture := ifacetestenv.NewFixture(t)
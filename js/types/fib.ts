@@ -1,4 +1,5 @@
 import type { Uint } from "./core";
+import type { Name } from "./ndni";
 
 /**
  * Forwarding Information Base (FIB) configuration.
@@ -8,4 +9,10 @@ export interface FibConfig {
   capacity?: Uint;
   nBuckets?: Uint;
   startDepth?: Uint;
+
+  /**
+   * Names of FIB entries whose counters are exported as OpenMetrics.
+   * @default []
+   */
+  metrics?: Name[];
 }
@@ -1,6 +1,6 @@
 import type { Uint } from "./core";
 import type { FibConfig } from "./fib";
-import type { NdtConfig } from "./ndt";
+import type { NdtBalancerConfig, NdtConfig } from "./ndt";
 import type { PcctConfig } from "./pcct";
 import type { SuppressConfig } from "./pit";
 import type { PktQueueConfig } from "./pktqueue";
@@ -11,10 +11,12 @@ import type { PktQueueConfig } from "./pktqueue";
  */
 export interface FwdpConfig {
   ndt?: NdtConfig;
+  ndtBalancer?: NdtBalancerConfig;
   fib?: FibConfig;
   pcct?: PcctConfig;
   suppress?: SuppressConfig;
   crypto?: FwdpCryptoConfig;
+  disk?: FwdpDiskConfig;
   fwdInterestQueue?: PktQueueConfig;
   fwdDataQueue?: PktQueueConfig;
   fwdNackQueue?: PktQueueConfig;
@@ -25,3 +27,8 @@ export interface FwdpCryptoConfig {
   inputCapacity?: Uint;
   opPoolCapacity?: Uint;
 }
+
+export interface FwdpDiskConfig {
+  filename?: string;
+  nBlocksPerSlot?: Uint;
+}
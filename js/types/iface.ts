@@ -32,11 +32,48 @@ export interface FaceConfig {
    */
   outputQueueSize?: Uint;
 
+  /**
+   * Output queue occupancy that triggers congestion marking.
+   * Default is 0, which disables congestion marking on the output queue.
+   * @minimum 0
+   */
+  congMarkThreshold?: Uint;
+
   /**
    * @minimum 960
    * @maximum 65000
    */
   mtu?: Uint;
+
+  /**
+   * NDNLPv2 link reliability; omit to disable.
+   */
+  reliability?: FaceReliabilityConfig;
+}
+
+/**
+ * Face link reliability configuration.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/iface#ReliabilityConfig>
+ */
+export interface FaceReliabilityConfig {
+  /**
+   * @default 200
+   */
+  retxTimeout?: NNMilliseconds;
+
+  /**
+   * @minimum 1
+   * @maximum 255
+   * @default 3
+   */
+  maxRetx?: Uint;
+
+  /**
+   * @minimum 64
+   * @maximum 65536
+   * @default 1024
+   */
+  window?: Uint;
 }
 
 /**
@@ -217,6 +254,9 @@ export interface FaceRxCounters {
   rxDecodeErrs: Counter;
   rxReassPackets: Counter;
   rxReassDrops: Counter;
+  rxReassShared: Counter;
+  rxRelDrops: Counter;
+  rxCongMarks: Counter;
 }
 
 export interface FaceTxCounters {
@@ -230,4 +270,12 @@ export interface FaceTxCounters {
   txFragBad: Counter;
   txAllocErrs: Counter;
   txDropped: Counter;
+  txCongMarks: Counter;
+  txCongMarksAdded: Counter;
+
+  txRetxFrames: Counter;
+  txAckedFrames: Counter;
+  txLostFrames: Counter;
+  txAckFields: Counter;
+  txIdleFrames: Counter;
 }
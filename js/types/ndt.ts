@@ -1,4 +1,4 @@
-import type { Uint } from "./core";
+import type { NNMilliseconds, Uint } from "./core";
 
 /**
  * Name Dispatch Table (NDT) configuration.
@@ -9,3 +9,15 @@ export interface NdtConfig {
   capacity?: Uint;
   sampleInterval?: Uint;
 }
+
+/**
+ * NDT load balancer configuration.
+ * @see <https://pkg.go.dev/github.com/usnistgov/ndn-dpdk/container/ndt#BalancerConfig>
+ */
+export interface NdtBalancerConfig {
+  interval?: NNMilliseconds;
+  highLoad?: number;
+  minLoadDiff?: number;
+  maxMoves?: Uint;
+  holdDown?: NNMilliseconds;
+}
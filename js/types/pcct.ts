@@ -8,4 +8,5 @@ export interface PcctConfig {
   pcctCapacity?: Uint;
   csDirectCapacity?: Uint;
   csIndirectCapacity?: Uint;
+  csDiskCapacity?: Uint;
 }
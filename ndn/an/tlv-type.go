@@ -13,6 +13,8 @@ const (
 	TtNack           = 0x0320
 	TtNackReason     = 0x0321
 	TtCongestionMark = 0x0340
+	TtLpAck          = 0x0344
+	TtLpTxSequence   = 0x0348
 
 	TtName                            = 0x07
 	TtGenericNameComponent            = 0x08
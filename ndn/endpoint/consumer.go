@@ -26,6 +26,8 @@ type ConsumerOptions struct {
 
 	// Verifier specifies a Data verifier.
 	// Default is no verification.
+	//
+	// If Verifier implements ContextVerifier, such as trust.Validator, its verification is bounded by the context.
 	Verifier ndn.Verifier
 }
 
@@ -56,7 +58,7 @@ func Consume(ctx context.Context, interest ndn.Interest, opts ConsumerOptions) (
 		data, e = c.once(ctx)
 		switch e {
 		case nil:
-			if e = opts.Verifier.Verify(data); e != nil {
+			if e = VerifyContext(ctx, opts.Verifier, data); e != nil {
 				return nil, e
 			}
 			return data, nil
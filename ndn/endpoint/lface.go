@@ -35,6 +35,10 @@ func (face lFaceL3) OnStateChange(cb func(st l3.TransportState)) (cancel func())
 	panic("not supported")
 }
 
+func (face lFaceL3) Counters() (cnt l3.FaceCounters) {
+	return cnt
+}
+
 // LFace is a logical face between endpoint (consumer or producer) and internal forwarder.
 type LFace struct {
 	ep2fw  chan *ndn.Packet
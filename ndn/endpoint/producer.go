@@ -54,7 +54,19 @@ type ProducerOptions struct {
 
 	// DataSigner automatically signs Data packets unless already signed.
 	// Default is keeping the Null signature.
+	// A signer from persistent storage can be obtained via keychain.KeyChain Signer method.
 	DataSigner ndn.Signer
+
+	// InterestVerifier verifies Interest signature before invoking the Handler.
+	// Interests that fail verification are dropped.
+	// Default is no verification.
+	InterestVerifier ndn.Verifier
+
+	// InterestPolicy checks SigNonce, SigTime, and SigSeqNum of signed Interests.
+	// Unsigned, replayed, or stale Interests are dropped.
+	// This check happens after InterestVerifier, so that the policy state is only updated by authentic Interests.
+	// Default is accepting every Interest.
+	InterestPolicy *ndn.SignedInterestPolicy
 }
 
 // Produce starts a producer.
@@ -131,6 +143,16 @@ func (p *producer) handleInterest(ctx context.Context, wg *sync.WaitGroup, pkt *
 	if !p.Prefix.IsPrefixOf(interest.Name) {
 		return
 	}
+	if p.InterestVerifier != nil {
+		if e := VerifyContext(ctx, p.InterestVerifier, interest); e != nil {
+			return
+		}
+	}
+	if p.InterestPolicy != nil {
+		if e := p.InterestPolicy.Check(*interest); e != nil {
+			return
+		}
+	}
 
 	ctx1, cancel1 := context.WithTimeout(ctx, interest.ApplyDefaultLifetime())
 	defer cancel1()
@@ -201,3 +201,50 @@ func TestProducerNoAdvertise(t *testing.T) {
 	time.Sleep(50 * time.Millisecond)
 	assert.Len(dest.withdrawn, 0)
 }
+
+func TestProducerSignedInterest(t *testing.T) {
+	fw := l3.NewForwarder()
+	assert, require := makeAR(t)
+
+	signer, verifier, e := keychain.NewECDSAKeyPair(ndn.ParseName("/K"))
+	require.NoError(e)
+	_, otherVerifier, e := keychain.NewECDSAKeyPair(ndn.ParseName("/K"))
+	require.NoError(e)
+
+	var nHandled int32
+	makeProducer := func(prefix string, verifier ndn.Verifier) endpoint.Producer {
+		p, e := endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+			Prefix: ndn.ParseName(prefix),
+			Handler: func(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+				atomic.AddInt32(&nHandled, 1)
+				return ndn.MakeData(interest), nil
+			},
+			Fw:               fw,
+			InterestVerifier: verifier,
+			InterestPolicy:   &ndn.SignedInterestPolicy{Time: true, SeqNum: true},
+		})
+		require.NoError(e)
+		return p
+	}
+	defer must.Close(makeProducer("/A", verifier))
+	defer must.Close(makeProducer("/B", otherVerifier))
+
+	interestSigner := (&ndn.SignedInterestPolicy{NonceLength: 8, Time: true, SeqNum: true}).Signer(signer)
+	makeSigned := func(name string) ndn.Interest {
+		interest := ndn.MakeInterest(name, 200*time.Millisecond)
+		require.NoError(interestSigner.Sign(&interest))
+		return interest
+	}
+	consume := func(interest ndn.Interest) error {
+		_, e := endpoint.Consume(context.Background(), interest, endpoint.ConsumerOptions{Fw: fw})
+		return e
+	}
+
+	i1 := makeSigned("/A/1")
+	assert.NoError(consume(i1))
+	assert.NoError(consume(makeSigned("/A/2")))
+	assert.ErrorIs(consume(i1), endpoint.ErrExpire)                                             // replayed
+	assert.ErrorIs(consume(makeSigned("/B/1")), endpoint.ErrExpire)                             // bad signature
+	assert.ErrorIs(consume(ndn.MakeInterest("/A/3", 200*time.Millisecond)), endpoint.ErrExpire) // unsigned
+	assert.EqualValues(2, atomic.LoadInt32(&nHandled))
+}
@@ -0,0 +1,23 @@
+package endpoint
+
+import (
+	"context"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// ContextVerifier is a Verifier whose verification procedure can be bounded by a context.
+// This is typically implemented by a verifier that retrieves certificates from the network.
+type ContextVerifier interface {
+	ndn.Verifier
+	VerifyContext(ctx context.Context, packet ndn.Verifiable) error
+}
+
+// VerifyContext verifies a packet.
+// If verifier implements ContextVerifier, the context is passed to the verifier.
+func VerifyContext(ctx context.Context, verifier ndn.Verifier, packet ndn.Verifiable) error {
+	if cv, ok := verifier.(ContextVerifier); ok {
+		return cv.VerifyContext(ctx, packet)
+	}
+	return verifier.Verify(packet)
+}
@@ -155,6 +155,9 @@ func (interest *Interest) SignWith(signer func(name Name, si *SigInfo) (LLSign,
 	interest.SigValue = sig
 
 	interest.UpdateParamsDigest()
+	if interest.packet != nil { // ToPacket should reflect the signed Interest
+		interest.packet = &Packet{Lp: interest.packet.Lp, Interest: interest}
+	}
 	return nil
 }
 
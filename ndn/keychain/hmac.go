@@ -0,0 +1,112 @@
+package keychain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+)
+
+// HMACKeyLength is the length of HMAC secret generated by NewHMACKeyPair.
+const HMACKeyLength = 32
+
+// ErrHMACSPKI indicates an attempt to export an HMAC key as SubjectPublicKeyInfo.
+var ErrHMACSPKI = errors.New("HMAC key cannot be encoded as SubjectPublicKeyInfo")
+
+var oidHmacWithSha256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+
+// hmacSecret is the key type of HMAC privateKey and publicKey.
+type hmacSecret []byte
+
+func (secret hmacSecret) compute(input []byte) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write(input)
+	return h.Sum(nil)
+}
+
+// hmacPKCS8 is a PKCS #8 PrivateKeyInfo structure that carries an HMAC secret.
+//
+// There is no standard PKCS #8 encoding for HMAC keys, and this is specific to NDNgo:
+// privateKeyAlgorithm is hmacWithSHA256 (RFC 8018) without parameters, and privateKey contains
+// the raw secret instead of an algorithm-specific structure.
+// It exists so that HMAC keys can be stored by MarshalKey and the directory-based KeyChain
+// alongside other key types; other NDN libraries cannot import it.
+type hmacPKCS8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+func (secret hmacSecret) marshalPKCS8() ([]byte, error) {
+	return asn1.Marshal(hmacPKCS8{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidHmacWithSha256},
+		PrivateKey: secret,
+	})
+}
+
+func parseHMACPKCS8(der []byte) (secret hmacSecret, ok bool) {
+	var info hmacPKCS8
+	if rest, e := asn1.Unmarshal(der, &info); e != nil || len(rest) > 0 || !info.Algo.Algorithm.Equal(oidHmacWithSha256) {
+		return nil, false
+	}
+	return info.PrivateKey, true
+}
+
+// NewHMACPrivateKey creates a private key for SigHmacWithSha256 signature type.
+func NewHMACPrivateKey(keyName ndn.Name, secret []byte) (PrivateKey, error) {
+	key := hmacSecret(append([]byte{}, secret...))
+	return newPrivateKey(an.SigHmacWithSha256, keyName, key, func(input []byte) (sig []byte, e error) {
+		return key.compute(input), nil
+	})
+}
+
+// NewHMACPublicKey creates a public key for SigHmacWithSha256 signature type.
+// HMAC is symmetric: the returned verifier holds the same secret as the private key and must be kept confidential.
+// Its SPKI method always fails.
+func NewHMACPublicKey(keyName ndn.Name, secret []byte) (PublicKey, error) {
+	key := hmacSecret(append([]byte{}, secret...))
+	return newPublicKey(an.SigHmacWithSha256, keyName, key, func(input, sig []byte) error {
+		if !hmac.Equal(key.compute(input), sig) {
+			return ndn.ErrSigValue
+		}
+		return nil
+	})
+}
+
+// NewHMACKeyPair creates a key pair for SigHmacWithSha256 signature type.
+// The secret is HMACKeyLength random octets.
+func NewHMACKeyPair(name ndn.Name) (PrivateKey, PublicKey, error) {
+	keyName := ToKeyName(name)
+	secret := make([]byte, HMACKeyLength)
+	if _, e := rand.Read(secret); e != nil {
+		return nil, nil, e
+	}
+	pvt, e := NewHMACPrivateKey(keyName, secret)
+	if e != nil {
+		return nil, nil, e
+	}
+	pub, e := NewHMACPublicKey(keyName, secret)
+	if e != nil {
+		return nil, nil, e
+	}
+	return pvt, pub, e
+}
+
+// HMACPublicKeyOf returns the verifier of an HMAC private key.
+// This is useful after loading a key via UnmarshalKey or KeyChain.
+func HMACPublicKeyOf(key PrivateKey) (PublicKey, error) {
+	pkey, _ := key.(*privateKey)
+	if pkey == nil {
+		return nil, ndn.ErrSigType
+	}
+	secret, ok := pkey.key.(hmacSecret)
+	if !ok {
+		return nil, ndn.ErrSigType
+	}
+	return NewHMACPublicKey(pkey.Name(), secret)
+}
@@ -0,0 +1,107 @@
+package keychain_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/core/testenv"
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/ndntestenv"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+func TestHMACSigning(t *testing.T) {
+	assert, require := makeAR(t)
+	secretA := bytes.Repeat([]byte{0xA0}, 32)
+
+	subjectName := ndn.ParseName("/K")
+	_, e := keychain.NewHMACPrivateKey(subjectName, secretA)
+	assert.Error(e)
+	_, e = keychain.NewHMACPublicKey(subjectName, secretA)
+	assert.Error(e)
+
+	keyNameA := keychain.ToKeyName(subjectName)
+	pvtA, e := keychain.NewHMACPrivateKey(keyNameA, secretA)
+	require.NoError(e)
+	pubA, e := keychain.NewHMACPublicKey(keyNameA, secretA)
+	require.NoError(e)
+	nameEqual(assert, keyNameA, pvtA)
+	nameEqual(assert, keyNameA, pubA)
+	_, e = pubA.SPKI()
+	assert.ErrorIs(e, keychain.ErrHMACSPKI)
+
+	pvtB, pubB, e := keychain.NewHMACKeyPair(subjectName)
+	require.NoError(e)
+	nameEqual(assert, pvtB, pubB)
+
+	pvtWireA, e := keychain.MarshalKey(pvtA)
+	require.NoError(e)
+	pvtA, e = keychain.UnmarshalKey(pvtWireA)
+	require.NoError(e)
+	nameEqual(assert, keyNameA, pvtA)
+	pubA, e = keychain.HMACPublicKeyOf(pvtA)
+	require.NoError(e)
+
+	rsaPvt, _, e := keychain.NewRSAKeyPair(subjectName)
+	require.NoError(e)
+	_, e = keychain.HMACPublicKeyOf(rsaPvt)
+	assert.Error(e)
+
+	var c ndntestenv.SignVerifyTester
+	c.PvtA, c.PvtB, c.PubA, c.PubB = pvtA, pvtB, pubA, pubB
+	c.CheckInterest(t)
+	c.CheckInterestParameterized(t)
+	rec := c.CheckData(t)
+
+	dataA := rec.PktA.(*ndn.Data)
+	assert.EqualValues(an.SigHmacWithSha256, dataA.SigInfo.Type)
+	nameEqual(assert, pubA, dataA.SigInfo.KeyLocator)
+}
+
+func TestHMACVector(t *testing.T) {
+	assert, require := makeAR(t)
+
+	// Data /ndn/hmac/data with FreshnessPeriod 10000ms and Content "hello", signed with
+	// HMAC-SHA256 key /hmac/KEY/k1, whose secret is the key of RFC 4231 test case 1.
+	// SignatureValue was computed over the signed portion with Python hmac module, not with ndn-cxx.
+	secret := bytes.Repeat([]byte{0x0B}, 20)
+	keyName := ndn.ParseName("/hmac/KEY/k1")
+	wire := testenv.BytesFromHex(`
+		065A name=071108036E646E0804686D6163080464617461 metainfo=140419022710 content=150568656C6C6F
+		siginfo=16161B01041C11070F0804686D616308034B455908026B31
+		sigvalue=17205BCE7A6A15AC706B23B8A904D9AC3A930F5967216398A8887F357904DB34715A
+	`)
+
+	var pkt ndn.Packet
+	require.NoError(tlv.Decode(wire, &pkt))
+	require.NotNil(pkt.Data)
+	assert.Equal(10000*time.Millisecond, pkt.Data.Freshness)
+
+	pub, e := keychain.NewHMACPublicKey(keyName, secret)
+	require.NoError(e)
+	assert.NoError(pub.Verify(pkt.Data))
+
+	otherPub, e := keychain.NewHMACPublicKey(keyName, bytes.Repeat([]byte{0x0C}, 20))
+	require.NoError(e)
+	assert.ErrorIs(otherPub.Verify(pkt.Data), ndn.ErrSigValue)
+
+	// modified Content fails verification
+	modified := append([]byte{}, wire...)
+	modified[bytes.Index(modified, []byte("hello"))] = 'j'
+	var modifiedPkt ndn.Packet
+	require.NoError(tlv.Decode(modified, &modifiedPkt))
+	require.NotNil(modifiedPkt.Data)
+	assert.ErrorIs(pub.Verify(modifiedPkt.Data), ndn.ErrSigValue)
+
+	// re-signing the decoded packet reproduces the exact wire encoding
+	pvt, e := keychain.NewHMACPrivateKey(keyName, secret)
+	require.NoError(e)
+	data := *pkt.Data
+	require.NoError(pvt.Sign(&data))
+	reencoded, e := tlv.EncodeFrom(data)
+	require.NoError(e)
+	assert.Equal(wire, reencoded)
+}
@@ -0,0 +1,524 @@
+package keychain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Error conditions for KeyChain.
+var (
+	ErrKeyNotFound  = errors.New("key not found")
+	ErrCertNotFound = errors.New("certificate not found")
+)
+
+const (
+	keyChainKeysDir     = "keys"
+	keyChainCertsDir    = "certs"
+	keyChainDefaultFile = "default.json"
+	keyChainKeyExt      = ".key"
+	keyChainCertExt     = ".cert"
+)
+
+type keyChainDefaults struct {
+	Identity ndn.Name            `json:"identity,omitempty"`
+	Keys     map[string]ndn.Name `json:"keys,omitempty"` // identity URI => key name
+}
+
+// KeyChain is a persistent storage of private keys and certificates.
+//
+// The storage is a directory with the following layout:
+//
+//	keys/*.key      private keys in MarshalKey format
+//	certs/*.cert    certificates in MarshalCert format
+//	default.json    default identity and key selection
+//
+// Files are named after the SHA-256 digest of the key name or certificate name.
+// Every file is written atomically, by writing to a temporary file and then renaming it.
+//
+// An identity is a subject name that has at least one key or certificate.
+type KeyChain struct {
+	dir      string
+	mutex    sync.RWMutex
+	keys     map[string]PrivateKey
+	certs    map[string]*Certificate
+	defaults keyChainDefaults
+}
+
+// OpenKeyChain opens a KeyChain stored in a directory.
+// The directory is created if it does not exist.
+func OpenKeyChain(dir string) (kc *KeyChain, e error) {
+	kc = &KeyChain{
+		dir:   dir,
+		keys:  map[string]PrivateKey{},
+		certs: map[string]*Certificate{},
+	}
+
+	for _, subdir := range []string{keyChainKeysDir, keyChainCertsDir} {
+		if e = os.MkdirAll(filepath.Join(dir, subdir), 0o700); e != nil {
+			return nil, e
+		}
+	}
+
+	if e = kc.loadFiles(keyChainKeysDir, keyChainKeyExt, func(wire []byte) error {
+		key, e := UnmarshalKey(wire)
+		if e == nil {
+			kc.keys[key.Name().String()] = key
+		}
+		return e
+	}); e != nil {
+		return nil, e
+	}
+
+	if e = kc.loadFiles(keyChainCertsDir, keyChainCertExt, func(wire []byte) error {
+		cert, e := UnmarshalCert(wire)
+		if e == nil {
+			kc.certs[cert.Name().String()] = cert
+		}
+		return e
+	}); e != nil {
+		return nil, e
+	}
+
+	switch wire, e := os.ReadFile(filepath.Join(dir, keyChainDefaultFile)); {
+	case e == nil:
+		if e = json.Unmarshal(wire, &kc.defaults); e != nil {
+			return nil, fmt.Errorf("%s: %w", keyChainDefaultFile, e)
+		}
+	case !errors.Is(e, os.ErrNotExist):
+		return nil, e
+	}
+	if kc.defaults.Keys == nil {
+		kc.defaults.Keys = map[string]ndn.Name{}
+	}
+
+	return kc, nil
+}
+
+func (kc *KeyChain) loadFiles(subdir, ext string, load func(wire []byte) error) error {
+	matches, e := filepath.Glob(filepath.Join(kc.dir, subdir, "*"+ext))
+	if e != nil {
+		return e
+	}
+	for _, filename := range matches {
+		wire, e := os.ReadFile(filename)
+		if e != nil {
+			return e
+		}
+		if e = load(wire); e != nil {
+			return fmt.Errorf("%s: %w", filename, e)
+		}
+	}
+	return nil
+}
+
+func (kc *KeyChain) filename(subdir, ext string, name ndn.Name) string {
+	wire, _ := name.MarshalBinary()
+	digest := sha256.Sum256(wire)
+	return filepath.Join(kc.dir, subdir, hex.EncodeToString(digest[:])+ext)
+}
+
+// writeFile atomically writes a file.
+func (kc *KeyChain) writeFile(filename string, wire []byte) error {
+	tmp, e := os.CreateTemp(filepath.Dir(filename), ".tmp-*")
+	if e != nil {
+		return e
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, e = tmp.Write(wire); e != nil {
+		tmp.Close()
+		return e
+	}
+	if e = tmp.Sync(); e != nil {
+		tmp.Close()
+		return e
+	}
+	if e = tmp.Close(); e != nil {
+		return e
+	}
+	return os.Rename(tmp.Name(), filename)
+}
+
+func (kc *KeyChain) saveDefaults() error {
+	wire, e := json.MarshalIndent(kc.defaults, "", "  ")
+	if e != nil {
+		return e
+	}
+	return kc.writeFile(filepath.Join(kc.dir, keyChainDefaultFile), wire)
+}
+
+// Identities returns a sorted list of identities.
+func (kc *KeyChain) Identities() (list []ndn.Name) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+
+	ids := map[string]ndn.Name{}
+	for _, key := range kc.keys {
+		id := ToSubjectName(key.Name())
+		ids[id.String()] = id
+	}
+	for _, cert := range kc.certs {
+		id := cert.SubjectName()
+		ids[id.String()] = id
+	}
+	for _, id := range ids {
+		list = append(list, id)
+	}
+	sortNames(list)
+	return list
+}
+
+// Keys returns a sorted list of private keys of an identity.
+func (kc *KeyChain) Keys(id ndn.Name) (list []PrivateKey) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+	return kc.listKeys(id)
+}
+
+func (kc *KeyChain) listKeys(id ndn.Name) (list []PrivateKey) {
+	for _, key := range kc.keys {
+		if ToSubjectName(key.Name()).Equal(id) {
+			list = append(list, key)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name().Compare(list[j].Name()) < 0 })
+	return list
+}
+
+// Certs returns a sorted list of certificates of a key.
+func (kc *KeyChain) Certs(keyName ndn.Name) (list []*Certificate) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+	return kc.listCerts(keyName)
+}
+
+func (kc *KeyChain) listCerts(keyName ndn.Name) (list []*Certificate) {
+	for _, cert := range kc.certs {
+		if ToKeyName(cert.Name()).Equal(keyName) {
+			list = append(list, cert)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name().Compare(list[j].Name()) < 0 })
+	return list
+}
+
+// Key returns a private key by key name.
+func (kc *KeyChain) Key(keyName ndn.Name) (PrivateKey, error) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+	if key := kc.keys[keyName.String()]; key != nil {
+		return key, nil
+	}
+	return nil, ErrKeyNotFound
+}
+
+// Cert returns a certificate by certificate name.
+func (kc *KeyChain) Cert(certName ndn.Name) (*Certificate, error) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+	if cert := kc.certs[certName.String()]; cert != nil {
+		return cert, nil
+	}
+	return nil, ErrCertNotFound
+}
+
+// ImportKey saves a private key, overwriting any existing key with the same name.
+// If its identity does not have a default key, this key becomes the default key.
+// If there is no default identity, its identity becomes the default identity.
+func (kc *KeyChain) ImportKey(key PrivateKey) error {
+	wire, e := MarshalKey(key)
+	if e != nil {
+		return e
+	}
+
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+	if e = kc.writeFile(kc.filename(keyChainKeysDir, keyChainKeyExt, key.Name()), wire); e != nil {
+		return e
+	}
+	kc.keys[key.Name().String()] = key
+
+	id := ToSubjectName(key.Name())
+	changed := false
+	if kc.defaults.Keys[id.String()] == nil {
+		kc.defaults.Keys[id.String()] = key.Name()
+		changed = true
+	}
+	if kc.defaults.Identity == nil {
+		kc.defaults.Identity = id
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return kc.saveDefaults()
+}
+
+// ImportCert saves a certificate, overwriting any existing certificate with the same name.
+func (kc *KeyChain) ImportCert(cert *Certificate) error {
+	wire, e := MarshalCert(cert)
+	if e != nil {
+		return e
+	}
+
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+	if e = kc.writeFile(kc.filename(keyChainCertsDir, keyChainCertExt, cert.Name()), wire); e != nil {
+		return e
+	}
+	kc.certs[cert.Name().String()] = cert
+	return nil
+}
+
+// ExportKey returns a private key in MarshalKey format.
+func (kc *KeyChain) ExportKey(keyName ndn.Name) ([]byte, error) {
+	key, e := kc.Key(keyName)
+	if e != nil {
+		return nil, e
+	}
+	return MarshalKey(key)
+}
+
+// ExportCert returns a certificate in MarshalCert format.
+func (kc *KeyChain) ExportCert(certName ndn.Name) ([]byte, error) {
+	cert, e := kc.Cert(certName)
+	if e != nil {
+		return nil, e
+	}
+	return MarshalCert(cert)
+}
+
+// DeleteCert deletes a certificate.
+func (kc *KeyChain) DeleteCert(certName ndn.Name) error {
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+	return kc.deleteCert(certName)
+}
+
+func (kc *KeyChain) deleteCert(certName ndn.Name) error {
+	if kc.certs[certName.String()] == nil {
+		return ErrCertNotFound
+	}
+	if e := os.Remove(kc.filename(keyChainCertsDir, keyChainCertExt, certName)); e != nil && !errors.Is(e, os.ErrNotExist) {
+		return e
+	}
+	delete(kc.certs, certName.String())
+	return nil
+}
+
+// DeleteKey deletes a private key and its certificates.
+// If it was the default key of its identity, another key of the same identity becomes the default key.
+func (kc *KeyChain) DeleteKey(keyName ndn.Name) error {
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+	if kc.keys[keyName.String()] == nil {
+		return ErrKeyNotFound
+	}
+	if e := kc.deleteKey(keyName); e != nil {
+		return e
+	}
+	return kc.fixDefaults()
+}
+
+func (kc *KeyChain) deleteKey(keyName ndn.Name) error {
+	for _, cert := range kc.listCerts(keyName) {
+		if e := kc.deleteCert(cert.Name()); e != nil {
+			return e
+		}
+	}
+	if kc.keys[keyName.String()] == nil {
+		return nil
+	}
+	if e := os.Remove(kc.filename(keyChainKeysDir, keyChainKeyExt, keyName)); e != nil && !errors.Is(e, os.ErrNotExist) {
+		return e
+	}
+	delete(kc.keys, keyName.String())
+	return nil
+}
+
+// DeleteIdentity deletes all private keys and certificates of an identity.
+func (kc *KeyChain) DeleteIdentity(id ndn.Name) error {
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+
+	keyNames := map[string]ndn.Name{}
+	for _, key := range kc.listKeys(id) {
+		keyNames[key.Name().String()] = key.Name()
+	}
+	for _, cert := range kc.certs {
+		if cert.SubjectName().Equal(id) {
+			keyName := ToKeyName(cert.Name())
+			keyNames[keyName.String()] = keyName
+		}
+	}
+	for _, keyName := range keyNames {
+		if e := kc.deleteKey(keyName); e != nil {
+			return e
+		}
+	}
+	return kc.fixDefaults()
+}
+
+// fixDefaults removes dangling default selections and chooses replacements.
+func (kc *KeyChain) fixDefaults() error {
+	changed := false
+	for idURI, keyName := range kc.defaults.Keys {
+		if kc.keys[keyName.String()] != nil {
+			continue
+		}
+		changed = true
+		if keys := kc.listKeys(ToSubjectName(keyName)); len(keys) > 0 {
+			kc.defaults.Keys[idURI] = keys[0].Name()
+		} else {
+			delete(kc.defaults.Keys, idURI)
+		}
+	}
+
+	if id := kc.defaults.Identity; id != nil && kc.defaults.Keys[id.String()] == nil {
+		changed = true
+		kc.defaults.Identity = nil
+		var ids []ndn.Name
+		for _, keyName := range kc.defaults.Keys {
+			ids = append(ids, ToSubjectName(keyName))
+		}
+		if len(ids) > 0 {
+			sortNames(ids)
+			kc.defaults.Identity = ids[0]
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return kc.saveDefaults()
+}
+
+// DefaultIdentity returns the default identity.
+// Returns nil if the KeyChain has no key.
+func (kc *KeyChain) DefaultIdentity() ndn.Name {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+	return kc.defaults.Identity
+}
+
+// SetDefaultIdentity changes the default identity.
+// The identity must have at least one private key.
+func (kc *KeyChain) SetDefaultIdentity(id ndn.Name) error {
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+	if kc.defaults.Keys[id.String()] == nil {
+		return ErrKeyNotFound
+	}
+	kc.defaults.Identity = id
+	return kc.saveDefaults()
+}
+
+// DefaultKey returns the default key of an identity.
+func (kc *KeyChain) DefaultKey(id ndn.Name) (PrivateKey, error) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+	return kc.defaultKey(id)
+}
+
+func (kc *KeyChain) defaultKey(id ndn.Name) (PrivateKey, error) {
+	keyName := kc.defaults.Keys[id.String()]
+	if keyName == nil {
+		return nil, ErrKeyNotFound
+	}
+	return kc.keys[keyName.String()], nil
+}
+
+// SetDefaultKey changes the default key of its identity.
+func (kc *KeyChain) SetDefaultKey(keyName ndn.Name) error {
+	kc.mutex.Lock()
+	defer kc.mutex.Unlock()
+	if kc.keys[keyName.String()] == nil {
+		return ErrKeyNotFound
+	}
+	kc.defaults.Keys[ToSubjectName(keyName).String()] = keyName
+	return kc.saveDefaults()
+}
+
+// Signer finds a signer by name.
+//   - If name is a certificate name, the signer uses the corresponding key and puts the certificate name in KeyLocator.
+//   - If name is a key name, the signer uses this key.
+//   - Otherwise, the signer uses the default key of the longest identity that is a prefix of name.
+//
+// If the chosen key has certificates, the last certificate in canonical order (normally the latest version) is put in KeyLocator.
+func (kc *KeyChain) Signer(name ndn.Name) (ndn.Signer, error) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+
+	switch {
+	case IsCertName(name):
+		if kc.certs[name.String()] == nil {
+			return nil, ErrCertNotFound
+		}
+		key := kc.keys[ToKeyName(name).String()]
+		if key == nil {
+			return nil, ErrKeyNotFound
+		}
+		return key.WithKeyLocator(name), nil
+	case IsKeyName(name):
+		key := kc.keys[name.String()]
+		if key == nil {
+			return nil, ErrKeyNotFound
+		}
+		return kc.signerOf(key), nil
+	}
+
+	var id ndn.Name
+	found := false
+	for _, keyName := range kc.defaults.Keys {
+		candidate := ToSubjectName(keyName)
+		if !candidate.IsPrefixOf(name) {
+			continue
+		}
+		if !found || len(candidate) > len(id) {
+			id, found = candidate, true
+		}
+	}
+	if !found {
+		return nil, ErrKeyNotFound
+	}
+	key, e := kc.defaultKey(id)
+	if e != nil {
+		return nil, e
+	}
+	return kc.signerOf(key), nil
+}
+
+// DefaultSigner returns a signer of the default key of the default identity.
+func (kc *KeyChain) DefaultSigner() (ndn.Signer, error) {
+	kc.mutex.RLock()
+	defer kc.mutex.RUnlock()
+
+	if kc.defaults.Identity == nil {
+		return nil, ErrKeyNotFound
+	}
+	key, e := kc.defaultKey(kc.defaults.Identity)
+	if e != nil {
+		return nil, e
+	}
+	return kc.signerOf(key), nil
+}
+
+func (kc *KeyChain) signerOf(key PrivateKey) ndn.Signer {
+	if certs := kc.listCerts(key.Name()); len(certs) > 0 {
+		return key.WithKeyLocator(certs[len(certs)-1].Name())
+	}
+	return key
+}
+
+func sortNames(list []ndn.Name) {
+	sort.Slice(list, func(i, j int) bool { return list[i].Compare(list[j]) < 0 })
+}
@@ -0,0 +1,119 @@
+package keychain_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+)
+
+func TestKeyChain(t *testing.T) {
+	assert, require := makeAR(t)
+	dir := t.TempDir()
+
+	kc, e := keychain.OpenKeyChain(dir)
+	require.NoError(e)
+	assert.Empty(kc.Identities())
+	assert.Nil(kc.DefaultIdentity())
+	_, e = kc.DefaultSigner()
+	assert.ErrorIs(e, keychain.ErrKeyNotFound)
+
+	pvtA1, pubA1, e := keychain.NewECDSAKeyPair(ndn.ParseName("/A"))
+	require.NoError(e)
+	pvtA2, _, e := keychain.NewRSAKeyPair(ndn.ParseName("/A"))
+	require.NoError(e)
+	pvtB, pubB, e := keychain.NewECDSAKeyPair(ndn.ParseName("/A/B"))
+	require.NoError(e)
+	certA1, e := keychain.MakeCert(pubA1, pvtA1, keychain.MakeCertOptions{})
+	require.NoError(e)
+	certB, e := keychain.MakeCert(pubB, pvtA1, keychain.MakeCertOptions{})
+	require.NoError(e)
+
+	require.NoError(kc.ImportKey(pvtA1))
+	require.NoError(kc.ImportKey(pvtA2))
+	require.NoError(kc.ImportKey(pvtB))
+	require.NoError(kc.ImportCert(certA1))
+	require.NoError(kc.ImportCert(certB))
+
+	// reopen from disk
+	kc, e = keychain.OpenKeyChain(dir)
+	require.NoError(e)
+
+	ids := kc.Identities()
+	require.Len(ids, 2)
+	nameEqual(assert, "/A", ids[0])
+	nameEqual(assert, "/A/B", ids[1])
+	assert.Len(kc.Keys(ndn.ParseName("/A")), 2)
+	assert.Len(kc.Keys(ndn.ParseName("/A/B")), 1)
+	assert.Len(kc.Certs(pvtA1.Name()), 1)
+	assert.Len(kc.Certs(pvtA2.Name()), 0)
+
+	nameEqual(assert, "/A", kc.DefaultIdentity())
+	dfltA, e := kc.DefaultKey(ndn.ParseName("/A"))
+	require.NoError(e)
+	nameEqual(assert, pvtA1, dfltA)
+
+	wire, e := kc.ExportKey(pvtA2.Name())
+	require.NoError(e)
+	exported, e := keychain.UnmarshalKey(wire)
+	require.NoError(e)
+	nameEqual(assert, pvtA2, exported)
+	wire, e = kc.ExportCert(certB.Name())
+	require.NoError(e)
+	exportedCert, e := keychain.UnmarshalCert(wire)
+	require.NoError(e)
+	nameEqual(assert, certB, exportedCert)
+
+	signData := func(signer ndn.Signer) ndn.Name {
+		data := ndn.MakeData("/A/B/C/data")
+		require.NoError(signer.Sign(&data))
+		return data.SigInfo.KeyLocator.Name
+	}
+
+	// longest prefix match of identity, KeyLocator is certificate name
+	signer, e := kc.Signer(ndn.ParseName("/A/B/C"))
+	require.NoError(e)
+	nameEqual(assert, certB, signData(signer))
+	signer, e = kc.Signer(ndn.ParseName("/A/Z"))
+	require.NoError(e)
+	nameEqual(assert, certA1, signData(signer))
+	_, e = kc.Signer(ndn.ParseName("/Z"))
+	assert.ErrorIs(e, keychain.ErrKeyNotFound)
+
+	// key without certificate, KeyLocator is key name
+	signer, e = kc.Signer(pvtA2.Name())
+	require.NoError(e)
+	nameEqual(assert, pvtA2, signData(signer))
+
+	require.NoError(kc.SetDefaultKey(pvtA2.Name()))
+	require.NoError(kc.SetDefaultIdentity(ndn.ParseName("/A/B")))
+	signer, e = kc.DefaultSigner()
+	require.NoError(e)
+	nameEqual(assert, certB, signData(signer))
+	assert.ErrorIs(kc.SetDefaultIdentity(ndn.ParseName("/Z")), keychain.ErrKeyNotFound)
+
+	// delete default identity, another identity becomes default
+	require.NoError(kc.DeleteIdentity(ndn.ParseName("/A/B")))
+	nameEqual(assert, "/A", kc.DefaultIdentity())
+	_, e = kc.Cert(certB.Name())
+	assert.ErrorIs(e, keychain.ErrCertNotFound)
+
+	// delete default key, another key becomes default
+	require.NoError(kc.DeleteKey(pvtA2.Name()))
+	assert.ErrorIs(kc.DeleteKey(pvtA2.Name()), keychain.ErrKeyNotFound)
+	dfltA, e = kc.DefaultKey(ndn.ParseName("/A"))
+	require.NoError(e)
+	nameEqual(assert, pvtA1, dfltA)
+
+	require.NoError(kc.DeleteCert(certA1.Name()))
+	signer, e = kc.Signer(ndn.ParseName("/A"))
+	require.NoError(e)
+	nameEqual(assert, pvtA1, signData(signer))
+
+	kc, e = keychain.OpenKeyChain(dir)
+	require.NoError(e)
+	ids = kc.Identities()
+	require.Len(ids, 1)
+	nameEqual(assert, "/A", kc.DefaultIdentity())
+	assert.Len(kc.Certs(pvtA1.Name()), 0)
+}
@@ -13,6 +13,7 @@ import (
 )
 
 // MarshalKey serializes a private key to an internal format.
+// HMAC keys use an NDNgo-specific PKCS #8 encoding, which is not interoperable with other libraries.
 func MarshalKey(key PrivateKey) ([]byte, error) {
 	pkey, _ := key.(*privateKey)
 	if pkey == nil {
@@ -24,7 +25,12 @@ func MarshalKey(key PrivateKey) ([]byte, error) {
 		return nil, e
 	}
 
-	pkcs8, e := x509.MarshalPKCS8PrivateKey(key.(*privateKey).key)
+	var pkcs8 []byte
+	if secret, ok := pkey.key.(hmacSecret); ok {
+		pkcs8, e = secret.marshalPKCS8()
+	} else {
+		pkcs8, e = x509.MarshalPKCS8PrivateKey(pkey.key)
+	}
 	if e != nil {
 		return nil, e
 	}
@@ -45,6 +51,9 @@ func UnmarshalKey(wire []byte) (PrivateKey, error) {
 	}
 
 	pkcs8 := d.Rest()
+	if secret, ok := parseHMACPKCS8(pkcs8); ok {
+		return NewHMACPrivateKey(name, secret)
+	}
 	key, e := x509.ParsePKCS8PrivateKey(pkcs8)
 	if e != nil {
 		return nil, e
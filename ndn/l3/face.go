@@ -10,6 +10,8 @@
 package l3
 
 import (
+	"time"
+
 	"github.com/pkg/math"
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
@@ -22,11 +24,33 @@ const (
 
 // FaceConfig contains options for NewFace.
 type FaceConfig struct {
+	// ReassemblerCapacity is the maximum number of partial packets in the reassembler.
+	// The minimum is MinReassemblerCapacity.
 	ReassemblerCapacity int
+
+	// ReassemblerTimeout is the duration before a partial packet is discarded.
+	// Default is DefaultReassemblerTimeout.
+	ReassemblerTimeout time.Duration
+
+	// Reliability enables NDNLPv2 link reliability.
+	// Default is disabled.
+	Reliability *ReliabilityConfig
 }
 
 func (cfg *FaceConfig) applyDefaults() {
 	cfg.ReassemblerCapacity = math.MaxInt(cfg.ReassemblerCapacity, MinReassemblerCapacity)
+	if cfg.ReassemblerTimeout <= 0 {
+		cfg.ReassemblerTimeout = DefaultReassemblerTimeout
+	}
+}
+
+// FaceCounters contains face counters.
+type FaceCounters struct {
+	Reassembler ReassemblerCounters `json:"reassembler"`
+
+	// Reliability contains link reliability counters.
+	// They are zero if link reliability is disabled.
+	Reliability ReliabilityCounters `json:"reliability"`
 }
 
 // Face represents a communicate channel to send and receive NDN network layer packets.
@@ -46,6 +70,9 @@ type Face interface {
 
 	State() TransportState
 	OnStateChange(cb func(st TransportState)) (cancel func())
+
+	// Counters returns face counters.
+	Counters() FaceCounters
 }
 
 // NewFace creates a Face.
@@ -56,10 +83,17 @@ func NewFace(tr Transport, cfg FaceConfig) (Face, error) {
 		faceTr:      faceTr{tr},
 		rx:          make(chan *ndn.Packet),
 		tx:          make(chan ndn.L3Packet),
-		reassembler: ndn.NewLpReassembler(cfg.ReassemblerCapacity),
+		reassembler: newReassembler(cfg.ReassemblerCapacity, cfg.ReassemblerTimeout),
+	}
+
+	if cfg.Reliability != nil {
+		f.rel = newReliability(*cfg.Reliability)
 	}
 
 	if mtu := tr.MTU(); mtu > 0 {
+		if f.rel != nil {
+			mtu -= reliabilityOverhead
+		}
 		f.fragmenter = ndn.NewLpFragmenter(mtu)
 	}
 
@@ -74,7 +108,8 @@ type face struct {
 	tx chan ndn.L3Packet
 
 	fragmenter  *ndn.LpFragmenter
-	reassembler *ndn.LpReassembler
+	reassembler *reassembler
+	rel         *reliability
 }
 
 type faceTr struct {
@@ -93,21 +128,33 @@ func (f *face) Tx() chan<- ndn.L3Packet {
 	return f.tx
 }
 
+func (f *face) Counters() (cnt FaceCounters) {
+	cnt.Reassembler = f.reassembler.Counters()
+	if f.rel != nil {
+		cnt.Reliability = f.rel.Counters()
+	}
+	return cnt
+}
+
 func (f *face) rxLoop() {
 	for wire := range f.faceTr.Rx() {
+		if f.rel != nil && f.rel.Rx(wire) {
+			continue
+		}
+
 		var pkt ndn.Packet
 		e := tlv.Decode(wire, &pkt)
 		if e != nil {
 			continue
 		}
 
-		if pkt.Fragment == nil {
-			f.rx <- &pkt
-		} else {
-			full, e := f.reassembler.Accept(&pkt)
-			if e == nil && full != nil {
+		switch {
+		case pkt.Fragment != nil:
+			if full := f.reassembler.Accept(&pkt, time.Now()); full != nil {
 				f.rx <- full
 			}
+		case pkt.Interest != nil, pkt.Data != nil, pkt.Nack != nil:
+			f.rx <- &pkt
 		}
 	}
 	close(f.rx)
@@ -115,24 +162,47 @@ func (f *face) rxLoop() {
 
 func (f *face) txLoop() {
 	transportTx := f.faceTr.Tx()
-	for l3packet := range f.tx {
-		pkt := l3packet.ToPacket()
-
-		if f.fragmenter == nil {
-			f.txFrames(transportTx, pkt)
-		} else {
-			frags, e := f.fragmenter.Fragment(pkt)
-			if e == nil {
-				f.txFrames(transportTx, frags...)
+	defer close(transportTx)
+
+	var tick <-chan time.Time
+	if f.rel != nil {
+		ticker := time.NewTicker(f.rel.cfg.IdleAckInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case l3packet, ok := <-f.tx:
+			if !ok {
+				return
+			}
+			f.txPacket(transportTx, l3packet.ToPacket())
+		case now := <-tick:
+			for _, wire := range f.rel.Poll(now) {
+				transportTx <- wire
 			}
 		}
 	}
-	close(transportTx)
+}
+
+func (f *face) txPacket(transportTx chan<- []byte, pkt *ndn.Packet) {
+	if f.fragmenter == nil {
+		f.txFrames(transportTx, pkt)
+	} else {
+		frags, e := f.fragmenter.Fragment(pkt)
+		if e == nil {
+			f.txFrames(transportTx, frags...)
+		}
+	}
 }
 
 func (f *face) txFrames(transportTx chan<- []byte, frames ...*ndn.Packet) {
 	for _, frame := range frames {
 		wire, e := tlv.EncodeFrom(frame)
+		if e == nil && f.rel != nil {
+			wire, e = f.rel.Tx(wire, time.Now())
+		}
 		if e == nil {
 			transportTx <- wire
 		}
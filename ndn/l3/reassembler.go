@@ -0,0 +1,166 @@
+package l3
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Reassembler limits and defaults.
+const (
+	// MaxReassemblerFragments is the maximum FragCount accepted by the reassembler.
+	MaxReassemblerFragments = 31
+
+	// DefaultReassemblerTimeout is the default duration before a partial packet is discarded.
+	DefaultReassemblerTimeout = 500 * time.Millisecond
+)
+
+// ReassemblerCounters contains reassembler counters.
+type ReassemblerCounters struct {
+	DeliverPackets   uint64 `json:"deliverPackets"`
+	DeliverFragments uint64 `json:"deliverFragments"`
+	DropFragments    uint64 `json:"dropFragments"`
+}
+
+type partialPacket struct {
+	seqNumBase uint64
+	lp         ndn.LpL3
+	frags      [][]byte
+	nAccepted  int
+	lastUpdate time.Time
+	node       *list.Element
+}
+
+// reassembler reassembles NDNLPv2 fragments.
+// It has the same semantics as C Reassembler, with an additional timeout.
+//
+// Partial packets are keyed by the sequence number of the first fragment, so that interleaved
+// fragments of different packets can be reassembled.
+// When the capacity is reached, the least recently updated partial packet is discarded.
+type reassembler struct {
+	capacity int
+	timeout  time.Duration
+	mutex    sync.Mutex
+	table    map[uint64]*partialPacket
+	list     *list.List // least recently updated in front
+	cnt      ReassemblerCounters
+}
+
+func newReassembler(capacity int, timeout time.Duration) *reassembler {
+	return &reassembler{
+		capacity: capacity,
+		timeout:  timeout,
+		table:    map[uint64]*partialPacket{},
+		list:     list.New(),
+	}
+}
+
+// Accept processes a fragment.
+// Returns a reassembled network layer packet, or nil if no packet is ready.
+func (reass *reassembler) Accept(pkt *ndn.Packet, now time.Time) (full *ndn.Packet) {
+	reass.mutex.Lock()
+	defer reass.mutex.Unlock()
+	reass.expire(now)
+
+	frag := pkt.Fragment
+	if frag.FragCount > MaxReassemblerFragments {
+		reass.cnt.DropFragments++
+		return nil
+	}
+
+	seqNumBase := frag.SeqNum - uint64(frag.FragIndex)
+	pp := reass.table[seqNumBase]
+	if pp == nil {
+		reass.insert(pkt, seqNumBase, now)
+		return nil
+	}
+
+	if frag.FragCount != len(pp.frags) { // FragCount changed
+		reass.drop(pp)
+		reass.cnt.DropFragments++
+		return nil
+	}
+
+	if pp.frags[frag.FragIndex] != nil { // duplicate FragIndex
+		reass.cnt.DropFragments++
+		return nil
+	}
+
+	pp.accept(pkt, now)
+	if pp.nAccepted < len(pp.frags) { // waiting for more fragments
+		reass.list.MoveToBack(pp.node)
+		return nil
+	}
+	return reass.reassemble(pp)
+}
+
+func (reass *reassembler) insert(pkt *ndn.Packet, seqNumBase uint64, now time.Time) {
+	if len(reass.table) >= reass.capacity {
+		reass.drop(reass.list.Front().Value.(*partialPacket))
+	}
+
+	pp := &partialPacket{
+		seqNumBase: seqNumBase,
+		frags:      make([][]byte, pkt.Fragment.FragCount),
+	}
+	pp.accept(pkt, now)
+	pp.node = reass.list.PushBack(pp)
+	reass.table[seqNumBase] = pp
+}
+
+func (pp *partialPacket) accept(pkt *ndn.Packet, now time.Time) {
+	if pkt.Fragment.FragIndex == 0 {
+		pp.lp = pkt.Lp
+	}
+	// copy payload, because the transport may reuse its buffer
+	pp.frags[pkt.Fragment.FragIndex] = append([]byte{}, pkt.Fragment.Payload()...)
+	pp.nAccepted++
+	pp.lastUpdate = now
+}
+
+func (reass *reassembler) delete(pp *partialPacket) {
+	delete(reass.table, pp.seqNumBase)
+	reass.list.Remove(pp.node)
+}
+
+func (reass *reassembler) drop(pp *partialPacket) {
+	reass.delete(pp)
+	reass.cnt.DropFragments += uint64(pp.nAccepted)
+}
+
+func (reass *reassembler) expire(now time.Time) {
+	for node := reass.list.Front(); node != nil; node = reass.list.Front() {
+		pp := node.Value.(*partialPacket)
+		if now.Sub(pp.lastUpdate) < reass.timeout {
+			break
+		}
+		reass.drop(pp)
+	}
+}
+
+func (reass *reassembler) reassemble(pp *partialPacket) *ndn.Packet {
+	reass.delete(pp)
+
+	payload := []byte{}
+	for _, frag := range pp.frags {
+		payload = append(payload, frag...)
+	}
+	full, e := ndn.DecodeReassembled(pp.lp, payload)
+	if e != nil {
+		reass.cnt.DropFragments += uint64(pp.nAccepted)
+		return nil
+	}
+
+	reass.cnt.DeliverPackets++
+	reass.cnt.DeliverFragments += uint64(pp.nAccepted)
+	return full
+}
+
+// Counters returns current counters.
+func (reass *reassembler) Counters() ReassemblerCounters {
+	reass.mutex.Lock()
+	defer reass.mutex.Unlock()
+	return reass.cnt
+}
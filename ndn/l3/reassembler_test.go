@@ -0,0 +1,142 @@
+package l3_test
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+type reassemblerFixture struct {
+	t        testing.TB
+	tr       lossyTransport
+	face     l3.Face
+	received chan string
+}
+
+func newReassemblerFixture(t testing.TB, cfg l3.FaceConfig) (f *reassemblerFixture) {
+	_, require := makeAR(t)
+	f = &reassemblerFixture{
+		t:        t,
+		tr:       makeLossyTransport(),
+		received: make(chan string, 256),
+	}
+	var e error
+	f.face, e = l3.NewFace(f.tr, cfg)
+	require.NoError(e)
+	go func() {
+		for pkt := range f.face.Rx() {
+			f.received <- pkt.Data.Name.String()
+		}
+		close(f.received)
+	}()
+	return f
+}
+
+// makeFragments fragments a Data packet and returns encoded fragments.
+func (f *reassemblerFixture) makeFragments(fragmenter *ndn.LpFragmenter, name string) (frames [][]byte) {
+	_, require := makeAR(f.t)
+	data := ndn.MakeData(name, bytes.Repeat([]byte{0xCC}, 2500))
+	frags, e := fragmenter.Fragment(data.ToPacket())
+	require.NoError(e)
+	require.Len(frags, 3)
+	for _, frag := range frags {
+		wire, e := tlv.EncodeFrom(frag)
+		require.NoError(e)
+		frames = append(frames, wire)
+	}
+	return frames
+}
+
+func (f *reassemblerFixture) Close() {
+	close(f.tr.p.Rx)
+	close(f.face.Tx())
+}
+
+func (f *reassemblerFixture) Collect() (names map[string]bool) {
+	names = map[string]bool{}
+	time.Sleep(100 * time.Millisecond)
+	for {
+		select {
+		case name := <-f.received:
+			names[name] = true
+		default:
+			return names
+		}
+	}
+}
+
+func TestReassemblerInterleaved(t *testing.T) {
+	assert, _ := makeAR(t)
+	f := newReassemblerFixture(t, l3.FaceConfig{ReassemblerCapacity: 64})
+	defer f.Close()
+
+	fragmenterA, fragmenterB := ndn.NewLpFragmenter(1000), ndn.NewLpFragmenter(1000)
+	var frames [][]byte
+	for i := 0; i < 20; i++ {
+		frames = append(frames, f.makeFragments(fragmenterA, fmt.Sprintf("/A/%d", i))...)
+		frames = append(frames, f.makeFragments(fragmenterB, fmt.Sprintf("/B/%d", i))...)
+	}
+	rand.Shuffle(len(frames), reflect.Swapper(frames))
+	for _, wire := range frames {
+		f.tr.p.Rx <- wire
+	}
+
+	assert.Len(f.Collect(), 40)
+	cnt := f.face.Counters().Reassembler
+	assert.EqualValues(40, cnt.DeliverPackets)
+	assert.EqualValues(120, cnt.DeliverFragments)
+	assert.EqualValues(0, cnt.DropFragments)
+}
+
+func TestReassemblerCapacity(t *testing.T) {
+	assert, _ := makeAR(t)
+	f := newReassemblerFixture(t, l3.FaceConfig{ReassemblerCapacity: 16})
+	defer f.Close()
+
+	fragmenter := ndn.NewLpFragmenter(1000)
+	var frames [][][]byte
+	for i := 0; i < 20; i++ {
+		frames = append(frames, f.makeFragments(fragmenter, fmt.Sprintf("/C/%d", i)))
+	}
+	for _, packetFrames := range frames {
+		f.tr.p.Rx <- packetFrames[0]
+	}
+	for i := len(frames) - 1; i >= 0; i-- { // newest first, so that no partial packet is evicted
+		f.tr.p.Rx <- frames[i][1]
+		f.tr.p.Rx <- frames[i][2]
+	}
+
+	names := f.Collect()
+	assert.Len(names, 16)
+	for i := 4; i < 20; i++ {
+		assert.True(names[fmt.Sprintf("/8=C/8=%d", i)])
+	}
+	cnt := f.face.Counters().Reassembler
+	assert.EqualValues(16, cnt.DeliverPackets)
+	assert.EqualValues(4, cnt.DropFragments) // first fragments of evicted packets
+}
+
+func TestReassemblerTimeout(t *testing.T) {
+	assert, _ := makeAR(t)
+	f := newReassemblerFixture(t, l3.FaceConfig{ReassemblerTimeout: 50 * time.Millisecond})
+	defer f.Close()
+
+	fragmenter := ndn.NewLpFragmenter(1000)
+	frames := f.makeFragments(fragmenter, "/T")
+	f.tr.p.Rx <- frames[0]
+	f.tr.p.Rx <- frames[1]
+	time.Sleep(100 * time.Millisecond)
+	f.tr.p.Rx <- frames[2]
+
+	assert.Len(f.Collect(), 0)
+	cnt := f.face.Counters().Reassembler
+	assert.EqualValues(0, cnt.DeliverPackets)
+	assert.EqualValues(2, cnt.DropFragments)
+}
@@ -0,0 +1,256 @@
+package l3
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+// Link reliability limits and defaults.
+const (
+	DefaultReliabilityRetxTimeout     = 200 * time.Millisecond
+	DefaultReliabilityMaxRetx         = 3
+	DefaultReliabilityWindow          = 1024
+	DefaultReliabilityIdleAckInterval = 20 * time.Millisecond
+
+	// ReliabilityMaxAcks is the maximum number of Ack fields in an outgoing LpPacket.
+	ReliabilityMaxAcks = 4
+
+	reliabilityOverhead = 0 +
+		1 + 3 + // LpPacket TL increase
+		1 + 3 + // LpPayload TL, if L3 packet was not in an LpPacket
+		(3 + 1 + 8) + // TxSequence
+		ReliabilityMaxAcks*(3+1+8) + // Acks
+		0
+)
+
+// ReliabilityConfig contains NDNLPv2 link reliability configuration.
+//
+// When enabled, each outgoing frame carries a TxSequence field, and is retained until the peer
+// acknowledges it.
+// Acknowledgements of incoming frames are piggybacked on outgoing frames, or sent in IDLE packets
+// when there is no outgoing traffic.
+type ReliabilityConfig struct {
+	// RetxTimeout is the duration before an unacknowledged frame is retransmitted.
+	// Default is DefaultReliabilityRetxTimeout.
+	RetxTimeout time.Duration
+
+	// MaxRetx is the maximum number of retransmissions per frame.
+	// Default is DefaultReliabilityMaxRetx.
+	MaxRetx int
+
+	// Window is the maximum number of unacknowledged frames.
+	// When this limit is exceeded, the oldest unacknowledged frame is abandoned.
+	// Default is DefaultReliabilityWindow.
+	Window int
+
+	// IdleAckInterval is the interval of checking for retransmissions and pending Acks.
+	// Default is DefaultReliabilityIdleAckInterval.
+	IdleAckInterval time.Duration
+}
+
+func (cfg *ReliabilityConfig) applyDefaults() {
+	if cfg.RetxTimeout <= 0 {
+		cfg.RetxTimeout = DefaultReliabilityRetxTimeout
+	}
+	if cfg.MaxRetx <= 0 {
+		cfg.MaxRetx = DefaultReliabilityMaxRetx
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = DefaultReliabilityWindow
+	}
+	if cfg.IdleAckInterval <= 0 {
+		cfg.IdleAckInterval = DefaultReliabilityIdleAckInterval
+	}
+}
+
+// ReliabilityCounters contains link reliability counters.
+type ReliabilityCounters struct {
+	RetxFrames  uint64 `json:"retxFrames"`
+	AckedFrames uint64 `json:"ackedFrames"`
+	LostFrames  uint64 `json:"lostFrames"`
+	AckFields   uint64 `json:"ackFields"`
+	IdleFrames  uint64 `json:"idleFrames"`
+}
+
+type relEntry struct {
+	header  []byte // LpPacket header fields before TxSequence
+	payload []byte // LpPayload TLV
+	sent    time.Time
+	nRetx   int
+}
+
+// reliability implements NDNLPv2 link reliability on the wire encoding of LpPacket.
+type reliability struct {
+	cfg       ReliabilityConfig
+	mutex     sync.Mutex
+	nextTxSeq uint64
+	unacked   map[uint64]*relEntry
+	ackQ      []uint64
+	cnt       ReliabilityCounters
+}
+
+func newReliability(cfg ReliabilityConfig) *reliability {
+	cfg.applyDefaults()
+	return &reliability{
+		cfg:       cfg,
+		nextTxSeq: rand.Uint64(),
+		unacked:   map[uint64]*relEntry{},
+	}
+}
+
+// Rx processes TxSequence and Ack fields of an incoming frame.
+// Returns true if the frame is an IDLE packet that should be dropped.
+func (r *reliability) Rx(wire []byte) (idle bool) {
+	d := tlv.DecodingBuffer(wire)
+	outer, e := d.Element()
+	if e != nil || outer.Type != an.TtLpPacket {
+		return false
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	idle = true
+	d = tlv.DecodingBuffer(outer.Value)
+	for _, de := range d.Elements() {
+		switch de.Type {
+		case an.TtLpTxSequence:
+			if de.Length() == 8 {
+				r.ackQ = append(r.ackQ, binary.BigEndian.Uint64(de.Value))
+			}
+		case an.TtLpAck:
+			if de.Length() == 8 {
+				r.processAck(binary.BigEndian.Uint64(de.Value))
+			}
+		case an.TtLpPayload:
+			idle = false
+		}
+	}
+	return idle
+}
+
+func (r *reliability) processAck(txSeq uint64) {
+	if _, ok := r.unacked[txSeq]; ok {
+		delete(r.unacked, txSeq)
+		r.cnt.AckedFrames++
+	}
+}
+
+// Tx assigns TxSequence and piggybacks Acks on an outgoing frame.
+// The frame may be an LpPacket or a bare L3 packet.
+func (r *reliability) Tx(wire []byte, now time.Time) ([]byte, error) {
+	var entry relEntry
+	d := tlv.DecodingBuffer(wire)
+	outer, e := d.Element()
+	if e != nil {
+		return nil, e
+	}
+	if outer.Type == an.TtLpPacket {
+		d = tlv.DecodingBuffer(outer.Value)
+		for _, de := range d.Elements() {
+			if de.Type == an.TtLpPayload {
+				entry.payload = de.WireAfter()
+				break
+			}
+			entry.header = append(entry.header, de.Wire...)
+		}
+	} else if entry.payload, e = tlv.Encode(tlv.TLVBytes(an.TtLpPayload, wire)); e != nil {
+		return nil, e
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.unacked) >= r.cfg.Window {
+		r.abandonOldest()
+	}
+	return r.send(&entry, now)
+}
+
+func (r *reliability) abandonOldest() {
+	oldest, oldestEntry := uint64(0), (*relEntry)(nil)
+	for txSeq, entry := range r.unacked {
+		if oldestEntry == nil || entry.sent.Before(oldestEntry.sent) {
+			oldest, oldestEntry = txSeq, entry
+		}
+	}
+	delete(r.unacked, oldest)
+	r.cnt.LostFrames++
+}
+
+// send encodes a frame with a new TxSequence and pending Acks, and records it as unacknowledged.
+func (r *reliability) send(entry *relEntry, now time.Time) ([]byte, error) {
+	txSeq := r.nextTxSeq
+	r.nextTxSeq++
+	fields := []tlv.Field{tlv.Bytes(entry.header)}
+	fields = append(fields, r.takeAcks()...)
+	fields = append(fields, tlv.TLVBytes(an.TtLpTxSequence, encodeUint64(txSeq)), tlv.Bytes(entry.payload))
+	wire, e := tlv.Encode(tlv.TLV(an.TtLpPacket, fields...))
+	if e != nil {
+		return nil, e
+	}
+	entry.sent = now
+	r.unacked[txSeq] = entry
+	return wire, nil
+}
+
+func (r *reliability) takeAcks() (fields []tlv.Field) {
+	n := len(r.ackQ)
+	if n > ReliabilityMaxAcks {
+		n = ReliabilityMaxAcks
+	}
+	for _, txSeq := range r.ackQ[:n] {
+		fields = append(fields, tlv.TLVBytes(an.TtLpAck, encodeUint64(txSeq)))
+	}
+	r.ackQ = r.ackQ[n:]
+	r.cnt.AckFields += uint64(n)
+	return fields
+}
+
+// Poll retransmits unacknowledged frames and sends pending Acks in IDLE packets.
+func (r *reliability) Poll(now time.Time) (frames [][]byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for txSeq, entry := range r.unacked {
+		if now.Sub(entry.sent) < r.cfg.RetxTimeout {
+			continue
+		}
+		delete(r.unacked, txSeq)
+		if entry.nRetx >= r.cfg.MaxRetx {
+			r.cnt.LostFrames++
+			continue
+		}
+		entry.nRetx++
+		if wire, e := r.send(entry, now); e == nil {
+			frames = append(frames, wire)
+			r.cnt.RetxFrames++
+		}
+	}
+
+	for len(r.ackQ) > 0 {
+		wire, e := tlv.Encode(tlv.TLV(an.TtLpPacket, r.takeAcks()...))
+		if e != nil {
+			break
+		}
+		frames = append(frames, wire)
+		r.cnt.IdleFrames++
+	}
+	return frames
+}
+
+// Counters returns current counters.
+func (r *reliability) Counters() ReliabilityCounters {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.cnt
+}
+
+func encodeUint64(v uint64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, v)
+	return b
+}
@@ -0,0 +1,87 @@
+package l3_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+)
+
+type lossyTransport struct {
+	*l3.TransportBase
+	p *l3.TransportBasePriv
+}
+
+// connectLossy forwards frames from a to b, dropping every dropEvery-th frame.
+func connectLossy(a, b lossyTransport, dropEvery int) {
+	n := 0
+	for wire := range a.p.Tx {
+		n++
+		if n%dropEvery == 0 {
+			continue
+		}
+		b.p.Rx <- wire
+	}
+	close(b.p.Rx)
+}
+
+func makeLossyTransport() (tr lossyTransport) {
+	tr.TransportBase, tr.p = l3.NewTransportBase(l3.TransportBaseConfig{MTU: 1500})
+	return tr
+}
+
+func TestReliability(t *testing.T) {
+	assert, require := makeAR(t)
+
+	trA, trB := makeLossyTransport(), makeLossyTransport()
+	go connectLossy(trA, trB, 3)
+	go connectLossy(trB, trA, 5)
+
+	relCfg := l3.ReliabilityConfig{
+		RetxTimeout:     50 * time.Millisecond,
+		MaxRetx:         8,
+		IdleAckInterval: 5 * time.Millisecond,
+	}
+	faceA, e := l3.NewFace(trA, l3.FaceConfig{Reliability: &relCfg})
+	require.NoError(e)
+	faceB, e := l3.NewFace(trB, l3.FaceConfig{Reliability: &relCfg})
+	require.NoError(e)
+
+	const nInterests = 100
+	go func() {
+		for i := 0; i < nInterests; i++ {
+			faceA.Tx() <- ndn.MakeInterest(fmt.Sprintf("/A/%d", i))
+		}
+	}()
+
+	received := map[string]bool{}
+	timeout := time.After(5 * time.Second)
+	for len(received) < nInterests {
+		select {
+		case pkt := <-faceB.Rx():
+			require.NotNil(pkt.Interest)
+			received[pkt.Interest.Name.String()] = true
+		case <-timeout:
+			require.FailNow("timeout", "received %d", len(received))
+		}
+	}
+
+	go func() { // drain duplicates caused by lost Acks
+		for range faceB.Rx() {
+		}
+	}()
+	assert.Eventually(func() bool {
+		return faceA.Counters().Reliability.AckedFrames == nInterests
+	}, time.Second, 10*time.Millisecond)
+	cntA := faceA.Counters().Reliability
+	assert.Greater(cntA.RetxFrames, uint64(0))
+	assert.Zero(cntA.LostFrames)
+	cntB := faceB.Counters().Reliability
+	assert.Greater(cntB.AckFields, uint64(nInterests))
+	assert.Greater(cntB.IdleFrames, uint64(0))
+
+	close(faceA.Tx())
+	close(faceB.Tx())
+}
@@ -73,6 +73,11 @@ func (frag LpFragment) String() string {
 	return strconv.FormatUint(frag.SeqNum, 16) + ":" + strconv.Itoa(frag.FragIndex) + ":" + strconv.Itoa(frag.FragCount)
 }
 
+// Payload returns the fragment payload.
+func (frag LpFragment) Payload() []byte {
+	return frag.payload
+}
+
 // Field implements tlv.Fielder interface.
 func (frag LpFragment) Field() tlv.Field {
 	if frag.FragIndex < 0 || frag.FragIndex >= frag.FragCount {
@@ -214,12 +219,15 @@ func (pp *lpPartialPacket) acceptOne(pkt *Packet) {
 }
 
 func (pp *lpPartialPacket) reassemble() (full *Packet, e error) {
-	full = &Packet{Lp: pp.lpl3}
+	return DecodeReassembled(pp.lpl3, bytes.Join(pp.buffer, nil))
+}
 
-	payload := bytes.Join(pp.buffer, nil)
+// DecodeReassembled decodes a network layer packet from concatenated fragment payloads.
+// lp should be the LpL3 of the first fragment.
+func DecodeReassembled(lp LpL3, payload []byte) (full *Packet, e error) {
+	full = &Packet{Lp: lp}
 	if e = full.decodePayload(payload); e != nil {
 		return nil, e
 	}
-
 	return full, nil
 }
@@ -2,13 +2,18 @@ package ndn6file
 
 import (
 	"bytes"
+	"context"
 	"encoding"
+	"errors"
 	"fmt"
 	"io/fs"
 	"time"
 
 	"github.com/usnistgov/ndn-dpdk/ndn"
 	"github.com/usnistgov/ndn-dpdk/ndn/an"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/rdr"
+	"github.com/usnistgov/ndn-dpdk/ndn/segmented"
 )
 
 // KeywordLs is the 32=ls component.
@@ -60,6 +65,35 @@ func (m *DirectoryListing) UnmarshalBinary(value []byte) (e error) {
 	return nil
 }
 
+var errNotDir = errors.New("metadata does not describe a directory")
+
+// RetrieveDirectoryListing retrieves a directory listing from ndn6-file-server.
+// name is the directory name, without 32=ls component.
+//
+// This retrieves the metadata of the directory listing to discover its versioned name, fetches
+// the directory listing as a segmented object until the segment indicated by FinalBlockId, and
+// reassembles the payload of all segments.
+func RetrieveDirectoryListing(ctx context.Context, name ndn.Name, opts segmented.FetchOptions) (ls DirectoryListing, e error) {
+	var m Metadata
+	if e = rdr.RetrieveMetadata(ctx, &m, name.Append(KeywordLs), endpoint.ConsumerOptions{
+		Fw:       opts.Fw,
+		Retx:     endpoint.RetxOptions{Limit: opts.RetxLimit},
+		Verifier: opts.Verifier,
+	}); e != nil {
+		return nil, e
+	}
+	if !m.IsDir() {
+		return nil, errNotDir
+	}
+
+	payload, e := segmented.Fetch(m.Name, opts).Payload(ctx)
+	if e != nil {
+		return nil, e
+	}
+	e = ls.UnmarshalBinary(payload)
+	return ls, e
+}
+
 type directoryEntry struct {
 	name  string
 	isDir bool
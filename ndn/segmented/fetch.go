@@ -42,7 +42,7 @@ type FetchOptions struct {
 	// Default is no limitation.
 	MaxCwnd int
 
-	// Verifier is a public key to verify Data.
+	// Verifier is a public key or trust.Validator to verify Data.
 	// Default is NopVerifier.
 	Verifier ndn.Verifier
 }
@@ -132,7 +132,7 @@ func (f *fetcher) Unordered(ctx context.Context, unordered chan<- *ndn.Data) err
 
 		case l3pkt := <-face.Rx():
 			pkt := l3pkt.ToPacket()
-			if pkt.Data == nil || f.Verifier.Verify(pkt.Data) != nil {
+			if pkt.Data == nil {
 				break
 			}
 			now := time.Now()
@@ -142,7 +142,7 @@ func (f *fetcher) Unordered(ctx context.Context, unordered chan<- *ndn.Data) err
 				break
 			}
 			fs, ok := pendings[seg]
-			if !ok {
+			if !ok || endpoint.VerifyContext(ctx, f.Verifier, pkt.Data) != nil {
 				break
 			}
 			if pkt.Data.FinalBlock.Type == an.TtSegmentNameComponent {
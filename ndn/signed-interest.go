@@ -0,0 +1,233 @@
+package ndn
+
+import (
+	"container/list"
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// SignedInterestPolicy assigns and checks SigNonce, SigTime, and SigSeqNum fields of signed Interests,
+// as defined in Signed Interest format v0.3.
+//
+// On the consumer side, use Signer method to wrap a signer, so that it assigns the enabled fields.
+// On the producer side, use Check method to reject replayed or stale Interests.
+// The same policy instance should not be used on both sides.
+type SignedInterestPolicy struct {
+	// NonceLength is the length of SigNonce.
+	// If positive, the signer assigns random SigNonce of this length, and the checker requires SigNonce
+	// and rejects a SigNonce recently seen from the same key.
+	// Default is disabling SigNonce.
+	NonceLength int
+
+	// NonceHistory is the number of recent SigNonce remembered per key.
+	// Default is 1000.
+	NonceHistory int
+
+	// Time enables SigTime.
+	// If true, the signer assigns current time as SigTime, and the checker requires SigTime to be
+	// within MaxClockOffset from current time and increasing per key.
+	Time bool
+
+	// MaxClockOffset is the maximum difference between SigTime and current time.
+	// Default is 60 seconds.
+	MaxClockOffset time.Duration
+
+	// SeqNum enables SigSeqNum.
+	// If true, the signer assigns increasing SigSeqNum, and the checker requires SigSeqNum to be
+	// increasing per key.
+	SeqNum bool
+
+	// MaxKeys is the maximum number of keys whose state is remembered by the checker.
+	// When exceeded, the least recently used key state is forgotten.
+	// Default is 1000.
+	MaxKeys int
+
+	// Now returns current time.
+	// Default is time.Now.
+	Now func() time.Time
+
+	mutex    sync.Mutex
+	lastTime uint64
+	seqNum   uint64
+	keys     map[string]*list.Element // value is *signedInterestKeyState
+	keysLru  list.List
+}
+
+type signedInterestKeyState struct {
+	keyID  string
+	time   uint64
+	seqNum uint64
+	nonces map[string]bool
+	ring   []string
+	pos    int
+}
+
+func (p *SignedInterestPolicy) now() time.Time {
+	if p.Now == nil {
+		return time.Now()
+	}
+	return p.Now()
+}
+
+// Signer wraps a signer, so that it assigns SigNonce, SigTime, and SigSeqNum according to the policy.
+// The returned signer only modifies Interests; Data packets are passed to the inner signer unchanged.
+func (p *SignedInterestPolicy) Signer(inner Signer) Signer {
+	return signedInterestSigner{p, inner}
+}
+
+func (p *SignedInterestPolicy) assign(si *SigInfo) error {
+	si.Nonce, si.Time, si.SeqNum = nil, 0, 0
+	if p.NonceLength > 0 {
+		si.Nonce = make([]byte, p.NonceLength)
+		if _, e := rand.Read(si.Nonce); e != nil {
+			return e
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.Time {
+		t := uint64(p.now().UnixNano() / int64(time.Millisecond))
+		if t <= p.lastTime {
+			t = p.lastTime + 1
+		}
+		p.lastTime, si.Time = t, t
+	}
+	if p.SeqNum {
+		p.seqNum++
+		si.SeqNum = p.seqNum
+	}
+	return nil
+}
+
+// Check determines whether a signed Interest is acceptable according to the policy.
+// If acceptable, the SigNonce, SigTime, and SigSeqNum are recorded, so that a replayed Interest is rejected.
+// This should be invoked after the Interest signature has been verified.
+func (p *SignedInterestPolicy) Check(interest Interest) error {
+	si := interest.SigInfo
+	if si == nil {
+		return ErrSigType
+	}
+
+	var sigTime time.Time
+	if p.Time {
+		if si.Time == 0 {
+			return ErrSigTime
+		}
+		sigTime = time.Unix(0, int64(si.Time)*int64(time.Millisecond))
+		maxOffset := p.MaxClockOffset
+		if maxOffset <= 0 {
+			maxOffset = 60 * time.Second
+		}
+		if offset := p.now().Sub(sigTime); offset > maxOffset || offset < -maxOffset {
+			return ErrSigTime
+		}
+	}
+	if p.SeqNum && si.SeqNum == 0 {
+		return ErrSigSeqNum
+	}
+	if p.NonceLength > 0 && len(si.Nonce) == 0 {
+		return ErrSigNonce
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.keys == nil {
+		p.keys = map[string]*list.Element{}
+	}
+	keyID := si.KeyLocator.String()
+	elem := p.keys[keyID]
+	var state *signedInterestKeyState
+	if elem == nil {
+		state = &signedInterestKeyState{keyID: keyID, nonces: map[string]bool{}}
+	} else {
+		state = elem.Value.(*signedInterestKeyState)
+	}
+
+	if p.Time && si.Time <= state.time {
+		return ErrSigTime
+	}
+	if p.SeqNum && si.SeqNum <= state.seqNum {
+		return ErrSigSeqNum
+	}
+	nonce := string(si.Nonce)
+	if p.NonceLength > 0 && state.nonces[nonce] {
+		return ErrSigNonce
+	}
+
+	if elem == nil {
+		p.keys[keyID] = p.keysLru.PushFront(state)
+		p.evictKeys()
+	} else {
+		p.keysLru.MoveToFront(elem)
+	}
+	if p.Time {
+		state.time = si.Time
+	}
+	if p.SeqNum {
+		state.seqNum = si.SeqNum
+	}
+	if p.NonceLength > 0 {
+		state.recordNonce(nonce, p.NonceHistory)
+	}
+	return nil
+}
+
+// evictKeys forgets least recently used key states in excess of MaxKeys.
+func (p *SignedInterestPolicy) evictKeys() {
+	maxKeys := p.MaxKeys
+	if maxKeys <= 0 {
+		maxKeys = 1000
+	}
+	for p.keysLru.Len() > maxKeys {
+		state := p.keysLru.Remove(p.keysLru.Back()).(*signedInterestKeyState)
+		delete(p.keys, state.keyID)
+	}
+}
+
+func (state *signedInterestKeyState) recordNonce(nonce string, history int) {
+	if history <= 0 {
+		history = 1000
+	}
+	if len(state.ring) < history {
+		state.ring = append(state.ring, nonce)
+	} else {
+		delete(state.nonces, state.ring[state.pos])
+		state.ring[state.pos] = nonce
+		state.pos = (state.pos + 1) % history
+	}
+	state.nonces[nonce] = true
+}
+
+type signedInterestSigner struct {
+	policy *SignedInterestPolicy
+	inner  Signer
+}
+
+func (signer signedInterestSigner) Sign(packet Signable) error {
+	if _, ok := packet.(*Interest); !ok {
+		return signer.inner.Sign(packet)
+	}
+	return signer.inner.Sign(signedInterestSignable{signer.policy, packet})
+}
+
+// signedInterestSignable intercepts SigInfo of an Interest being signed, and assigns policy fields
+// after the inner signer has filled SigType and KeyLocator.
+type signedInterestSignable struct {
+	policy *SignedInterestPolicy
+	packet Signable
+}
+
+func (s signedInterestSignable) SignWith(signer func(name Name, si *SigInfo) (LLSign, error)) error {
+	return s.packet.SignWith(func(name Name, si *SigInfo) (LLSign, error) {
+		llSign, e := signer(name, si)
+		if e != nil {
+			return nil, e
+		}
+		if e = s.policy.assign(si); e != nil {
+			return nil, e
+		}
+		return llSign, nil
+	})
+}
@@ -0,0 +1,92 @@
+package ndn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/tlv"
+)
+
+func TestSignedInterestPolicy(t *testing.T) {
+	assert, require := makeAR(t)
+
+	now := time.Unix(1600000000, 0)
+	clock := func() time.Time { return now }
+	signerPolicy := &ndn.SignedInterestPolicy{NonceLength: 8, Time: true, SeqNum: true, Now: clock}
+	checkerPolicy := &ndn.SignedInterestPolicy{NonceLength: 8, NonceHistory: 2, Time: true, SeqNum: true, Now: clock}
+	signer := signerPolicy.Signer(ndn.DigestSigning)
+
+	makeSigned := func(name string) ndn.Interest {
+		interest := ndn.MakeInterest(name)
+		require.NoError(signer.Sign(&interest))
+		wire, e := tlv.EncodeFrom(interest)
+		require.NoError(e)
+		var pkt ndn.Packet
+		require.NoError(tlv.Decode(wire, &pkt))
+		require.NotNil(pkt.Interest)
+		assert.NoError(ndn.DigestSigning.Verify(pkt.Interest))
+		return *pkt.Interest
+	}
+
+	i1 := makeSigned("/A/1")
+	require.NotNil(i1.SigInfo)
+	assert.Len(i1.SigInfo.Nonce, 8)
+	assert.EqualValues(1600000000000, i1.SigInfo.Time)
+	assert.EqualValues(1, i1.SigInfo.SeqNum)
+	i2 := makeSigned("/A/2")
+	assert.EqualValues(1600000000001, i2.SigInfo.Time) // SigTime is increasing even if clock is stopped
+	assert.EqualValues(2, i2.SigInfo.SeqNum)
+	assert.NotEqual(i1.SigInfo.Nonce, i2.SigInfo.Nonce)
+
+	assert.NoError(checkerPolicy.Check(i1))
+	assert.ErrorIs(checkerPolicy.Check(i1), ndn.ErrSigTime) // replay
+	assert.NoError(checkerPolicy.Check(i2))
+
+	// unsigned
+	assert.Error(checkerPolicy.Check(ndn.MakeInterest("/A/0")))
+
+	// stale
+	now = now.Add(2 * time.Minute)
+	i3 := makeSigned("/A/3")
+	now = now.Add(2 * time.Minute)
+	assert.ErrorIs(checkerPolicy.Check(i3), ndn.ErrSigTime)
+	i4 := makeSigned("/A/4")
+	assert.NoError(checkerPolicy.Check(i4))
+
+	// SigSeqNum-only policy
+	seqChecker := &ndn.SignedInterestPolicy{SeqNum: true}
+	assert.NoError(seqChecker.Check(i2))
+	assert.ErrorIs(seqChecker.Check(i1), ndn.ErrSigSeqNum)
+	assert.NoError(seqChecker.Check(i3))
+
+	// SigNonce-only policy
+	nonceChecker := &ndn.SignedInterestPolicy{NonceLength: 8, NonceHistory: 2}
+	assert.NoError(nonceChecker.Check(i1))
+	assert.NoError(nonceChecker.Check(i2))
+	assert.ErrorIs(nonceChecker.Check(i2), ndn.ErrSigNonce)
+	assert.NoError(nonceChecker.Check(i3))
+	assert.NoError(nonceChecker.Check(i1)) // evicted from history
+
+	// key state is bounded
+	lruChecker := &ndn.SignedInterestPolicy{SeqNum: true, MaxKeys: 2}
+	makeKeyed := func(key string, seqNum uint64) ndn.Interest {
+		interest := ndn.MakeInterest("/K")
+		interest.SigInfo = &ndn.SigInfo{KeyLocator: ndn.KeyLocator{Name: ndn.ParseName(key)}, SeqNum: seqNum}
+		return interest
+	}
+	assert.NoError(lruChecker.Check(makeKeyed("/K1", 5)))
+	assert.NoError(lruChecker.Check(makeKeyed("/K2", 5)))
+	assert.NoError(lruChecker.Check(makeKeyed("/K1", 6)))
+	assert.NoError(lruChecker.Check(makeKeyed("/K3", 5))) // evicts K2
+	assert.ErrorIs(lruChecker.Check(makeKeyed("/K1", 6)), ndn.ErrSigSeqNum)
+	assert.ErrorIs(lruChecker.Check(makeKeyed("/K3", 5)), ndn.ErrSigSeqNum)
+	assert.NoError(lruChecker.Check(makeKeyed("/K2", 1)))
+
+	// Data is not modified
+	data := ndn.MakeData("/D")
+	require.NoError(signer.Sign(&data))
+	assert.Len(data.SigInfo.Nonce, 0)
+	assert.Zero(data.SigInfo.Time)
+	assert.Zero(data.SigInfo.SeqNum)
+}
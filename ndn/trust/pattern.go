@@ -0,0 +1,161 @@
+package trust
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Error conditions for name patterns.
+var (
+	ErrPattern = errors.New("bad name pattern")
+)
+
+type patternCompKind int
+
+const (
+	patternLiteral patternCompKind = iota
+	patternVariable
+	patternRest
+)
+
+type patternComp struct {
+	kind     patternCompKind
+	literal  ndn.NameComponent
+	variable string
+}
+
+func (pc patternComp) String() string {
+	switch pc.kind {
+	case patternVariable:
+		return "<" + pc.variable + ">"
+	case patternRest:
+		return "<*>"
+	}
+	return pc.literal.String()
+}
+
+// Vars contains variable bindings from name pattern matching.
+type Vars map[string]ndn.NameComponent
+
+// Pattern is a name pattern.
+//
+// Each component of a name pattern is one of:
+//   - A literal name component, which matches the same name component.
+//   - "<_>", which matches any one name component.
+//   - "<id>", where id is an identifier, which matches one name component and binds it to variable id.
+//     If variable id is already bound, the name component must equal the bound value.
+//   - "<*>", which matches zero or more name components.
+//     This may only appear as the last component.
+type Pattern struct {
+	comps []patternComp
+}
+
+// Match determines whether name matches the pattern.
+// vars contains existing variable bindings, which are not modified.
+// If matched, returns updated variable bindings.
+func (p Pattern) Match(name ndn.Name, vars Vars) (matched Vars, ok bool) {
+	matched = Vars{}
+	for k, v := range vars {
+		matched[k] = v
+	}
+
+	for i, pc := range p.comps {
+		if pc.kind == patternRest {
+			return matched, true
+		}
+		if i >= len(name) {
+			return nil, false
+		}
+
+		comp := name[i]
+		switch pc.kind {
+		case patternLiteral:
+			if !pc.literal.Equal(comp) {
+				return nil, false
+			}
+		case patternVariable:
+			if pc.variable == "_" {
+				continue
+			}
+			if bound, ok := matched[pc.variable]; ok {
+				if !bound.Equal(comp) {
+					return nil, false
+				}
+			} else {
+				matched[pc.variable] = comp
+			}
+		}
+	}
+
+	if len(name) != len(p.comps) {
+		return nil, false
+	}
+	return matched, true
+}
+
+// String returns the pattern string.
+func (p Pattern) String() string {
+	if len(p.comps) == 0 {
+		return "/"
+	}
+	var b strings.Builder
+	for _, pc := range p.comps {
+		b.WriteByte('/')
+		b.WriteString(pc.String())
+	}
+	return b.String()
+}
+
+// MarshalText implements encoding.TextMarshaler interface.
+func (p Pattern) MarshalText() (text []byte, e error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler interface.
+func (p *Pattern) UnmarshalText(text []byte) (e error) {
+	*p, e = ParsePattern(string(text))
+	return e
+}
+
+// ParsePattern parses a name pattern from its string representation.
+func ParsePattern(input string) (p Pattern, e error) {
+	input = strings.TrimPrefix(input, "/")
+	if input == "" {
+		return p, nil
+	}
+
+	tokens := strings.Split(input, "/")
+	for i, token := range tokens {
+		var pc patternComp
+		switch {
+		case token == "<*>":
+			if i != len(tokens)-1 {
+				return Pattern{}, ErrPattern
+			}
+			pc.kind = patternRest
+		case strings.HasPrefix(token, "<") && strings.HasSuffix(token, ">"):
+			pc.kind, pc.variable = patternVariable, token[1:len(token)-1]
+			if pc.variable == "" || strings.ContainsAny(pc.variable, "<>*") {
+				return Pattern{}, ErrPattern
+			}
+		default:
+			pc.kind, pc.literal = patternLiteral, ndn.ParseNameComponent(token)
+			if !pc.literal.Valid() {
+				return Pattern{}, ErrPattern
+			}
+		}
+		p.comps = append(p.comps, pc)
+	}
+	return p, nil
+}
+
+// MustParsePattern parses a name pattern, and panics on error.
+func MustParsePattern(input string) Pattern {
+	p, e := ParsePattern(input)
+	if e != nil {
+		panic(e)
+	}
+	return p
+}
@@ -0,0 +1,53 @@
+package trust_test
+
+import (
+	"testing"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/trust"
+)
+
+func TestPattern(t *testing.T) {
+	assert, require := makeAR(t)
+
+	for _, input := range []string{"/A/<*>/B", "/A/<>", "/A/<x*>"} {
+		_, e := trust.ParsePattern(input)
+		assert.ErrorIs(e, trust.ErrPattern, input)
+	}
+
+	p, e := trust.ParsePattern("/A/<user>/<_>/<*>")
+	require.NoError(e)
+	assert.Equal("/8=A/<user>/<_>/<*>", p.String())
+
+	_, ok := p.Match(ndn.ParseName("/A/alice"), nil)
+	assert.False(ok)
+	_, ok = p.Match(ndn.ParseName("/B/alice/1"), nil)
+	assert.False(ok)
+	vars, ok := p.Match(ndn.ParseName("/A/alice/1"), nil)
+	require.True(ok)
+	nameEqual(assert, "/alice", ndn.Name{vars["user"]})
+	vars, ok = p.Match(ndn.ParseName("/A/alice/1/2/3"), trust.Vars{"x": ndn.ParseNameComponent("X")})
+	require.True(ok)
+	assert.Len(vars, 2)
+	_, ok = p.Match(ndn.ParseName("/A/alice/1"), trust.Vars{"user": ndn.ParseNameComponent("bob")})
+	assert.False(ok)
+
+	exact := trust.MustParsePattern("/A/<_>")
+	_, ok = exact.Match(ndn.ParseName("/A/1"), nil)
+	assert.True(ok)
+	_, ok = exact.Match(ndn.ParseName("/A/1/2"), nil)
+	assert.False(ok)
+}
+
+func TestPolicy(t *testing.T) {
+	assert, _ := makeAR(t)
+
+	policy := trust.Policy{
+		trust.MustParseRule("/blog/<user>/article/<*>", "/blog/<user>/KEY/<_>"),
+		trust.MustParseRule("/blog/<user>/KEY/<*>", "/blog/KEY/<_>"),
+	}
+	assert.True(policy.Allows(ndn.ParseName("/blog/alice/article/1"), ndn.ParseName("/blog/alice/KEY/k")))
+	assert.False(policy.Allows(ndn.ParseName("/blog/alice/article/1"), ndn.ParseName("/blog/bob/KEY/k")))
+	assert.True(policy.Allows(ndn.ParseName("/blog/bob/KEY/k/self/1"), ndn.ParseName("/blog/KEY/r")))
+	assert.False(policy.Allows(ndn.ParseName("/blog/bob/comment/1"), ndn.ParseName("/blog/KEY/r")))
+}
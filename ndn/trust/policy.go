@@ -0,0 +1,51 @@
+package trust
+
+import (
+	"github.com/usnistgov/ndn-dpdk/ndn"
+)
+
+// Rule is a name-based trust rule.
+//
+// A rule allows a packet to be signed by a key if the packet name matches Packet pattern, and then the key name
+// matches Signer pattern with variable bindings from the Packet pattern.
+// For example, the following rule allows a user's key to sign the user's articles:
+//
+//	Packet: /blog/<user>/article/<*>
+//	Signer: /blog/<user>/KEY/<_>
+type Rule struct {
+	Packet Pattern `json:"packet"`
+	Signer Pattern `json:"signer"`
+}
+
+// Allows determines whether a packet can be signed by a key according to this rule.
+// keyName should be a key name, not a certificate name.
+func (r Rule) Allows(packetName, keyName ndn.Name) bool {
+	vars, ok := r.Packet.Match(packetName, nil)
+	if !ok {
+		return false
+	}
+	_, ok = r.Signer.Match(keyName, vars)
+	return ok
+}
+
+// MustParseRule constructs a Rule from packet and signer pattern strings, and panics on error.
+func MustParseRule(packet, signer string) Rule {
+	return Rule{
+		Packet: MustParsePattern(packet),
+		Signer: MustParsePattern(signer),
+	}
+}
+
+// Policy is a list of trust rules.
+// A packet is allowed if any rule allows it.
+type Policy []Rule
+
+// Allows determines whether a packet can be signed by a key according to this policy.
+func (p Policy) Allows(packetName, keyName ndn.Name) bool {
+	for _, r := range p {
+		if r.Allows(packetName, keyName) {
+			return true
+		}
+	}
+	return false
+}
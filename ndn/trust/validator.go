@@ -0,0 +1,243 @@
+// Package trust implements name-based trust policies and certificate chain validation.
+package trust
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+)
+
+// Error conditions for validation.
+var (
+	ErrPolicy   = errors.New("packet disallowed by trust policy")
+	ErrExpired  = errors.New("certificate outside ValidityPeriod")
+	ErrDepth    = errors.New("certificate chain too long")
+	ErrCertName = errors.New("retrieved certificate does not match KeyLocator")
+)
+
+var errExtracted = errors.New("extracted")
+
+// ValidatorOptions contains arguments to NewValidator function.
+type ValidatorOptions struct {
+	// Anchors are trusted certificates.
+	// A certificate chain must end at one of these certificates.
+	Anchors []*keychain.Certificate
+
+	// Policy contains trust rules.
+	// Every packet in the certificate chain, except trust anchors, must be allowed by the policy.
+	Policy Policy
+
+	// Fw specifies the L3 Forwarder used for retrieving certificates.
+	// Default is the default Forwarder.
+	Fw l3.Forwarder
+
+	// Retx specifies retransmission policy when retrieving certificates.
+	// Default is disabling retransmission.
+	Retx endpoint.RetxPolicy
+
+	// FetchTimeout is the timeout of retrieving each certificate.
+	// Default is 4 seconds.
+	FetchTimeout time.Duration
+
+	// MaxDepth is the maximum number of retrieved certificates in a chain.
+	// Default is 8.
+	MaxDepth int
+
+	// CacheLifetime is the duration a verified certificate is cached.
+	// A certificate is never cached beyond its ValidityPeriod.
+	// Default is 1 hour.
+	CacheLifetime time.Duration
+
+	// Now returns current time.
+	// Default is time.Now.
+	Now func() time.Time
+}
+
+func (opts *ValidatorOptions) applyDefaults() {
+	if opts.FetchTimeout <= 0 {
+		opts.FetchTimeout = 4 * time.Second
+	}
+	if opts.MaxDepth <= 0 {
+		opts.MaxDepth = 8
+	}
+	if opts.CacheLifetime <= 0 {
+		opts.CacheLifetime = time.Hour
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+}
+
+type cachedCert struct {
+	cert   *keychain.Certificate
+	expiry time.Time
+}
+
+// Validator verifies packets according to a trust policy.
+//
+// To verify a packet, the validator checks the packet against the trust policy, retrieves the certificate named
+// in the KeyLocator, and verifies the signature with the certificate's public key.
+// The certificate itself is verified in the same way, recursively, until reaching a trust anchor.
+// Every certificate in the chain must be within its ValidityPeriod.
+//
+// Validator implements ndn.Verifier, so that it can be used in endpoint.ConsumerOptions and segmented.FetchOptions.
+type Validator struct {
+	opts    ValidatorOptions
+	anchors map[string]*keychain.Certificate // key name URI => anchor
+
+	mutex     sync.Mutex
+	certCache map[string]cachedCert // certificate name URI => verified certificate
+	keyCache  map[string]cachedCert // key name URI => verified certificate
+}
+
+var _ ndn.Verifier = (*Validator)(nil)
+
+// Verify implements ndn.Verifier interface.
+func (v *Validator) Verify(packet ndn.Verifiable) error {
+	return v.VerifyContext(context.Background(), packet)
+}
+
+// VerifyContext verifies a packet, where certificate retrieval is bounded by the context.
+func (v *Validator) VerifyContext(ctx context.Context, packet ndn.Verifiable) error {
+	return v.verify(ctx, packet, 0)
+}
+
+func (v *Validator) verify(ctx context.Context, packet ndn.Verifiable, depth int) error {
+	var name, klName ndn.Name
+	if e := packet.VerifyWith(func(n ndn.Name, si ndn.SigInfo) (ndn.LLVerify, error) {
+		name, klName = n, si.KeyLocator.Name
+		return nil, errExtracted
+	}); e != errExtracted {
+		return e
+	}
+
+	if !keychain.IsKeyName(klName) && !keychain.IsCertName(klName) {
+		return ndn.ErrKeyLocator
+	}
+	if !v.opts.Policy.Allows(name, keychain.ToKeyName(klName)) {
+		return ErrPolicy
+	}
+
+	cert, e := v.findCert(ctx, klName, depth)
+	if e != nil {
+		return e
+	}
+	return cert.PublicKey().Verify(packet)
+}
+
+// findCert returns a verified certificate that matches KeyLocator name.
+func (v *Validator) findCert(ctx context.Context, klName ndn.Name, depth int) (cert *keychain.Certificate, e error) {
+	now := v.opts.Now()
+	keyName := keychain.ToKeyName(klName)
+	isCertName := keychain.IsCertName(klName)
+
+	if anchor := v.anchors[keyName.String()]; anchor != nil && (!isCertName || anchor.Name().Equal(klName)) {
+		if !anchor.Validity().Includes(now) {
+			return nil, ErrExpired
+		}
+		return anchor, nil
+	}
+
+	if cert = v.lookupCache(klName, isCertName, now); cert != nil {
+		return cert, nil
+	}
+
+	if depth >= v.opts.MaxDepth {
+		return nil, ErrDepth
+	}
+
+	interestArgs := []interface{}{klName, v.opts.FetchTimeout}
+	if !isCertName {
+		interestArgs = append(interestArgs, ndn.CanBePrefixFlag, ndn.MustBeFreshFlag)
+	}
+	interest := ndn.MakeInterest(interestArgs...)
+	ctx1, cancel1 := context.WithTimeout(ctx, v.opts.FetchTimeout)
+	defer cancel1()
+	data, e := endpoint.Consume(ctx1, interest, endpoint.ConsumerOptions{
+		Fw:   v.opts.Fw,
+		Retx: v.opts.Retx,
+	})
+	if e != nil {
+		return nil, e
+	}
+
+	if cert, e = keychain.CertFromData(*data); e != nil {
+		return nil, e
+	}
+	if !klName.IsPrefixOf(cert.Name()) {
+		return nil, ErrCertName
+	}
+	if !cert.Validity().Includes(now) {
+		return nil, ErrExpired
+	}
+	if e = v.verify(ctx, data, depth+1); e != nil {
+		return nil, e
+	}
+
+	v.insertCache(cert, now)
+	return cert, nil
+}
+
+func (v *Validator) lookupCache(klName ndn.Name, isCertName bool, now time.Time) *keychain.Certificate {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	cache := v.keyCache
+	if isCertName {
+		cache = v.certCache
+	}
+	uri := klName.String()
+	entry, ok := cache[uri]
+	if !ok {
+		return nil
+	}
+	if now.After(entry.expiry) {
+		delete(cache, uri)
+		return nil
+	}
+	return entry.cert
+}
+
+func (v *Validator) insertCache(cert *keychain.Certificate, now time.Time) {
+	entry := cachedCert{
+		cert:   cert,
+		expiry: now.Add(v.opts.CacheLifetime),
+	}
+	if notAfter := cert.Validity().NotAfter; notAfter.Before(entry.expiry) {
+		entry.expiry = notAfter
+	}
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.certCache[cert.Name().String()] = entry
+	v.keyCache[keychain.ToKeyName(cert.Name()).String()] = entry
+}
+
+// ClearCache erases all cached certificates.
+func (v *Validator) ClearCache() {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.certCache = map[string]cachedCert{}
+	v.keyCache = map[string]cachedCert{}
+}
+
+// NewValidator creates a Validator.
+func NewValidator(opts ValidatorOptions) *Validator {
+	opts.applyDefaults()
+	v := &Validator{
+		opts:      opts,
+		anchors:   map[string]*keychain.Certificate{},
+		certCache: map[string]cachedCert{},
+		keyCache:  map[string]cachedCert{},
+	}
+	for _, anchor := range opts.Anchors {
+		v.anchors[keychain.ToKeyName(anchor.Name()).String()] = anchor
+	}
+	return v
+}
@@ -0,0 +1,121 @@
+package trust_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/usnistgov/ndn-dpdk/ndn"
+	"github.com/usnistgov/ndn-dpdk/ndn/endpoint"
+	"github.com/usnistgov/ndn-dpdk/ndn/keychain"
+	"github.com/usnistgov/ndn-dpdk/ndn/l3"
+	"github.com/usnistgov/ndn-dpdk/ndn/trust"
+)
+
+func TestValidator(t *testing.T) {
+	assert, require := makeAR(t)
+	fw := l3.NewForwarder()
+
+	rootPvt, rootPub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/R"))
+	require.NoError(e)
+	rootCert, e := keychain.MakeCert(rootPub, rootPvt, keychain.MakeCertOptions{})
+	require.NoError(e)
+	sitePvt, sitePub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/R/site"))
+	require.NoError(e)
+	siteCert, e := keychain.MakeCert(sitePub, rootPvt.WithKeyLocator(rootCert.Name()), keychain.MakeCertOptions{})
+	require.NoError(e)
+	alicePvt, alicePub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/R/site/alice"))
+	require.NoError(e)
+	aliceCert, e := keychain.MakeCert(alicePub, sitePvt.WithKeyLocator(siteCert.Name()), keychain.MakeCertOptions{})
+	require.NoError(e)
+	bobPvt, bobPub, e := keychain.NewECDSAKeyPair(ndn.ParseName("/R/site/bob"))
+	require.NoError(e)
+	expiredCert, e := keychain.MakeCert(bobPub, sitePvt.WithKeyLocator(siteCert.Name()), keychain.MakeCertOptions{
+		Validity: keychain.ValidityPeriod{
+			NotBefore: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			NotAfter:  time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	})
+	require.NoError(e)
+
+	var nCertInterests int32
+	certs := []*keychain.Certificate{siteCert, aliceCert, expiredCert}
+	p, e := endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+		Prefix: ndn.ParseName("/R"),
+		Fw:     fw,
+		Handler: func(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+			atomic.AddInt32(&nCertInterests, 1)
+			for _, cert := range certs {
+				if interest.Name.IsPrefixOf(cert.Name()) {
+					return cert.Data(), nil
+				}
+			}
+			return ndn.Data{}, endpoint.ErrExpire
+		},
+	})
+	require.NoError(e)
+	defer p.Close()
+
+	v := trust.NewValidator(trust.ValidatorOptions{
+		Anchors: []*keychain.Certificate{rootCert},
+		Policy: trust.Policy{
+			trust.MustParseRule("/R/<site>/<user>/blog/<*>", "/R/<site>/<user>/KEY/<_>"),
+			trust.MustParseRule("/R/<site>/<user>/KEY/<*>", "/R/<site>/KEY/<_>"),
+			trust.MustParseRule("/R/<site>/KEY/<*>", "/R/KEY/<_>"),
+		},
+		Fw:           fw,
+		FetchTimeout: 200 * time.Millisecond,
+	})
+
+	makeData := func(name string, signer ndn.Signer) *ndn.Data {
+		data := ndn.MakeData(name)
+		require.NoError(signer.Sign(&data))
+		return &data
+	}
+
+	// full chain retrieval
+	assert.NoError(v.Verify(makeData("/R/site/alice/blog/1", alicePvt.WithKeyLocator(aliceCert.Name()))))
+	assert.EqualValues(2, atomic.LoadInt32(&nCertInterests))
+
+	// certificates are cached, KeyLocator can be key name
+	assert.NoError(v.Verify(makeData("/R/site/alice/blog/2", alicePvt)))
+	assert.EqualValues(2, atomic.LoadInt32(&nCertInterests))
+
+	// disallowed by policy
+	assert.ErrorIs(v.Verify(makeData("/R/site/bob/blog/1", alicePvt)), trust.ErrPolicy)
+	assert.ErrorIs(v.Verify(makeData("/R/site/alice/photo/1", alicePvt)), trust.ErrPolicy)
+
+	// bad signature
+	forged := makeData("/R/site/alice/blog/3", bobPvt.WithKeyLocator(aliceCert.Name()))
+	assert.Error(v.Verify(forged))
+
+	// expired certificate
+	assert.ErrorIs(v.Verify(makeData("/R/site/bob/blog/1", bobPvt.WithKeyLocator(expiredCert.Name()))), trust.ErrExpired)
+
+	// no KeyLocator
+	assert.ErrorIs(v.Verify(makeData("/R/site/alice/blog/4", ndn.DigestSigning)), ndn.ErrKeyLocator)
+
+	// certificate retrieval by key name
+	v.ClearCache()
+	assert.NoError(v.Verify(makeData("/R/site/alice/blog/2", alicePvt)))
+
+	// plug into endpoint.Consume
+	p2, e := endpoint.Produce(context.Background(), endpoint.ProducerOptions{
+		Prefix: ndn.ParseName("/R/site/alice/blog"),
+		Fw:     fw,
+		Handler: func(ctx context.Context, interest ndn.Interest) (ndn.Data, error) {
+			return ndn.MakeData(interest), nil
+		},
+		DataSigner: alicePvt,
+	})
+	require.NoError(e)
+	defer p2.Close()
+
+	v.ClearCache()
+	data, e := endpoint.Consume(context.Background(), ndn.MakeInterest("/R/site/alice/blog/5"),
+		endpoint.ConsumerOptions{Fw: fw, Verifier: v})
+	if assert.NoError(e) {
+		nameEqual(assert, "/R/site/alice/blog/5", data)
+	}
+}
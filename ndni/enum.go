@@ -12,6 +12,9 @@ import (
 
 const (
 	// LpHeaderHeadroom is the required headroom to prepend NDNLPv2 header.
+	// Since mempools are shared among faces, this includes LpReliabilityHeadroom even if no face
+	// enables link reliability, but faces without link reliability do not subtract that portion
+	// from their fragment payload size.
 	LpHeaderHeadroom = 0 +
 		1 + 5 + // LpPacket TL
 		1 + 1 + 8 + // SeqNum
@@ -20,8 +23,19 @@ const (
 		1 + 1 + 8 + // PitToken
 		3 + 1 + 3 + 1 + 1 + // Nack
 		3 + 1 + 1 + // CongestionMark
+		LpReliabilityHeadroom +
 		1 + 5 // Payload TL
 
+	// LpReliabilityHeadroom is the portion of LpHeaderHeadroom for link reliability fields.
+	LpReliabilityHeadroom = 0 +
+		LpMaxAcks*(3+1+8) + // Acks
+		3 + 1 + 8 // TxSequence
+
+	// LpMaxAcks is the maximum number of NDNLPv2 Ack fields in a packet.
+	// The link reliability feature piggybacks up to this many Acks on each outgoing frame, and
+	// recognizes up to this many Acks on each incoming frame.
+	LpMaxAcks = 4
+
 	// LpMaxFragments is the maximum number of NDNLPv2 fragments.
 	LpMaxFragments = 31
 